@@ -9,22 +9,27 @@ import (
 	"connectrpc.com/connect"
 	"coupon-issuance-system/gen/coupon"
 	"coupon-issuance-system/gen/coupon/couponconnect"
+	"coupon-issuance-system/internal/clock"
 )
 
 func main() {
+	serverURL := "http://localhost:8080"
 	client := couponconnect.NewCouponServiceClient(
 		http.DefaultClient,
-		"http://localhost:8080",
+		serverURL,
 	)
 	ctx := context.Background()
 
+	// 서버가 -clock-control 로 떠 있으면 서버 시계 기준으로 시작 시간을 정하고, 기다리는 대신 서버 시계를 앞당김
+	serverClock := clock.NewRemote(http.DefaultClient, serverURL)
+
 	// 1. 캠페인 생성
 	fmt.Print("📋 2초 뒤에 시작하는 테스트 캠페인 생성 중... ")
-	startTime := time.Now().Add(2 * time.Second).Unix()
+	startTime := serverClock.Now().Add(2 * time.Second).UnixMilli()
 
 	createReq := connect.NewRequest(&coupon.CreateCampaignRequest{
 		Name:          "데모 캠페인",
-		StartTime:     startTime,
+		StartTimeMs:   startTime,
 		TotalQuantity: 3,
 	})
 
@@ -38,8 +43,11 @@ func main() {
 
 	// 2. 활성화 대기
 	fmt.Print("📋 캠페인 시작시간 대기 중... ")
-	time.Sleep(3 * time.Second)
-	fmt.Println("✅ 완료")
+	if serverClock.Advance(3 * time.Second) {
+		fmt.Println("✅ 완료 (서버 시계를 앞당김)")
+	} else {
+		fmt.Println("✅ 완료")
+	}
 
 	// 3. 쿠폰 발급
 	fmt.Print("📋 쿠폰 발급 중... ")
@@ -48,17 +56,13 @@ func main() {
 		UserId:     "demo-user",
 	})
 
+	// 발급 불가(소진, 기간 아님 등)도 오류 코드로 응답됨 (예: 소진이면 connect.CodeResourceExhausted)
 	issueResp, err := client.IssueCoupon(ctx, issueReq)
 	if err != nil {
-		fmt.Printf("❌ 쿠폰 발급 실패: %v\n", err)
+		fmt.Printf("❌ 쿠폰 발급 실패 (%s): %v\n", connect.CodeOf(err), err)
 		return
 	}
-
-	if issueResp.Msg.Success {
-		fmt.Printf("✅ 쿠폰 발급 완료 (쿠폰코드: %s)\n", issueResp.Msg.Coupon.CouponCode)
-	} else {
-		fmt.Printf("❌ 쿠폰 발급 실패: %s\n", issueResp.Msg.Message)
-	}
+	fmt.Printf("✅ 쿠폰 발급 완료 (쿠폰코드: %s)\n", issueResp.Msg.Coupon.CouponCode)
 
 	// 4. 최종 상태 확인
 	fmt.Print("📋 최종 상태 확인 중... ")
@@ -12,6 +12,7 @@ import (
 	"connectrpc.com/connect"
 	"coupon-issuance-system/gen/coupon"
 	"coupon-issuance-system/gen/coupon/couponconnect"
+	"coupon-issuance-system/internal/clock"
 )
 
 func main() {
@@ -27,10 +28,11 @@ func main() {
 		workerCount, totalRequests, couponLimit)
 
 	client := couponconnect.NewCouponServiceClient(http.DefaultClient, serverURL)
+	serverClock := clock.NewRemote(http.DefaultClient, serverURL) // -clock-control 서버면 활성화를 기다리지 않음
 	ctx := context.Background()
 
 	// 1. 캠페인 생성
-	campaignID := createCampaign(ctx, client, couponLimit)
+	campaignID := createCampaign(ctx, client, serverClock, couponLimit)
 
 	// 2. 부하테스트 실행
 	runLoadTest(ctx, client, campaignID, workerCount, totalRequests)
@@ -39,12 +41,12 @@ func main() {
 	checkResults(ctx, client, campaignID, couponLimit)
 }
 
-func createCampaign(ctx context.Context, client couponconnect.CouponServiceClient, limit int) string {
+func createCampaign(ctx context.Context, client couponconnect.CouponServiceClient, serverClock *clock.Remote, limit int) string {
 	fmt.Print("📋 캠페인 생성 중... ")
 
 	req := connect.NewRequest(&coupon.CreateCampaignRequest{
 		Name:          "부하테스트",
-		StartTime:     time.Now().Add(1 * time.Second).Unix(),
+		StartTimeMs:   serverClock.Now().Add(1 * time.Second).UnixMilli(),
 		TotalQuantity: int32(limit),
 	})
 
@@ -57,8 +59,11 @@ func createCampaign(ctx context.Context, client couponconnect.CouponServiceClien
 
 	// 캠페인 활성화 대기
 	fmt.Print("⏳ 캠페인 활성화 대기... ")
-	time.Sleep(2 * time.Second)
-	fmt.Println("완료")
+	if serverClock.Advance(2 * time.Second) {
+		fmt.Println("완료 (서버 시계를 앞당김)")
+	} else {
+		fmt.Println("완료")
+	}
 
 	return resp.Msg.Campaign.CampaignId
 }
@@ -73,6 +78,7 @@ func runLoadTest(
 
 	var successCount int64
 	var failCount int64
+	var soldOutCount int64 // 실패 중 수량 소진 (정상적인 거절)
 	var wg sync.WaitGroup
 
 	// 작업 큐 생성
@@ -98,12 +104,15 @@ func runLoadTest(
 					UserId:     fmt.Sprintf("user-%d-%d", workerID, requestID),
 				})
 
-				resp, err := client.IssueCoupon(ctx, req)
+				_, err := client.IssueCoupon(ctx, req)
 
-				if err == nil && resp.Msg.Success {
+				if err == nil {
 					atomic.AddInt64(&successCount, 1)
 				} else {
 					atomic.AddInt64(&failCount, 1)
+					if connect.CodeOf(err) == connect.CodeResourceExhausted {
+						atomic.AddInt64(&soldOutCount, 1)
+					}
 				}
 
 				// 진행률 출력 (100개마다)
@@ -122,7 +131,7 @@ func runLoadTest(
 	fmt.Printf("\n✅ 부하테스트 완료!\n")
 	fmt.Printf("   소요시간: %v\n", duration)
 	fmt.Printf("   성공: %d개\n", successCount)
-	fmt.Printf("   실패: %d개\n", failCount)
+	fmt.Printf("   실패: %d개 (수량 소진: %d개)\n", failCount, soldOutCount)
 	fmt.Printf("   RPS: %.0f\n", float64(totalRequests)/duration.Seconds())
 }
 
@@ -36,6 +36,9 @@ const (
 	CampaignStatus_WAITING     CampaignStatus = 1 // 대기중
 	CampaignStatus_ACTIVE      CampaignStatus = 2 // 진행중
 	CampaignStatus_COMPLETED   CampaignStatus = 3 // 완료
+	CampaignStatus_ENDED       CampaignStatus = 4 // 기간 종료 (소진되지 않은 채 end_time 도달)
+	CampaignStatus_PAUSED      CampaignStatus = 5 // 일시 중지 (재개 가능)
+	CampaignStatus_CANCELLED   CampaignStatus = 6 // 취소 (되돌릴 수 없음)
 )
 
 // Enum value maps for CampaignStatus.
@@ -45,12 +48,18 @@ var (
 		1: "WAITING",
 		2: "ACTIVE",
 		3: "COMPLETED",
+		4: "ENDED",
+		5: "PAUSED",
+		6: "CANCELLED",
 	}
 	CampaignStatus_value = map[string]int32{
 		"UNSPECIFIED": 0,
 		"WAITING":     1,
 		"ACTIVE":      2,
 		"COMPLETED":   3,
+		"ENDED":       4,
+		"PAUSED":      5,
+		"CANCELLED":   6,
 	}
 )
 
@@ -81,34 +90,1856 @@ func (CampaignStatus) EnumDescriptor() ([]byte, []int) {
 	return file_proto_coupon_proto_rawDescGZIP(), []int{0}
 }
 
+// enum 값 이름은 패키지 단위로 유일해야 하므로 UNSPECIFIED 대신 접두어를 붙임
+type CouponStatus int32
+
+const (
+	CouponStatus_COUPON_STATUS_UNSPECIFIED CouponStatus = 0 // 기본값 (ISSUED 로 취급)
+	CouponStatus_ISSUED                    CouponStatus = 1 // 발급됨 (사용 가능)
+	CouponStatus_REDEEMED                  CouponStatus = 2 // 사용 완료
+	CouponStatus_EXPIRED                   CouponStatus = 3 // 만료
+	CouponStatus_REVOKED                   CouponStatus = 4 // 회수
+)
+
+// Enum value maps for CouponStatus.
+var (
+	CouponStatus_name = map[int32]string{
+		0: "COUPON_STATUS_UNSPECIFIED",
+		1: "ISSUED",
+		2: "REDEEMED",
+		3: "EXPIRED",
+		4: "REVOKED",
+	}
+	CouponStatus_value = map[string]int32{
+		"COUPON_STATUS_UNSPECIFIED": 0,
+		"ISSUED":                    1,
+		"REDEEMED":                  2,
+		"EXPIRED":                   3,
+		"REVOKED":                   4,
+	}
+)
+
+func (x CouponStatus) Enum() *CouponStatus {
+	p := new(CouponStatus)
+	*p = x
+	return p
+}
+
+func (x CouponStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CouponStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_coupon_proto_enumTypes[1].Descriptor()
+}
+
+func (CouponStatus) Type() protoreflect.EnumType {
+	return &file_proto_coupon_proto_enumTypes[1]
+}
+
+func (x CouponStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CouponStatus.Descriptor instead.
+func (CouponStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_coupon_proto_rawDescGZIP(), []int{1}
+}
+
+// 쿠폰 코드 형식. 전체 길이는 prefix 포함, 구분자 '-' 제외 최대 10자
+type CodeFormat int32
+
+const (
+	CodeFormat_CODE_FORMAT_UNSPECIFIED  CodeFormat = 0 // 기본값 (HANGUL 로 취급)
+	CodeFormat_CODE_FORMAT_HANGUL       CodeFormat = 1 // prefix(생략 시 캠페인명 한글 2~3자) + 한글 2자 + 숫자
+	CodeFormat_CODE_FORMAT_ALPHANUMERIC CodeFormat = 2 // prefix + 영문 대문자/숫자
+	CodeFormat_CODE_FORMAT_UNAMBIGUOUS  CodeFormat = 3 // prefix + 혼동하기 쉬운 문자(0, O, 1, I, L)를 뺀 영문 대문자/숫자
+	CodeFormat_CODE_FORMAT_NUMERIC      CodeFormat = 4 // prefix + 숫자 (고정 길이 PIN)
+	CodeFormat_CODE_FORMAT_TEMPLATE     CodeFormat = 5 // code_template 형식 (예: "{PREFIX}-{AAAA}-{9999}")
+	CodeFormat_CODE_FORMAT_POOL         CodeFormat = 6 // ImportCouponCodes 로 등록한 코드 목록에서 등록 순서대로 발급 (총 수량 = 등록한 코드 수)
+)
+
+// Enum value maps for CodeFormat.
+var (
+	CodeFormat_name = map[int32]string{
+		0: "CODE_FORMAT_UNSPECIFIED",
+		1: "CODE_FORMAT_HANGUL",
+		2: "CODE_FORMAT_ALPHANUMERIC",
+		3: "CODE_FORMAT_UNAMBIGUOUS",
+		4: "CODE_FORMAT_NUMERIC",
+		5: "CODE_FORMAT_TEMPLATE",
+		6: "CODE_FORMAT_POOL",
+	}
+	CodeFormat_value = map[string]int32{
+		"CODE_FORMAT_UNSPECIFIED":  0,
+		"CODE_FORMAT_HANGUL":       1,
+		"CODE_FORMAT_ALPHANUMERIC": 2,
+		"CODE_FORMAT_UNAMBIGUOUS":  3,
+		"CODE_FORMAT_NUMERIC":      4,
+		"CODE_FORMAT_TEMPLATE":     5,
+		"CODE_FORMAT_POOL":         6,
+	}
+)
+
+func (x CodeFormat) Enum() *CodeFormat {
+	p := new(CodeFormat)
+	*p = x
+	return p
+}
+
+func (x CodeFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CodeFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_coupon_proto_enumTypes[2].Descriptor()
+}
+
+func (CodeFormat) Type() protoreflect.EnumType {
+	return &file_proto_coupon_proto_enumTypes[2]
+}
+
+func (x CodeFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CodeFormat.Descriptor instead.
+func (CodeFormat) EnumDescriptor() ([]byte, []int) {
+	return file_proto_coupon_proto_rawDescGZIP(), []int{2}
+}
+
+// 쿠폰 코드 확인 결과
+type CodeCheckResult int32
+
+const (
+	CodeCheckResult_CODE_CHECK_RESULT_UNSPECIFIED CodeCheckResult = 0 // 기본값
+	CodeCheckResult_CODE_CHECK_RESULT_VALID       CodeCheckResult = 1 // 발급된 쿠폰
+	CodeCheckResult_CODE_CHECK_RESULT_MALFORMED   CodeCheckResult = 2 // 형식 또는 검사 문자 오류 (잘못 입력한 코드, 저장소를 조회하지 않음)
+	CodeCheckResult_CODE_CHECK_RESULT_NOT_FOUND   CodeCheckResult = 3 // 형식은 올바르지만 발급되지 않은 코드
+)
+
+// Enum value maps for CodeCheckResult.
+var (
+	CodeCheckResult_name = map[int32]string{
+		0: "CODE_CHECK_RESULT_UNSPECIFIED",
+		1: "CODE_CHECK_RESULT_VALID",
+		2: "CODE_CHECK_RESULT_MALFORMED",
+		3: "CODE_CHECK_RESULT_NOT_FOUND",
+	}
+	CodeCheckResult_value = map[string]int32{
+		"CODE_CHECK_RESULT_UNSPECIFIED": 0,
+		"CODE_CHECK_RESULT_VALID":       1,
+		"CODE_CHECK_RESULT_MALFORMED":   2,
+		"CODE_CHECK_RESULT_NOT_FOUND":   3,
+	}
+)
+
+func (x CodeCheckResult) Enum() *CodeCheckResult {
+	p := new(CodeCheckResult)
+	*p = x
+	return p
+}
+
+func (x CodeCheckResult) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CodeCheckResult) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_coupon_proto_enumTypes[3].Descriptor()
+}
+
+func (CodeCheckResult) Type() protoreflect.EnumType {
+	return &file_proto_coupon_proto_enumTypes[3]
+}
+
+func (x CodeCheckResult) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CodeCheckResult.Descriptor instead.
+func (CodeCheckResult) EnumDescriptor() ([]byte, []int) {
+	return file_proto_coupon_proto_rawDescGZIP(), []int{3}
+}
+
+// 요청 실패 종류. 실패한 RPC 는 Connect 오류 코드와 함께 details 에 ErrorDetail 을 담아 응답하므로
+// 클라이언트는 메시지 문구 대신 reason 으로 분기할 수 있음
+type ErrorReason int32
+
+const (
+	ErrorReason_ERROR_REASON_UNSPECIFIED             ErrorReason = 0
+	ErrorReason_ERROR_REASON_INVALID_ARGUMENT        ErrorReason = 1  // 요청 값 오류 (INVALID_ARGUMENT)
+	ErrorReason_ERROR_REASON_MALFORMED_CODE          ErrorReason = 2  // 형식이나 검사 문자가 틀린 쿠폰 코드 (INVALID_ARGUMENT)
+	ErrorReason_ERROR_REASON_CAMPAIGN_NOT_FOUND      ErrorReason = 3  // 존재하지 않는 캠페인 (NOT_FOUND)
+	ErrorReason_ERROR_REASON_COUPON_NOT_FOUND        ErrorReason = 4  // 존재하지 않는 쿠폰 (NOT_FOUND)
+	ErrorReason_ERROR_REASON_CAMPAIGN_NOT_STARTED    ErrorReason = 5  // 시작 전인 캠페인 (FAILED_PRECONDITION)
+	ErrorReason_ERROR_REASON_CAMPAIGN_ENDED          ErrorReason = 6  // 기간이 끝난 캠페인 (FAILED_PRECONDITION)
+	ErrorReason_ERROR_REASON_CAMPAIGN_PAUSED         ErrorReason = 7  // 일시 중지된 캠페인 (FAILED_PRECONDITION)
+	ErrorReason_ERROR_REASON_CAMPAIGN_CANCELLED      ErrorReason = 8  // 취소된 캠페인 (FAILED_PRECONDITION)
+	ErrorReason_ERROR_REASON_INVALID_CAMPAIGN_STATE  ErrorReason = 9  // 현재 캠페인 상태에서 할 수 없는 변경 (FAILED_PRECONDITION)
+	ErrorReason_ERROR_REASON_CAMPAIGN_SOLD_OUT       ErrorReason = 10 // 쿠폰 소진 (RESOURCE_EXHAUSTED)
+	ErrorReason_ERROR_REASON_USER_LIMIT_EXCEEDED     ErrorReason = 11 // 사용자당 발급 한도 초과 (RESOURCE_EXHAUSTED)
+	ErrorReason_ERROR_REASON_COUPON_NOT_OWNED        ErrorReason = 12 // 다른 사용자에게 발급된 쿠폰 (PERMISSION_DENIED)
+	ErrorReason_ERROR_REASON_COUPON_ALREADY_REDEEMED ErrorReason = 13 // 이미 사용된 쿠폰 (FAILED_PRECONDITION)
+	ErrorReason_ERROR_REASON_COUPON_EXPIRED          ErrorReason = 14 // 만료된 쿠폰 (FAILED_PRECONDITION)
+	ErrorReason_ERROR_REASON_COUPON_REVOKED          ErrorReason = 15 // 회수된 쿠폰 (FAILED_PRECONDITION)
+	ErrorReason_ERROR_REASON_COUPON_UNAVAILABLE      ErrorReason = 16 // 그 밖에 사용할 수 없는 쿠폰 상태 (FAILED_PRECONDITION)
+	ErrorReason_ERROR_REASON_VERSION_CONFLICT        ErrorReason = 17 // 다른 요청이 먼저 수정함. 다시 조회 후 재시도 (ABORTED)
+	ErrorReason_ERROR_REASON_CODE_CONFLICT           ErrorReason = 18 // 이미 사용 중인 쿠폰 코드 (ALREADY_EXISTS)
+)
+
+// Enum value maps for ErrorReason.
+var (
+	ErrorReason_name = map[int32]string{
+		0:  "ERROR_REASON_UNSPECIFIED",
+		1:  "ERROR_REASON_INVALID_ARGUMENT",
+		2:  "ERROR_REASON_MALFORMED_CODE",
+		3:  "ERROR_REASON_CAMPAIGN_NOT_FOUND",
+		4:  "ERROR_REASON_COUPON_NOT_FOUND",
+		5:  "ERROR_REASON_CAMPAIGN_NOT_STARTED",
+		6:  "ERROR_REASON_CAMPAIGN_ENDED",
+		7:  "ERROR_REASON_CAMPAIGN_PAUSED",
+		8:  "ERROR_REASON_CAMPAIGN_CANCELLED",
+		9:  "ERROR_REASON_INVALID_CAMPAIGN_STATE",
+		10: "ERROR_REASON_CAMPAIGN_SOLD_OUT",
+		11: "ERROR_REASON_USER_LIMIT_EXCEEDED",
+		12: "ERROR_REASON_COUPON_NOT_OWNED",
+		13: "ERROR_REASON_COUPON_ALREADY_REDEEMED",
+		14: "ERROR_REASON_COUPON_EXPIRED",
+		15: "ERROR_REASON_COUPON_REVOKED",
+		16: "ERROR_REASON_COUPON_UNAVAILABLE",
+		17: "ERROR_REASON_VERSION_CONFLICT",
+		18: "ERROR_REASON_CODE_CONFLICT",
+	}
+	ErrorReason_value = map[string]int32{
+		"ERROR_REASON_UNSPECIFIED":             0,
+		"ERROR_REASON_INVALID_ARGUMENT":        1,
+		"ERROR_REASON_MALFORMED_CODE":          2,
+		"ERROR_REASON_CAMPAIGN_NOT_FOUND":      3,
+		"ERROR_REASON_COUPON_NOT_FOUND":        4,
+		"ERROR_REASON_CAMPAIGN_NOT_STARTED":    5,
+		"ERROR_REASON_CAMPAIGN_ENDED":          6,
+		"ERROR_REASON_CAMPAIGN_PAUSED":         7,
+		"ERROR_REASON_CAMPAIGN_CANCELLED":      8,
+		"ERROR_REASON_INVALID_CAMPAIGN_STATE":  9,
+		"ERROR_REASON_CAMPAIGN_SOLD_OUT":       10,
+		"ERROR_REASON_USER_LIMIT_EXCEEDED":     11,
+		"ERROR_REASON_COUPON_NOT_OWNED":        12,
+		"ERROR_REASON_COUPON_ALREADY_REDEEMED": 13,
+		"ERROR_REASON_COUPON_EXPIRED":          14,
+		"ERROR_REASON_COUPON_REVOKED":          15,
+		"ERROR_REASON_COUPON_UNAVAILABLE":      16,
+		"ERROR_REASON_VERSION_CONFLICT":        17,
+		"ERROR_REASON_CODE_CONFLICT":           18,
+	}
+)
+
+func (x ErrorReason) Enum() *ErrorReason {
+	p := new(ErrorReason)
+	*p = x
+	return p
+}
+
+func (x ErrorReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ErrorReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_coupon_proto_enumTypes[4].Descriptor()
+}
+
+func (ErrorReason) Type() protoreflect.EnumType {
+	return &file_proto_coupon_proto_enumTypes[4]
+}
+
+func (x ErrorReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ErrorReason.Descriptor instead.
+func (ErrorReason) EnumDescriptor() ([]byte, []int) {
+	return file_proto_coupon_proto_rawDescGZIP(), []int{4}
+}
+
 type Campaign struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
-	CampaignId     string                 `protobuf:"bytes,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`              // 캠페인 고유 ID
-	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`                                            // 캠페인 이름
-	StartTime      int64                  `protobuf:"varint,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`                // 시작 시간 (Unix timestamp)
-	TotalQuantity  int32                  `protobuf:"varint,4,opt,name=total_quantity,json=totalQuantity,proto3" json:"total_quantity,omitempty"`    // 총 발급 가능 수량
-	IssuedQuantity int32                  `protobuf:"varint,5,opt,name=issued_quantity,json=issuedQuantity,proto3" json:"issued_quantity,omitempty"` // 현재 발급된 수량
-	Status         CampaignStatus         `protobuf:"varint,6,opt,name=status,proto3,enum=coupon.CampaignStatus" json:"status,omitempty"`            // 캠페인 상태
-	CreatedAt      int64                  `protobuf:"varint,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`                // 캠페인 생성 시간
+	CampaignId     string                 `protobuf:"bytes,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`                          // 캠페인 고유 ID
+	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`                                                        // 캠페인 이름
+	StartTime      int64                  `protobuf:"varint,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`                            // 시작 시간 (Unix timestamp, 초). start_time_ms 를 초 단위로 내림한 값
+	TotalQuantity  int32                  `protobuf:"varint,4,opt,name=total_quantity,json=totalQuantity,proto3" json:"total_quantity,omitempty"`                // 총 발급 가능 수량
+	IssuedQuantity int32                  `protobuf:"varint,5,opt,name=issued_quantity,json=issuedQuantity,proto3" json:"issued_quantity,omitempty"`             // 현재 발급된 수량
+	Status         CampaignStatus         `protobuf:"varint,6,opt,name=status,proto3,enum=coupon.CampaignStatus" json:"status,omitempty"`                        // 캠페인 상태
+	CreatedAt      int64                  `protobuf:"varint,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`                            // 캠페인 생성 시간
+	MaxPerUser     int32                  `protobuf:"varint,8,opt,name=max_per_user,json=maxPerUser,proto3" json:"max_per_user,omitempty"`                       // 사용자당 최대 발급 수량 (0이면 기본값 1)
+	EndTime        int64                  `protobuf:"varint,9,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`                                  // 종료 시간 (Unix timestamp, 초, 0이면 종료 시간 없음). end_time_ms 를 초 단위로 내림한 값
+	Version        int64                  `protobuf:"varint,10,opt,name=version,proto3" json:"version,omitempty"`                                                // 낙관적 동시성 제어용 버전 (관리자 수정 시에만 증가)
+	CodeSequence   int64                  `protobuf:"varint,11,opt,name=code_sequence,json=codeSequence,proto3" json:"code_sequence,omitempty"`                  // 다음 쿠폰 코드 순번 (순번 기반 코드 생성 시 발급 시도마다 증가)
+	CodeFormat     CodeFormat             `protobuf:"varint,12,opt,name=code_format,json=codeFormat,proto3,enum=coupon.CodeFormat" json:"code_format,omitempty"` // 쿠폰 코드 형식
+	CodeLength     int32                  `protobuf:"varint,13,opt,name=code_length,json=codeLength,proto3" json:"code_length,omitempty"`                        // 코드 전체 길이 (prefix 포함, 0이면 10자. TEMPLATE 은 템플릿이 길이를 정함)
+	CodePrefix     string                 `protobuf:"bytes,14,opt,name=code_prefix,json=codePrefix,proto3" json:"code_prefix,omitempty"`                         // 코드 prefix (HANGUL 은 생성 시 캠페인명으로 정해짐)
+	CodeTemplate   string                 `protobuf:"bytes,15,opt,name=code_template,json=codeTemplate,proto3" json:"code_template,omitempty"`                   // TEMPLATE 형식의 템플릿
+	CodeCheckChar  bool                   `protobuf:"varint,16,opt,name=code_check_char,json=codeCheckChar,proto3" json:"code_check_char,omitempty"`             // 코드 끝에 오타 검출용 검사 문자를 붙임 (HANGUL 형식만)
+	StartTimeMs    int64                  `protobuf:"varint,17,opt,name=start_time_ms,json=startTimeMs,proto3" json:"start_time_ms,omitempty"`                   // 시작 시간 (Unix 밀리초). 발급 시작은 이 값 기준으로 판정
+	EndTimeMs      int64                  `protobuf:"varint,18,opt,name=end_time_ms,json=endTimeMs,proto3" json:"end_time_ms,omitempty"`                         // 종료 시간 (Unix 밀리초, 0이면 종료 시간 없음)
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
 
-func (x *Campaign) Reset() {
-	*x = Campaign{}
-	mi := &file_proto_coupon_proto_msgTypes[0]
+func (x *Campaign) Reset() {
+	*x = Campaign{}
+	mi := &file_proto_coupon_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Campaign) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Campaign) ProtoMessage() {}
+
+func (x *Campaign) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coupon_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Campaign.ProtoReflect.Descriptor instead.
+func (*Campaign) Descriptor() ([]byte, []int) {
+	return file_proto_coupon_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Campaign) GetCampaignId() string {
+	if x != nil {
+		return x.CampaignId
+	}
+	return ""
+}
+
+func (x *Campaign) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Campaign) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *Campaign) GetTotalQuantity() int32 {
+	if x != nil {
+		return x.TotalQuantity
+	}
+	return 0
+}
+
+func (x *Campaign) GetIssuedQuantity() int32 {
+	if x != nil {
+		return x.IssuedQuantity
+	}
+	return 0
+}
+
+func (x *Campaign) GetStatus() CampaignStatus {
+	if x != nil {
+		return x.Status
+	}
+	return CampaignStatus_UNSPECIFIED
+}
+
+func (x *Campaign) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Campaign) GetMaxPerUser() int32 {
+	if x != nil {
+		return x.MaxPerUser
+	}
+	return 0
+}
+
+func (x *Campaign) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *Campaign) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Campaign) GetCodeSequence() int64 {
+	if x != nil {
+		return x.CodeSequence
+	}
+	return 0
+}
+
+func (x *Campaign) GetCodeFormat() CodeFormat {
+	if x != nil {
+		return x.CodeFormat
+	}
+	return CodeFormat_CODE_FORMAT_UNSPECIFIED
+}
+
+func (x *Campaign) GetCodeLength() int32 {
+	if x != nil {
+		return x.CodeLength
+	}
+	return 0
+}
+
+func (x *Campaign) GetCodePrefix() string {
+	if x != nil {
+		return x.CodePrefix
+	}
+	return ""
+}
+
+func (x *Campaign) GetCodeTemplate() string {
+	if x != nil {
+		return x.CodeTemplate
+	}
+	return ""
+}
+
+func (x *Campaign) GetCodeCheckChar() bool {
+	if x != nil {
+		return x.CodeCheckChar
+	}
+	return false
+}
+
+func (x *Campaign) GetStartTimeMs() int64 {
+	if x != nil {
+		return x.StartTimeMs
+	}
+	return 0
+}
+
+func (x *Campaign) GetEndTimeMs() int64 {
+	if x != nil {
+		return x.EndTimeMs
+	}
+	return 0
+}
+
+type Coupon struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CouponCode    string                 `protobuf:"bytes,1,opt,name=coupon_code,json=couponCode,proto3" json:"coupon_code,omitempty"`          // 쿠폰 고유 코드 (최대 10자)
+	CampaignId    string                 `protobuf:"bytes,2,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`          // 소속 캠페인 ID
+	IssuedAt      int64                  `protobuf:"varint,3,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at,omitempty"`               // 발급 시간 (Unix timestamp, 초)
+	IssuedTo      string                 `protobuf:"bytes,4,opt,name=issued_to,json=issuedTo,proto3" json:"issued_to,omitempty"`                // 발급 대상 (사용자 ID)
+	Status        CouponStatus           `protobuf:"varint,5,opt,name=status,proto3,enum=coupon.CouponStatus" json:"status,omitempty"`          // 쿠폰 상태
+	RedeemedAt    int64                  `protobuf:"varint,6,opt,name=redeemed_at,json=redeemedAt,proto3" json:"redeemed_at,omitempty"`         // 사용 시간 (Unix timestamp, 초, 사용 시에만)
+	OrderId       string                 `protobuf:"bytes,7,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`                   // 사용된 주문 ID (사용 시에만)
+	IssuedAtMs    int64                  `protobuf:"varint,8,opt,name=issued_at_ms,json=issuedAtMs,proto3" json:"issued_at_ms,omitempty"`       // 발급 시간 (Unix 밀리초)
+	RedeemedAtMs  int64                  `protobuf:"varint,9,opt,name=redeemed_at_ms,json=redeemedAtMs,proto3" json:"redeemed_at_ms,omitempty"` // 사용 시간 (Unix 밀리초, 사용 시에만)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Coupon) Reset() {
+	*x = Coupon{}
+	mi := &file_proto_coupon_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Coupon) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Coupon) ProtoMessage() {}
+
+func (x *Coupon) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coupon_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Coupon.ProtoReflect.Descriptor instead.
+func (*Coupon) Descriptor() ([]byte, []int) {
+	return file_proto_coupon_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Coupon) GetCouponCode() string {
+	if x != nil {
+		return x.CouponCode
+	}
+	return ""
+}
+
+func (x *Coupon) GetCampaignId() string {
+	if x != nil {
+		return x.CampaignId
+	}
+	return ""
+}
+
+func (x *Coupon) GetIssuedAt() int64 {
+	if x != nil {
+		return x.IssuedAt
+	}
+	return 0
+}
+
+func (x *Coupon) GetIssuedTo() string {
+	if x != nil {
+		return x.IssuedTo
+	}
+	return ""
+}
+
+func (x *Coupon) GetStatus() CouponStatus {
+	if x != nil {
+		return x.Status
+	}
+	return CouponStatus_COUPON_STATUS_UNSPECIFIED
+}
+
+func (x *Coupon) GetRedeemedAt() int64 {
+	if x != nil {
+		return x.RedeemedAt
+	}
+	return 0
+}
+
+func (x *Coupon) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Coupon) GetIssuedAtMs() int64 {
+	if x != nil {
+		return x.IssuedAtMs
+	}
+	return 0
+}
+
+func (x *Coupon) GetRedeemedAtMs() int64 {
+	if x != nil {
+		return x.RedeemedAtMs
+	}
+	return 0
+}
+
+type CreateCampaignRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                                       // 캠페인 이름
+	StartTime     int64                  `protobuf:"varint,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`                           // 쿠폰 발급 시작 시간 (Unix timestamp, 초. start_time_ms 를 보내면 무시)
+	TotalQuantity int32                  `protobuf:"varint,3,opt,name=total_quantity,json=totalQuantity,proto3" json:"total_quantity,omitempty"`               // 총 발급할 쿠폰 수량
+	MaxPerUser    int32                  `protobuf:"varint,4,opt,name=max_per_user,json=maxPerUser,proto3" json:"max_per_user,omitempty"`                      // 사용자당 최대 발급 수량 (생략 시 1)
+	EndTime       int64                  `protobuf:"varint,5,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`                                 // 쿠폰 발급 종료 시간 (Unix timestamp, 초. 생략 시 종료 시간 없음, end_time_ms 를 보내면 무시)
+	CodeFormat    CodeFormat             `protobuf:"varint,6,opt,name=code_format,json=codeFormat,proto3,enum=coupon.CodeFormat" json:"code_format,omitempty"` // 쿠폰 코드 형식 (생략 시 HANGUL)
+	CodeLength    int32                  `protobuf:"varint,7,opt,name=code_length,json=codeLength,proto3" json:"code_length,omitempty"`                        // 코드 전체 길이 (prefix 포함, 생략 시 10자)
+	CodePrefix    string                 `protobuf:"bytes,8,opt,name=code_prefix,json=codePrefix,proto3" json:"code_prefix,omitempty"`                         // 코드 prefix (영문/숫자/한글. HANGUL 형식에서 생략 시 캠페인명 한글 2~3자)
+	CodeTemplate  string                 `protobuf:"bytes,9,opt,name=code_template,json=codeTemplate,proto3" json:"code_template,omitempty"`                   // TEMPLATE 형식의 템플릿. {PREFIX}, {A..}(영문), {9..}(숫자), {X..}(영숫자), {H..}(한글), '-'
+	CodeCheckChar bool                   `protobuf:"varint,10,opt,name=code_check_char,json=codeCheckChar,proto3" json:"code_check_char,omitempty"`            // 코드 끝에 오타 검출용 검사 문자를 붙임 (HANGUL 형식만, 길이는 10자 안에 포함)
+	StartTimeMs   int64                  `protobuf:"varint,11,opt,name=start_time_ms,json=startTimeMs,proto3" json:"start_time_ms,omitempty"`                  // 쿠폰 발급 시작 시간 (Unix 밀리초. 보내면 start_time 대신 사용)
+	EndTimeMs     int64                  `protobuf:"varint,12,opt,name=end_time_ms,json=endTimeMs,proto3" json:"end_time_ms,omitempty"`                        // 쿠폰 발급 종료 시간 (Unix 밀리초. 보내면 end_time 대신 사용)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCampaignRequest) Reset() {
+	*x = CreateCampaignRequest{}
+	mi := &file_proto_coupon_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCampaignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCampaignRequest) ProtoMessage() {}
+
+func (x *CreateCampaignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coupon_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCampaignRequest.ProtoReflect.Descriptor instead.
+func (*CreateCampaignRequest) Descriptor() ([]byte, []int) {
+	return file_proto_coupon_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateCampaignRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateCampaignRequest) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *CreateCampaignRequest) GetTotalQuantity() int32 {
+	if x != nil {
+		return x.TotalQuantity
+	}
+	return 0
+}
+
+func (x *CreateCampaignRequest) GetMaxPerUser() int32 {
+	if x != nil {
+		return x.MaxPerUser
+	}
+	return 0
+}
+
+func (x *CreateCampaignRequest) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *CreateCampaignRequest) GetCodeFormat() CodeFormat {
+	if x != nil {
+		return x.CodeFormat
+	}
+	return CodeFormat_CODE_FORMAT_UNSPECIFIED
+}
+
+func (x *CreateCampaignRequest) GetCodeLength() int32 {
+	if x != nil {
+		return x.CodeLength
+	}
+	return 0
+}
+
+func (x *CreateCampaignRequest) GetCodePrefix() string {
+	if x != nil {
+		return x.CodePrefix
+	}
+	return ""
+}
+
+func (x *CreateCampaignRequest) GetCodeTemplate() string {
+	if x != nil {
+		return x.CodeTemplate
+	}
+	return ""
+}
+
+func (x *CreateCampaignRequest) GetCodeCheckChar() bool {
+	if x != nil {
+		return x.CodeCheckChar
+	}
+	return false
+}
+
+func (x *CreateCampaignRequest) GetStartTimeMs() int64 {
+	if x != nil {
+		return x.StartTimeMs
+	}
+	return 0
+}
+
+func (x *CreateCampaignRequest) GetEndTimeMs() int64 {
+	if x != nil {
+		return x.EndTimeMs
+	}
+	return 0
+}
+
+type CreateCampaignResponse struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Campaign             *Campaign              `protobuf:"bytes,1,opt,name=campaign,proto3" json:"campaign,omitempty"`                                                       // 생성된 캠페인 정보
+	Message              string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`                                                         // 성공/실패 메시지
+	CodeKeyspace         int64                  `protobuf:"varint,3,opt,name=code_keyspace,json=codeKeyspace,proto3" json:"code_keyspace,omitempty"`                          // 코드 형식이 만들 수 있는 코드 수
+	CodeSpaceReserved    int64                  `protobuf:"varint,4,opt,name=code_space_reserved,json=codeSpaceReserved,proto3" json:"code_space_reserved,omitempty"`         // 같은 코드 공간을 쓰는 다른 캠페인이 이미 차지했거나 차지할 수 있는 코드 수
+	CodeSpaceUsage       float64                `protobuf:"fixed64,5,opt,name=code_space_usage,json=codeSpaceUsage,proto3" json:"code_space_usage,omitempty"`                 // 이 캠페인까지 발급되었을 때의 코드 공간 사용률 (0~1)
+	CollisionProbability float64                `protobuf:"fixed64,6,opt,name=collision_probability,json=collisionProbability,proto3" json:"collision_probability,omitempty"` // 발급 중 무작위 코드가 한 번 이상 충돌(재생성)할 확률 추정치
+	Warning              string                 `protobuf:"bytes,7,opt,name=warning,proto3" json:"warning,omitempty"`                                                         // 코드 공간 사용률 경고 (캠페인은 생성됨)
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *CreateCampaignResponse) Reset() {
+	*x = CreateCampaignResponse{}
+	mi := &file_proto_coupon_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCampaignResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCampaignResponse) ProtoMessage() {}
+
+func (x *CreateCampaignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coupon_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCampaignResponse.ProtoReflect.Descriptor instead.
+func (*CreateCampaignResponse) Descriptor() ([]byte, []int) {
+	return file_proto_coupon_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateCampaignResponse) GetCampaign() *Campaign {
+	if x != nil {
+		return x.Campaign
+	}
+	return nil
+}
+
+func (x *CreateCampaignResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CreateCampaignResponse) GetCodeKeyspace() int64 {
+	if x != nil {
+		return x.CodeKeyspace
+	}
+	return 0
+}
+
+func (x *CreateCampaignResponse) GetCodeSpaceReserved() int64 {
+	if x != nil {
+		return x.CodeSpaceReserved
+	}
+	return 0
+}
+
+func (x *CreateCampaignResponse) GetCodeSpaceUsage() float64 {
+	if x != nil {
+		return x.CodeSpaceUsage
+	}
+	return 0
+}
+
+func (x *CreateCampaignResponse) GetCollisionProbability() float64 {
+	if x != nil {
+		return x.CollisionProbability
+	}
+	return 0
+}
+
+func (x *CreateCampaignResponse) GetWarning() string {
+	if x != nil {
+		return x.Warning
+	}
+	return ""
+}
+
+type GetCampaignRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId     string                 `protobuf:"bytes,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`              // 조회할 캠페인 ID
+	ExcludeCoupons bool                   `protobuf:"varint,2,opt,name=exclude_coupons,json=excludeCoupons,proto3" json:"exclude_coupons,omitempty"` // true 면 issued_coupons 를 생략하고 캠페인 카운터만 반환
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetCampaignRequest) Reset() {
+	*x = GetCampaignRequest{}
+	mi := &file_proto_coupon_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCampaignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCampaignRequest) ProtoMessage() {}
+
+func (x *GetCampaignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coupon_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCampaignRequest.ProtoReflect.Descriptor instead.
+func (*GetCampaignRequest) Descriptor() ([]byte, []int) {
+	return file_proto_coupon_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetCampaignRequest) GetCampaignId() string {
+	if x != nil {
+		return x.CampaignId
+	}
+	return ""
+}
+
+func (x *GetCampaignRequest) GetExcludeCoupons() bool {
+	if x != nil {
+		return x.ExcludeCoupons
+	}
+	return false
+}
+
+type GetCampaignResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Campaign      *Campaign              `protobuf:"bytes,1,opt,name=campaign,proto3" json:"campaign,omitempty"`                                // 캠페인 기본 정보
+	IssuedCoupons []*Coupon              `protobuf:"bytes,2,rep,name=issued_coupons,json=issuedCoupons,proto3" json:"issued_coupons,omitempty"` // 발급된 쿠폰들 (repeated = 배열)
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`                                  // 성공/실패 메시지
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCampaignResponse) Reset() {
+	*x = GetCampaignResponse{}
+	mi := &file_proto_coupon_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCampaignResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCampaignResponse) ProtoMessage() {}
+
+func (x *GetCampaignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coupon_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCampaignResponse.ProtoReflect.Descriptor instead.
+func (*GetCampaignResponse) Descriptor() ([]byte, []int) {
+	return file_proto_coupon_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetCampaignResponse) GetCampaign() *Campaign {
+	if x != nil {
+		return x.Campaign
+	}
+	return nil
+}
+
+func (x *GetCampaignResponse) GetIssuedCoupons() []*Coupon {
+	if x != nil {
+		return x.IssuedCoupons
+	}
+	return nil
+}
+
+func (x *GetCampaignResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type IssueCouponRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId     string                 `protobuf:"bytes,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`             // 대상 캠페인 ID
+	UserId         string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                         // 요청자 ID
+	IdempotencyKey string                 `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"` // 재시도 식별 키 (선택). 같은 키의 재요청은 최초 응답을 그대로 반환
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *IssueCouponRequest) Reset() {
+	*x = IssueCouponRequest{}
+	mi := &file_proto_coupon_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueCouponRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueCouponRequest) ProtoMessage() {}
+
+func (x *IssueCouponRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coupon_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueCouponRequest.ProtoReflect.Descriptor instead.
+func (*IssueCouponRequest) Descriptor() ([]byte, []int) {
+	return file_proto_coupon_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *IssueCouponRequest) GetCampaignId() string {
+	if x != nil {
+		return x.CampaignId
+	}
+	return ""
+}
+
+func (x *IssueCouponRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *IssueCouponRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type IssueCouponResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"` // 발급 성공 여부
+	Coupon        *Coupon                `protobuf:"bytes,2,opt,name=coupon,proto3" json:"coupon,omitempty"`    // 발급된 쿠폰 (성공 시에만)
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`  // 성공/실패 메시지
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssueCouponResponse) Reset() {
+	*x = IssueCouponResponse{}
+	mi := &file_proto_coupon_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueCouponResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueCouponResponse) ProtoMessage() {}
+
+func (x *IssueCouponResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coupon_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueCouponResponse.ProtoReflect.Descriptor instead.
+func (*IssueCouponResponse) Descriptor() ([]byte, []int) {
+	return file_proto_coupon_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *IssueCouponResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *IssueCouponResponse) GetCoupon() *Coupon {
+	if x != nil {
+		return x.Coupon
+	}
+	return nil
+}
+
+func (x *IssueCouponResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type RedeemCouponRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CouponCode    string                 `protobuf:"bytes,1,opt,name=coupon_code,json=couponCode,proto3" json:"coupon_code,omitempty"` // 사용할 쿠폰 코드
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`             // 사용자 ID (발급 대상과 일치해야 함)
+	OrderId       string                 `protobuf:"bytes,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`          // 쿠폰이 적용되는 주문 ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedeemCouponRequest) Reset() {
+	*x = RedeemCouponRequest{}
+	mi := &file_proto_coupon_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedeemCouponRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedeemCouponRequest) ProtoMessage() {}
+
+func (x *RedeemCouponRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coupon_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedeemCouponRequest.ProtoReflect.Descriptor instead.
+func (*RedeemCouponRequest) Descriptor() ([]byte, []int) {
+	return file_proto_coupon_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RedeemCouponRequest) GetCouponCode() string {
+	if x != nil {
+		return x.CouponCode
+	}
+	return ""
+}
+
+func (x *RedeemCouponRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RedeemCouponRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type RedeemCouponResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"` // 사용 성공 여부
+	Coupon        *Coupon                `protobuf:"bytes,2,opt,name=coupon,proto3" json:"coupon,omitempty"`    // 사용 처리된 쿠폰 (성공 시에만)
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`  // 성공/실패 메시지
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedeemCouponResponse) Reset() {
+	*x = RedeemCouponResponse{}
+	mi := &file_proto_coupon_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedeemCouponResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedeemCouponResponse) ProtoMessage() {}
+
+func (x *RedeemCouponResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coupon_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedeemCouponResponse.ProtoReflect.Descriptor instead.
+func (*RedeemCouponResponse) Descriptor() ([]byte, []int) {
+	return file_proto_coupon_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RedeemCouponResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RedeemCouponResponse) GetCoupon() *Coupon {
+	if x != nil {
+		return x.Coupon
+	}
+	return nil
+}
+
+func (x *RedeemCouponResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ListCampaignsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Statuses      []CampaignStatus       `protobuf:"varint,1,rep,packed,name=statuses,proto3,enum=coupon.CampaignStatus" json:"statuses,omitempty"` // 상태 필터 (비어있으면 전체)
+	NameContains  string                 `protobuf:"bytes,2,opt,name=name_contains,json=nameContains,proto3" json:"name_contains,omitempty"`        // 이름 부분 일치 필터
+	StartTimeFrom int64                  `protobuf:"varint,3,opt,name=start_time_from,json=startTimeFrom,proto3" json:"start_time_from,omitempty"`  // 시작 시간 하한 (포함, 0이면 제한 없음)
+	StartTimeTo   int64                  `protobuf:"varint,4,opt,name=start_time_to,json=startTimeTo,proto3" json:"start_time_to,omitempty"`        // 시작 시간 상한 (포함, 0이면 제한 없음)
+	PageSize      int32                  `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`                   // 페이지 크기 (0이면 기본값 20, 최대 100)
+	PageToken     string                 `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`                 // 이전 응답의 next_page_token (첫 페이지는 빈 값)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCampaignsRequest) Reset() {
+	*x = ListCampaignsRequest{}
+	mi := &file_proto_coupon_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCampaignsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCampaignsRequest) ProtoMessage() {}
+
+func (x *ListCampaignsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coupon_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCampaignsRequest.ProtoReflect.Descriptor instead.
+func (*ListCampaignsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_coupon_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListCampaignsRequest) GetStatuses() []CampaignStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *ListCampaignsRequest) GetNameContains() string {
+	if x != nil {
+		return x.NameContains
+	}
+	return ""
+}
+
+func (x *ListCampaignsRequest) GetStartTimeFrom() int64 {
+	if x != nil {
+		return x.StartTimeFrom
+	}
+	return 0
+}
+
+func (x *ListCampaignsRequest) GetStartTimeTo() int64 {
+	if x != nil {
+		return x.StartTimeTo
+	}
+	return 0
+}
+
+func (x *ListCampaignsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListCampaignsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListCampaignsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Campaigns     []*Campaign            `protobuf:"bytes,1,rep,name=campaigns,proto3" json:"campaigns,omitempty"`                                // created_at 오름차순
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // 다음 페이지 토큰 (마지막 페이지면 빈 값)
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`                                    // 성공/실패 메시지
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCampaignsResponse) Reset() {
+	*x = ListCampaignsResponse{}
+	mi := &file_proto_coupon_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCampaignsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCampaignsResponse) ProtoMessage() {}
+
+func (x *ListCampaignsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coupon_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCampaignsResponse.ProtoReflect.Descriptor instead.
+func (*ListCampaignsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_coupon_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListCampaignsResponse) GetCampaigns() []*Campaign {
+	if x != nil {
+		return x.Campaigns
+	}
+	return nil
+}
+
+func (x *ListCampaignsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListCampaignsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ListIssuedCouponsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId    string                 `protobuf:"bytes,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"` // 조회할 캠페인 ID
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`      // 페이지 크기 (0이면 기본값 20, 최대 100)
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`    // 이전 응답의 next_page_token (첫 페이지는 빈 값)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListIssuedCouponsRequest) Reset() {
+	*x = ListIssuedCouponsRequest{}
+	mi := &file_proto_coupon_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIssuedCouponsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIssuedCouponsRequest) ProtoMessage() {}
+
+func (x *ListIssuedCouponsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coupon_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIssuedCouponsRequest.ProtoReflect.Descriptor instead.
+func (*ListIssuedCouponsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_coupon_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListIssuedCouponsRequest) GetCampaignId() string {
+	if x != nil {
+		return x.CampaignId
+	}
+	return ""
+}
+
+func (x *ListIssuedCouponsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListIssuedCouponsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListIssuedCouponsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Coupons       []*Coupon              `protobuf:"bytes,1,rep,name=coupons,proto3" json:"coupons,omitempty"`                                    // 발급 순서대로 정렬
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // 다음 페이지 토큰 (마지막 페이지면 빈 값)
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`                                    // 성공/실패 메시지
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListIssuedCouponsResponse) Reset() {
+	*x = ListIssuedCouponsResponse{}
+	mi := &file_proto_coupon_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIssuedCouponsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIssuedCouponsResponse) ProtoMessage() {}
+
+func (x *ListIssuedCouponsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coupon_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIssuedCouponsResponse.ProtoReflect.Descriptor instead.
+func (*ListIssuedCouponsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_coupon_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListIssuedCouponsResponse) GetCoupons() []*Coupon {
+	if x != nil {
+		return x.Coupons
+	}
+	return nil
+}
+
+func (x *ListIssuedCouponsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListIssuedCouponsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type StreamIssuedCouponsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId    string                 `protobuf:"bytes,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"` // 조회할 캠페인 ID
+	BatchSize     int32                  `protobuf:"varint,2,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`   // 메시지당 쿠폰 수 (0이면 기본값 100, 최대 1000)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamIssuedCouponsRequest) Reset() {
+	*x = StreamIssuedCouponsRequest{}
+	mi := &file_proto_coupon_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamIssuedCouponsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamIssuedCouponsRequest) ProtoMessage() {}
+
+func (x *StreamIssuedCouponsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coupon_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamIssuedCouponsRequest.ProtoReflect.Descriptor instead.
+func (*StreamIssuedCouponsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_coupon_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *StreamIssuedCouponsRequest) GetCampaignId() string {
+	if x != nil {
+		return x.CampaignId
+	}
+	return ""
+}
+
+func (x *StreamIssuedCouponsRequest) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+type StreamIssuedCouponsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Coupons       []*Coupon              `protobuf:"bytes,1,rep,name=coupons,proto3" json:"coupons,omitempty"` // 발급 순서대로 정렬된 쿠폰 묶음
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamIssuedCouponsResponse) Reset() {
+	*x = StreamIssuedCouponsResponse{}
+	mi := &file_proto_coupon_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamIssuedCouponsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamIssuedCouponsResponse) ProtoMessage() {}
+
+func (x *StreamIssuedCouponsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coupon_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamIssuedCouponsResponse.ProtoReflect.Descriptor instead.
+func (*StreamIssuedCouponsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_coupon_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *StreamIssuedCouponsResponse) GetCoupons() []*Coupon {
+	if x != nil {
+		return x.Coupons
+	}
+	return nil
+}
+
+type PauseCampaignRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId    string                 `protobuf:"bytes,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"` // 일시 중지할 캠페인 ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseCampaignRequest) Reset() {
+	*x = PauseCampaignRequest{}
+	mi := &file_proto_coupon_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseCampaignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseCampaignRequest) ProtoMessage() {}
+
+func (x *PauseCampaignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coupon_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseCampaignRequest.ProtoReflect.Descriptor instead.
+func (*PauseCampaignRequest) Descriptor() ([]byte, []int) {
+	return file_proto_coupon_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PauseCampaignRequest) GetCampaignId() string {
+	if x != nil {
+		return x.CampaignId
+	}
+	return ""
+}
+
+type PauseCampaignResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`  // 처리 성공 여부
+	Campaign      *Campaign              `protobuf:"bytes,2,opt,name=campaign,proto3" json:"campaign,omitempty"` // 변경된 캠페인 정보 (성공 시에만)
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`   // 성공/실패 메시지
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseCampaignResponse) Reset() {
+	*x = PauseCampaignResponse{}
+	mi := &file_proto_coupon_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseCampaignResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseCampaignResponse) ProtoMessage() {}
+
+func (x *PauseCampaignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coupon_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseCampaignResponse.ProtoReflect.Descriptor instead.
+func (*PauseCampaignResponse) Descriptor() ([]byte, []int) {
+	return file_proto_coupon_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *PauseCampaignResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *PauseCampaignResponse) GetCampaign() *Campaign {
+	if x != nil {
+		return x.Campaign
+	}
+	return nil
+}
+
+func (x *PauseCampaignResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ResumeCampaignRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId    string                 `protobuf:"bytes,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"` // 재개할 캠페인 ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeCampaignRequest) Reset() {
+	*x = ResumeCampaignRequest{}
+	mi := &file_proto_coupon_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeCampaignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeCampaignRequest) ProtoMessage() {}
+
+func (x *ResumeCampaignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coupon_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeCampaignRequest.ProtoReflect.Descriptor instead.
+func (*ResumeCampaignRequest) Descriptor() ([]byte, []int) {
+	return file_proto_coupon_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ResumeCampaignRequest) GetCampaignId() string {
+	if x != nil {
+		return x.CampaignId
+	}
+	return ""
+}
+
+type ResumeCampaignResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`  // 처리 성공 여부
+	Campaign      *Campaign              `protobuf:"bytes,2,opt,name=campaign,proto3" json:"campaign,omitempty"` // 변경된 캠페인 정보 (성공 시에만)
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`   // 성공/실패 메시지
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeCampaignResponse) Reset() {
+	*x = ResumeCampaignResponse{}
+	mi := &file_proto_coupon_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeCampaignResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeCampaignResponse) ProtoMessage() {}
+
+func (x *ResumeCampaignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coupon_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeCampaignResponse.ProtoReflect.Descriptor instead.
+func (*ResumeCampaignResponse) Descriptor() ([]byte, []int) {
+	return file_proto_coupon_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ResumeCampaignResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ResumeCampaignResponse) GetCampaign() *Campaign {
+	if x != nil {
+		return x.Campaign
+	}
+	return nil
+}
+
+func (x *ResumeCampaignResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type CancelCampaignRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId          string                 `protobuf:"bytes,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`                               // 취소할 캠페인 ID
+	RevokeIssuedCoupons bool                   `protobuf:"varint,2,opt,name=revoke_issued_coupons,json=revokeIssuedCoupons,proto3" json:"revoke_issued_coupons,omitempty"` // true 면 아직 사용되지 않은 발급 쿠폰을 REVOKED 로 회수 (사용된 쿠폰은 유지)
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *CancelCampaignRequest) Reset() {
+	*x = CancelCampaignRequest{}
+	mi := &file_proto_coupon_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelCampaignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelCampaignRequest) ProtoMessage() {}
+
+func (x *CancelCampaignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coupon_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelCampaignRequest.ProtoReflect.Descriptor instead.
+func (*CancelCampaignRequest) Descriptor() ([]byte, []int) {
+	return file_proto_coupon_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CancelCampaignRequest) GetCampaignId() string {
+	if x != nil {
+		return x.CampaignId
+	}
+	return ""
+}
+
+func (x *CancelCampaignRequest) GetRevokeIssuedCoupons() bool {
+	if x != nil {
+		return x.RevokeIssuedCoupons
+	}
+	return false
+}
+
+type CancelCampaignResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`                               // 처리 성공 여부
+	Campaign      *Campaign              `protobuf:"bytes,2,opt,name=campaign,proto3" json:"campaign,omitempty"`                              // 변경된 캠페인 정보 (성공 시에만)
+	RevokedCount  int32                  `protobuf:"varint,3,opt,name=revoked_count,json=revokedCount,proto3" json:"revoked_count,omitempty"` // 회수된 쿠폰 수
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`                                // 성공/실패 메시지
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelCampaignResponse) Reset() {
+	*x = CancelCampaignResponse{}
+	mi := &file_proto_coupon_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelCampaignResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelCampaignResponse) ProtoMessage() {}
+
+func (x *CancelCampaignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coupon_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelCampaignResponse.ProtoReflect.Descriptor instead.
+func (*CancelCampaignResponse) Descriptor() ([]byte, []int) {
+	return file_proto_coupon_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CancelCampaignResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CancelCampaignResponse) GetCampaign() *Campaign {
+	if x != nil {
+		return x.Campaign
+	}
+	return nil
+}
+
+func (x *CancelCampaignResponse) GetRevokedCount() int32 {
+	if x != nil {
+		return x.RevokedCount
+	}
+	return 0
+}
+
+func (x *CancelCampaignResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// optional: 값을 보내지 않은 필드는 변경하지 않음 (0/빈 문자열과 구분하기 위해 사용)
+type UpdateCampaignRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId      string                 `protobuf:"bytes,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`                 // 수정할 캠페인 ID
+	ExpectedVersion int64                  `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"` // 클라이언트가 조회한 캠페인 version. 다르면 수정 거절
+	Name            *string                `protobuf:"bytes,3,opt,name=name,proto3,oneof" json:"name,omitempty"`                                         // 캠페인 이름 (시작 전에만 변경 가능)
+	StartTime       *int64                 `protobuf:"varint,4,opt,name=start_time,json=startTime,proto3,oneof" json:"start_time,omitempty"`             // 시작 시간 (Unix timestamp, 초. 시작 전에만 변경 가능)
+	TotalQuantity   *int32                 `protobuf:"varint,5,opt,name=total_quantity,json=totalQuantity,proto3,oneof" json:"total_quantity,omitempty"` // 총 발급 수량 (발급된 수량 미만으로는 변경 불가)
+	StartTimeMs     *int64                 `protobuf:"varint,6,opt,name=start_time_ms,json=startTimeMs,proto3,oneof" json:"start_time_ms,omitempty"`     // 시작 시간 (Unix 밀리초. 보내면 start_time 대신 사용)
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateCampaignRequest) Reset() {
+	*x = UpdateCampaignRequest{}
+	mi := &file_proto_coupon_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Campaign) String() string {
+func (x *UpdateCampaignRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Campaign) ProtoMessage() {}
+func (*UpdateCampaignRequest) ProtoMessage() {}
 
-func (x *Campaign) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_coupon_proto_msgTypes[0]
+func (x *UpdateCampaignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coupon_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -119,85 +1950,77 @@ func (x *Campaign) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Campaign.ProtoReflect.Descriptor instead.
-func (*Campaign) Descriptor() ([]byte, []int) {
-	return file_proto_coupon_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use UpdateCampaignRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCampaignRequest) Descriptor() ([]byte, []int) {
+	return file_proto_coupon_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *Campaign) GetCampaignId() string {
+func (x *UpdateCampaignRequest) GetCampaignId() string {
 	if x != nil {
 		return x.CampaignId
 	}
 	return ""
 }
 
-func (x *Campaign) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *Campaign) GetStartTime() int64 {
+func (x *UpdateCampaignRequest) GetExpectedVersion() int64 {
 	if x != nil {
-		return x.StartTime
+		return x.ExpectedVersion
 	}
 	return 0
 }
 
-func (x *Campaign) GetTotalQuantity() int32 {
-	if x != nil {
-		return x.TotalQuantity
+func (x *UpdateCampaignRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
 	}
-	return 0
+	return ""
 }
 
-func (x *Campaign) GetIssuedQuantity() int32 {
-	if x != nil {
-		return x.IssuedQuantity
+func (x *UpdateCampaignRequest) GetStartTime() int64 {
+	if x != nil && x.StartTime != nil {
+		return *x.StartTime
 	}
 	return 0
 }
 
-func (x *Campaign) GetStatus() CampaignStatus {
-	if x != nil {
-		return x.Status
+func (x *UpdateCampaignRequest) GetTotalQuantity() int32 {
+	if x != nil && x.TotalQuantity != nil {
+		return *x.TotalQuantity
 	}
-	return CampaignStatus_UNSPECIFIED
+	return 0
 }
 
-func (x *Campaign) GetCreatedAt() int64 {
-	if x != nil {
-		return x.CreatedAt
+func (x *UpdateCampaignRequest) GetStartTimeMs() int64 {
+	if x != nil && x.StartTimeMs != nil {
+		return *x.StartTimeMs
 	}
 	return 0
 }
 
-type Coupon struct {
+type UpdateCampaignResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	CouponCode    string                 `protobuf:"bytes,1,opt,name=coupon_code,json=couponCode,proto3" json:"coupon_code,omitempty"` // 쿠폰 고유 코드 (최대 10자)
-	CampaignId    string                 `protobuf:"bytes,2,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"` // 소속 캠페인 ID
-	IssuedAt      int64                  `protobuf:"varint,3,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at,omitempty"`      // 발급 시간
-	IssuedTo      string                 `protobuf:"bytes,4,opt,name=issued_to,json=issuedTo,proto3" json:"issued_to,omitempty"`       // 발급 대상 (사용자 ID)
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`  // 처리 성공 여부
+	Campaign      *Campaign              `protobuf:"bytes,2,opt,name=campaign,proto3" json:"campaign,omitempty"` // 변경된 캠페인 정보 (성공 시에만)
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`   // 성공/실패 메시지
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Coupon) Reset() {
-	*x = Coupon{}
-	mi := &file_proto_coupon_proto_msgTypes[1]
+func (x *UpdateCampaignResponse) Reset() {
+	*x = UpdateCampaignResponse{}
+	mi := &file_proto_coupon_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Coupon) String() string {
+func (x *UpdateCampaignResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Coupon) ProtoMessage() {}
+func (*UpdateCampaignResponse) ProtoMessage() {}
 
-func (x *Coupon) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_coupon_proto_msgTypes[1]
+func (x *UpdateCampaignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coupon_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -208,63 +2031,54 @@ func (x *Coupon) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Coupon.ProtoReflect.Descriptor instead.
-func (*Coupon) Descriptor() ([]byte, []int) {
-	return file_proto_coupon_proto_rawDescGZIP(), []int{1}
-}
-
-func (x *Coupon) GetCouponCode() string {
-	if x != nil {
-		return x.CouponCode
-	}
-	return ""
+// Deprecated: Use UpdateCampaignResponse.ProtoReflect.Descriptor instead.
+func (*UpdateCampaignResponse) Descriptor() ([]byte, []int) {
+	return file_proto_coupon_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *Coupon) GetCampaignId() string {
+func (x *UpdateCampaignResponse) GetSuccess() bool {
 	if x != nil {
-		return x.CampaignId
+		return x.Success
 	}
-	return ""
+	return false
 }
 
-func (x *Coupon) GetIssuedAt() int64 {
+func (x *UpdateCampaignResponse) GetCampaign() *Campaign {
 	if x != nil {
-		return x.IssuedAt
+		return x.Campaign
 	}
-	return 0
+	return nil
 }
 
-func (x *Coupon) GetIssuedTo() string {
+func (x *UpdateCampaignResponse) GetMessage() string {
 	if x != nil {
-		return x.IssuedTo
+		return x.Message
 	}
 	return ""
 }
 
-type CreateCampaignRequest struct {
+type ValidateCouponRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                         // 캠페인 이름
-	StartTime     int64                  `protobuf:"varint,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`             // 쿠폰 발급 시작 시간
-	TotalQuantity int32                  `protobuf:"varint,3,opt,name=total_quantity,json=totalQuantity,proto3" json:"total_quantity,omitempty"` // 총 발급할 쿠폰 수량
+	CouponCode    string                 `protobuf:"bytes,1,opt,name=coupon_code,json=couponCode,proto3" json:"coupon_code,omitempty"` // 확인할 쿠폰 코드
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateCampaignRequest) Reset() {
-	*x = CreateCampaignRequest{}
-	mi := &file_proto_coupon_proto_msgTypes[2]
+func (x *ValidateCouponRequest) Reset() {
+	*x = ValidateCouponRequest{}
+	mi := &file_proto_coupon_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateCampaignRequest) String() string {
+func (x *ValidateCouponRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateCampaignRequest) ProtoMessage() {}
+func (*ValidateCouponRequest) ProtoMessage() {}
 
-func (x *CreateCampaignRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_coupon_proto_msgTypes[2]
+func (x *ValidateCouponRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coupon_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -275,55 +2089,42 @@ func (x *CreateCampaignRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateCampaignRequest.ProtoReflect.Descriptor instead.
-func (*CreateCampaignRequest) Descriptor() ([]byte, []int) {
-	return file_proto_coupon_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use ValidateCouponRequest.ProtoReflect.Descriptor instead.
+func (*ValidateCouponRequest) Descriptor() ([]byte, []int) {
+	return file_proto_coupon_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *CreateCampaignRequest) GetName() string {
+func (x *ValidateCouponRequest) GetCouponCode() string {
 	if x != nil {
-		return x.Name
+		return x.CouponCode
 	}
 	return ""
 }
 
-func (x *CreateCampaignRequest) GetStartTime() int64 {
-	if x != nil {
-		return x.StartTime
-	}
-	return 0
-}
-
-func (x *CreateCampaignRequest) GetTotalQuantity() int32 {
-	if x != nil {
-		return x.TotalQuantity
-	}
-	return 0
-}
-
-type CreateCampaignResponse struct {
+type ValidateCouponResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Campaign      *Campaign              `protobuf:"bytes,1,opt,name=campaign,proto3" json:"campaign,omitempty"` // 생성된 캠페인 정보
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`   // 성공/실패 메시지
+	Result        CodeCheckResult        `protobuf:"varint,1,opt,name=result,proto3,enum=coupon.CodeCheckResult" json:"result,omitempty"` // 확인 결과
+	Coupon        *Coupon                `protobuf:"bytes,2,opt,name=coupon,proto3" json:"coupon,omitempty"`                              // 쿠폰 정보 (VALID 일 때만)
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`                            // 결과 메시지
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateCampaignResponse) Reset() {
-	*x = CreateCampaignResponse{}
-	mi := &file_proto_coupon_proto_msgTypes[3]
+func (x *ValidateCouponResponse) Reset() {
+	*x = ValidateCouponResponse{}
+	mi := &file_proto_coupon_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateCampaignResponse) String() string {
+func (x *ValidateCouponResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateCampaignResponse) ProtoMessage() {}
+func (*ValidateCouponResponse) ProtoMessage() {}
 
-func (x *CreateCampaignResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_coupon_proto_msgTypes[3]
+func (x *ValidateCouponResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coupon_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -334,47 +2135,57 @@ func (x *CreateCampaignResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateCampaignResponse.ProtoReflect.Descriptor instead.
-func (*CreateCampaignResponse) Descriptor() ([]byte, []int) {
-	return file_proto_coupon_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use ValidateCouponResponse.ProtoReflect.Descriptor instead.
+func (*ValidateCouponResponse) Descriptor() ([]byte, []int) {
+	return file_proto_coupon_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *CreateCampaignResponse) GetCampaign() *Campaign {
+func (x *ValidateCouponResponse) GetResult() CodeCheckResult {
 	if x != nil {
-		return x.Campaign
+		return x.Result
+	}
+	return CodeCheckResult_CODE_CHECK_RESULT_UNSPECIFIED
+}
+
+func (x *ValidateCouponResponse) GetCoupon() *Coupon {
+	if x != nil {
+		return x.Coupon
 	}
 	return nil
 }
 
-func (x *CreateCampaignResponse) GetMessage() string {
+func (x *ValidateCouponResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-type GetCampaignRequest struct {
+// 첫 메시지에 campaign_id 가 있어야 하며, 이후 메시지는 codes 만 채워도 됨 (채우면 첫 메시지와 같아야 함)
+// 모든 코드를 검증한 뒤 한 번에 등록하므로 하나라도 문제가 있으면 아무 코드도 등록되지 않음
+type ImportCouponCodesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	CampaignId    string                 `protobuf:"bytes,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"` // 조회할 캠페인 ID
+	CampaignId    string                 `protobuf:"bytes,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"` // 코드를 등록할 캠페인 ID (POOL 형식)
+	Codes         []string               `protobuf:"bytes,2,rep,name=codes,proto3" json:"codes,omitempty"`                             // 등록할 쿠폰 코드 (영문/숫자/한글. 공백과 '-' 를 뺀 정규형 기준 최대 10자)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetCampaignRequest) Reset() {
-	*x = GetCampaignRequest{}
-	mi := &file_proto_coupon_proto_msgTypes[4]
+func (x *ImportCouponCodesRequest) Reset() {
+	*x = ImportCouponCodesRequest{}
+	mi := &file_proto_coupon_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetCampaignRequest) String() string {
+func (x *ImportCouponCodesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCampaignRequest) ProtoMessage() {}
+func (*ImportCouponCodesRequest) ProtoMessage() {}
 
-func (x *GetCampaignRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_coupon_proto_msgTypes[4]
+func (x *ImportCouponCodesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coupon_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -385,42 +2196,51 @@ func (x *GetCampaignRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCampaignRequest.ProtoReflect.Descriptor instead.
-func (*GetCampaignRequest) Descriptor() ([]byte, []int) {
-	return file_proto_coupon_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use ImportCouponCodesRequest.ProtoReflect.Descriptor instead.
+func (*ImportCouponCodesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_coupon_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *GetCampaignRequest) GetCampaignId() string {
+func (x *ImportCouponCodesRequest) GetCampaignId() string {
 	if x != nil {
 		return x.CampaignId
 	}
 	return ""
 }
 
-type GetCampaignResponse struct {
+func (x *ImportCouponCodesRequest) GetCodes() []string {
+	if x != nil {
+		return x.Codes
+	}
+	return nil
+}
+
+type ImportCouponCodesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Campaign      *Campaign              `protobuf:"bytes,1,opt,name=campaign,proto3" json:"campaign,omitempty"`                                // 캠페인 기본 정보
-	IssuedCoupons []*Coupon              `protobuf:"bytes,2,rep,name=issued_coupons,json=issuedCoupons,proto3" json:"issued_coupons,omitempty"` // 발급된 쿠폰들 (repeated = 배열)
-	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`                                  // 성공/실패 메시지
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`                                  // 등록 성공 여부
+	Campaign      *Campaign              `protobuf:"bytes,2,opt,name=campaign,proto3" json:"campaign,omitempty"`                                 // 변경된 캠페인 정보 (성공 시에만. total_quantity 가 등록한 코드 수만큼 늘어남)
+	ImportedCount int32                  `protobuf:"varint,3,opt,name=imported_count,json=importedCount,proto3" json:"imported_count,omitempty"` // 이번 요청으로 등록한 코드 수
+	RejectedCodes []string               `protobuf:"bytes,4,rep,name=rejected_codes,json=rejectedCodes,proto3" json:"rejected_codes,omitempty"`  // 형식 오류, 요청 안 중복, 이미 발급되었거나 다른 캠페인에 등록된 코드 (최대 100개)
+	Message       string                 `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`                                   // 성공/실패 메시지
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetCampaignResponse) Reset() {
-	*x = GetCampaignResponse{}
-	mi := &file_proto_coupon_proto_msgTypes[5]
+func (x *ImportCouponCodesResponse) Reset() {
+	*x = ImportCouponCodesResponse{}
+	mi := &file_proto_coupon_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetCampaignResponse) String() string {
+func (x *ImportCouponCodesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCampaignResponse) ProtoMessage() {}
+func (*ImportCouponCodesResponse) ProtoMessage() {}
 
-func (x *GetCampaignResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_coupon_proto_msgTypes[5]
+func (x *ImportCouponCodesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coupon_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -431,55 +2251,72 @@ func (x *GetCampaignResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCampaignResponse.ProtoReflect.Descriptor instead.
-func (*GetCampaignResponse) Descriptor() ([]byte, []int) {
-	return file_proto_coupon_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use ImportCouponCodesResponse.ProtoReflect.Descriptor instead.
+func (*ImportCouponCodesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_coupon_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *GetCampaignResponse) GetCampaign() *Campaign {
+func (x *ImportCouponCodesResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ImportCouponCodesResponse) GetCampaign() *Campaign {
 	if x != nil {
 		return x.Campaign
 	}
 	return nil
 }
 
-func (x *GetCampaignResponse) GetIssuedCoupons() []*Coupon {
+func (x *ImportCouponCodesResponse) GetImportedCount() int32 {
 	if x != nil {
-		return x.IssuedCoupons
+		return x.ImportedCount
+	}
+	return 0
+}
+
+func (x *ImportCouponCodesResponse) GetRejectedCodes() []string {
+	if x != nil {
+		return x.RejectedCodes
 	}
 	return nil
 }
 
-func (x *GetCampaignResponse) GetMessage() string {
+func (x *ImportCouponCodesResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-type IssueCouponRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	CampaignId    string                 `protobuf:"bytes,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"` // 대상 캠페인 ID
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`             // 요청자 ID
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// Connect 오류 details 에 담기는 실패 정보
+// details 에는 기존 클라이언트를 위해 실패 응답 메시지(success = false, message 등을 채운 각 RPC 의 응답)도 함께 담김
+type ErrorDetail struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Reason          ErrorReason            `protobuf:"varint,1,opt,name=reason,proto3,enum=coupon.ErrorReason" json:"reason,omitempty"`                 // 실패 종류
+	Message         string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`                                        // 사용자 안내 문구 (응답 message 필드와 같음)
+	FieldViolations []*FieldViolation      `protobuf:"bytes,3,rep,name=field_violations,json=fieldViolations,proto3" json:"field_violations,omitempty"` // 요청 값 오류(INVALID_ARGUMENT)의 필드별 위반 사항. 첫 위반에서 멈추지 않고 모두 담음
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *IssueCouponRequest) Reset() {
-	*x = IssueCouponRequest{}
-	mi := &file_proto_coupon_proto_msgTypes[6]
+func (x *ErrorDetail) Reset() {
+	*x = ErrorDetail{}
+	mi := &file_proto_coupon_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IssueCouponRequest) String() string {
+func (x *ErrorDetail) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IssueCouponRequest) ProtoMessage() {}
+func (*ErrorDetail) ProtoMessage() {}
 
-func (x *IssueCouponRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_coupon_proto_msgTypes[6]
+func (x *ErrorDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coupon_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -490,49 +2327,56 @@ func (x *IssueCouponRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IssueCouponRequest.ProtoReflect.Descriptor instead.
-func (*IssueCouponRequest) Descriptor() ([]byte, []int) {
-	return file_proto_coupon_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use ErrorDetail.ProtoReflect.Descriptor instead.
+func (*ErrorDetail) Descriptor() ([]byte, []int) {
+	return file_proto_coupon_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *IssueCouponRequest) GetCampaignId() string {
+func (x *ErrorDetail) GetReason() ErrorReason {
 	if x != nil {
-		return x.CampaignId
+		return x.Reason
 	}
-	return ""
+	return ErrorReason_ERROR_REASON_UNSPECIFIED
 }
 
-func (x *IssueCouponRequest) GetUserId() string {
+func (x *ErrorDetail) GetMessage() string {
 	if x != nil {
-		return x.UserId
+		return x.Message
 	}
 	return ""
 }
 
-type IssueCouponResponse struct {
+func (x *ErrorDetail) GetFieldViolations() []*FieldViolation {
+	if x != nil {
+		return x.FieldViolations
+	}
+	return nil
+}
+
+// 요청 필드 하나의 검증 실패
+type FieldViolation struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"` // 발급 성공 여부
-	Coupon        *Coupon                `protobuf:"bytes,2,opt,name=coupon,proto3" json:"coupon,omitempty"`    // 발급된 쿠폰 (성공 시에만)
-	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`  // 성공/실패 메시지
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`             // 요청 메시지의 필드 이름 (예: "start_time")
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"` // 사용자 안내 문구
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IssueCouponResponse) Reset() {
-	*x = IssueCouponResponse{}
-	mi := &file_proto_coupon_proto_msgTypes[7]
+func (x *FieldViolation) Reset() {
+	*x = FieldViolation{}
+	mi := &file_proto_coupon_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IssueCouponResponse) String() string {
+func (x *FieldViolation) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IssueCouponResponse) ProtoMessage() {}
+func (*FieldViolation) ProtoMessage() {}
 
-func (x *IssueCouponResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_coupon_proto_msgTypes[7]
+func (x *FieldViolation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_coupon_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -543,28 +2387,21 @@ func (x *IssueCouponResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IssueCouponResponse.ProtoReflect.Descriptor instead.
-func (*IssueCouponResponse) Descriptor() ([]byte, []int) {
-	return file_proto_coupon_proto_rawDescGZIP(), []int{7}
-}
-
-func (x *IssueCouponResponse) GetSuccess() bool {
-	if x != nil {
-		return x.Success
-	}
-	return false
+// Deprecated: Use FieldViolation.ProtoReflect.Descriptor instead.
+func (*FieldViolation) Descriptor() ([]byte, []int) {
+	return file_proto_coupon_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *IssueCouponResponse) GetCoupon() *Coupon {
+func (x *FieldViolation) GetField() string {
 	if x != nil {
-		return x.Coupon
+		return x.Field
 	}
-	return nil
+	return ""
 }
 
-func (x *IssueCouponResponse) GetMessage() string {
+func (x *FieldViolation) GetDescription() string {
 	if x != nil {
-		return x.Message
+		return x.Description
 	}
 	return ""
 }
@@ -573,7 +2410,7 @@ var File_proto_coupon_proto protoreflect.FileDescriptor
 
 const file_proto_coupon_proto_rawDesc = "" +
 	"\n" +
-	"\x12proto/coupon.proto\x12\x06coupon\"\xfd\x01\n" +
+	"\x12proto/coupon.proto\x12\x06coupon\"\x81\x05\n" +
 	"\bCampaign\x12\x1f\n" +
 	"\vcampaign_id\x18\x01 \x01(\tR\n" +
 	"campaignId\x12\x12\n" +
@@ -584,47 +2421,249 @@ const file_proto_coupon_proto_rawDesc = "" +
 	"\x0fissued_quantity\x18\x05 \x01(\x05R\x0eissuedQuantity\x12.\n" +
 	"\x06status\x18\x06 \x01(\x0e2\x16.coupon.CampaignStatusR\x06status\x12\x1d\n" +
 	"\n" +
-	"created_at\x18\a \x01(\x03R\tcreatedAt\"\x84\x01\n" +
+	"created_at\x18\a \x01(\x03R\tcreatedAt\x12 \n" +
+	"\fmax_per_user\x18\b \x01(\x05R\n" +
+	"maxPerUser\x12\x19\n" +
+	"\bend_time\x18\t \x01(\x03R\aendTime\x12\x18\n" +
+	"\aversion\x18\n" +
+	" \x01(\x03R\aversion\x12#\n" +
+	"\rcode_sequence\x18\v \x01(\x03R\fcodeSequence\x123\n" +
+	"\vcode_format\x18\f \x01(\x0e2\x12.coupon.CodeFormatR\n" +
+	"codeFormat\x12\x1f\n" +
+	"\vcode_length\x18\r \x01(\x05R\n" +
+	"codeLength\x12\x1f\n" +
+	"\vcode_prefix\x18\x0e \x01(\tR\n" +
+	"codePrefix\x12#\n" +
+	"\rcode_template\x18\x0f \x01(\tR\fcodeTemplate\x12&\n" +
+	"\x0fcode_check_char\x18\x10 \x01(\bR\rcodeCheckChar\x12\"\n" +
+	"\rstart_time_ms\x18\x11 \x01(\x03R\vstartTimeMs\x12\x1e\n" +
+	"\vend_time_ms\x18\x12 \x01(\x03R\tendTimeMs\"\xb6\x02\n" +
 	"\x06Coupon\x12\x1f\n" +
 	"\vcoupon_code\x18\x01 \x01(\tR\n" +
 	"couponCode\x12\x1f\n" +
 	"\vcampaign_id\x18\x02 \x01(\tR\n" +
 	"campaignId\x12\x1b\n" +
 	"\tissued_at\x18\x03 \x01(\x03R\bissuedAt\x12\x1b\n" +
-	"\tissued_to\x18\x04 \x01(\tR\bissuedTo\"q\n" +
+	"\tissued_to\x18\x04 \x01(\tR\bissuedTo\x12,\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x14.coupon.CouponStatusR\x06status\x12\x1f\n" +
+	"\vredeemed_at\x18\x06 \x01(\x03R\n" +
+	"redeemedAt\x12\x19\n" +
+	"\border_id\x18\a \x01(\tR\aorderId\x12 \n" +
+	"\fissued_at_ms\x18\b \x01(\x03R\n" +
+	"issuedAtMs\x12$\n" +
+	"\x0eredeemed_at_ms\x18\t \x01(\x03R\fredeemedAtMs\"\xb6\x03\n" +
 	"\x15CreateCampaignRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1d\n" +
 	"\n" +
 	"start_time\x18\x02 \x01(\x03R\tstartTime\x12%\n" +
-	"\x0etotal_quantity\x18\x03 \x01(\x05R\rtotalQuantity\"`\n" +
+	"\x0etotal_quantity\x18\x03 \x01(\x05R\rtotalQuantity\x12 \n" +
+	"\fmax_per_user\x18\x04 \x01(\x05R\n" +
+	"maxPerUser\x12\x19\n" +
+	"\bend_time\x18\x05 \x01(\x03R\aendTime\x123\n" +
+	"\vcode_format\x18\x06 \x01(\x0e2\x12.coupon.CodeFormatR\n" +
+	"codeFormat\x12\x1f\n" +
+	"\vcode_length\x18\a \x01(\x05R\n" +
+	"codeLength\x12\x1f\n" +
+	"\vcode_prefix\x18\b \x01(\tR\n" +
+	"codePrefix\x12#\n" +
+	"\rcode_template\x18\t \x01(\tR\fcodeTemplate\x12&\n" +
+	"\x0fcode_check_char\x18\n" +
+	" \x01(\bR\rcodeCheckChar\x12\"\n" +
+	"\rstart_time_ms\x18\v \x01(\x03R\vstartTimeMs\x12\x1e\n" +
+	"\vend_time_ms\x18\f \x01(\x03R\tendTimeMs\"\xae\x02\n" +
 	"\x16CreateCampaignResponse\x12,\n" +
 	"\bcampaign\x18\x01 \x01(\v2\x10.coupon.CampaignR\bcampaign\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"5\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12#\n" +
+	"\rcode_keyspace\x18\x03 \x01(\x03R\fcodeKeyspace\x12.\n" +
+	"\x13code_space_reserved\x18\x04 \x01(\x03R\x11codeSpaceReserved\x12(\n" +
+	"\x10code_space_usage\x18\x05 \x01(\x01R\x0ecodeSpaceUsage\x123\n" +
+	"\x15collision_probability\x18\x06 \x01(\x01R\x14collisionProbability\x12\x18\n" +
+	"\awarning\x18\a \x01(\tR\awarning\"^\n" +
 	"\x12GetCampaignRequest\x12\x1f\n" +
 	"\vcampaign_id\x18\x01 \x01(\tR\n" +
-	"campaignId\"\x94\x01\n" +
+	"campaignId\x12'\n" +
+	"\x0fexclude_coupons\x18\x02 \x01(\bR\x0eexcludeCoupons\"\x94\x01\n" +
 	"\x13GetCampaignResponse\x12,\n" +
 	"\bcampaign\x18\x01 \x01(\v2\x10.coupon.CampaignR\bcampaign\x125\n" +
 	"\x0eissued_coupons\x18\x02 \x03(\v2\x0e.coupon.CouponR\rissuedCoupons\x12\x18\n" +
-	"\amessage\x18\x03 \x01(\tR\amessage\"N\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"w\n" +
 	"\x12IssueCouponRequest\x12\x1f\n" +
 	"\vcampaign_id\x18\x01 \x01(\tR\n" +
 	"campaignId\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\"q\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12'\n" +
+	"\x0fidempotency_key\x18\x03 \x01(\tR\x0eidempotencyKey\"q\n" +
 	"\x13IssueCouponResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12&\n" +
 	"\x06coupon\x18\x02 \x01(\v2\x0e.coupon.CouponR\x06coupon\x12\x18\n" +
-	"\amessage\x18\x03 \x01(\tR\amessage*I\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"j\n" +
+	"\x13RedeemCouponRequest\x12\x1f\n" +
+	"\vcoupon_code\x18\x01 \x01(\tR\n" +
+	"couponCode\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x19\n" +
+	"\border_id\x18\x03 \x01(\tR\aorderId\"r\n" +
+	"\x14RedeemCouponResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12&\n" +
+	"\x06coupon\x18\x02 \x01(\v2\x0e.coupon.CouponR\x06coupon\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\xf7\x01\n" +
+	"\x14ListCampaignsRequest\x122\n" +
+	"\bstatuses\x18\x01 \x03(\x0e2\x16.coupon.CampaignStatusR\bstatuses\x12#\n" +
+	"\rname_contains\x18\x02 \x01(\tR\fnameContains\x12&\n" +
+	"\x0fstart_time_from\x18\x03 \x01(\x03R\rstartTimeFrom\x12\"\n" +
+	"\rstart_time_to\x18\x04 \x01(\x03R\vstartTimeTo\x12\x1b\n" +
+	"\tpage_size\x18\x05 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x06 \x01(\tR\tpageToken\"\x89\x01\n" +
+	"\x15ListCampaignsResponse\x12.\n" +
+	"\tcampaigns\x18\x01 \x03(\v2\x10.coupon.CampaignR\tcampaigns\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"w\n" +
+	"\x18ListIssuedCouponsRequest\x12\x1f\n" +
+	"\vcampaign_id\x18\x01 \x01(\tR\n" +
+	"campaignId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"\x87\x01\n" +
+	"\x19ListIssuedCouponsResponse\x12(\n" +
+	"\acoupons\x18\x01 \x03(\v2\x0e.coupon.CouponR\acoupons\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\\\n" +
+	"\x1aStreamIssuedCouponsRequest\x12\x1f\n" +
+	"\vcampaign_id\x18\x01 \x01(\tR\n" +
+	"campaignId\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x02 \x01(\x05R\tbatchSize\"G\n" +
+	"\x1bStreamIssuedCouponsResponse\x12(\n" +
+	"\acoupons\x18\x01 \x03(\v2\x0e.coupon.CouponR\acoupons\"7\n" +
+	"\x14PauseCampaignRequest\x12\x1f\n" +
+	"\vcampaign_id\x18\x01 \x01(\tR\n" +
+	"campaignId\"y\n" +
+	"\x15PauseCampaignResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12,\n" +
+	"\bcampaign\x18\x02 \x01(\v2\x10.coupon.CampaignR\bcampaign\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"8\n" +
+	"\x15ResumeCampaignRequest\x12\x1f\n" +
+	"\vcampaign_id\x18\x01 \x01(\tR\n" +
+	"campaignId\"z\n" +
+	"\x16ResumeCampaignResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12,\n" +
+	"\bcampaign\x18\x02 \x01(\v2\x10.coupon.CampaignR\bcampaign\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"l\n" +
+	"\x15CancelCampaignRequest\x12\x1f\n" +
+	"\vcampaign_id\x18\x01 \x01(\tR\n" +
+	"campaignId\x122\n" +
+	"\x15revoke_issued_coupons\x18\x02 \x01(\bR\x13revokeIssuedCoupons\"\x9f\x01\n" +
+	"\x16CancelCampaignResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12,\n" +
+	"\bcampaign\x18\x02 \x01(\v2\x10.coupon.CampaignR\bcampaign\x12#\n" +
+	"\rrevoked_count\x18\x03 \x01(\x05R\frevokedCount\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"\xb2\x02\n" +
+	"\x15UpdateCampaignRequest\x12\x1f\n" +
+	"\vcampaign_id\x18\x01 \x01(\tR\n" +
+	"campaignId\x12)\n" +
+	"\x10expected_version\x18\x02 \x01(\x03R\x0fexpectedVersion\x12\x17\n" +
+	"\x04name\x18\x03 \x01(\tH\x00R\x04name\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"start_time\x18\x04 \x01(\x03H\x01R\tstartTime\x88\x01\x01\x12*\n" +
+	"\x0etotal_quantity\x18\x05 \x01(\x05H\x02R\rtotalQuantity\x88\x01\x01\x12'\n" +
+	"\rstart_time_ms\x18\x06 \x01(\x03H\x03R\vstartTimeMs\x88\x01\x01B\a\n" +
+	"\x05_nameB\r\n" +
+	"\v_start_timeB\x11\n" +
+	"\x0f_total_quantityB\x10\n" +
+	"\x0e_start_time_ms\"z\n" +
+	"\x16UpdateCampaignResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12,\n" +
+	"\bcampaign\x18\x02 \x01(\v2\x10.coupon.CampaignR\bcampaign\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"8\n" +
+	"\x15ValidateCouponRequest\x12\x1f\n" +
+	"\vcoupon_code\x18\x01 \x01(\tR\n" +
+	"couponCode\"\x8b\x01\n" +
+	"\x16ValidateCouponResponse\x12/\n" +
+	"\x06result\x18\x01 \x01(\x0e2\x17.coupon.CodeCheckResultR\x06result\x12&\n" +
+	"\x06coupon\x18\x02 \x01(\v2\x0e.coupon.CouponR\x06coupon\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"Q\n" +
+	"\x18ImportCouponCodesRequest\x12\x1f\n" +
+	"\vcampaign_id\x18\x01 \x01(\tR\n" +
+	"campaignId\x12\x14\n" +
+	"\x05codes\x18\x02 \x03(\tR\x05codes\"\xcb\x01\n" +
+	"\x19ImportCouponCodesResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12,\n" +
+	"\bcampaign\x18\x02 \x01(\v2\x10.coupon.CampaignR\bcampaign\x12%\n" +
+	"\x0eimported_count\x18\x03 \x01(\x05R\rimportedCount\x12%\n" +
+	"\x0erejected_codes\x18\x04 \x03(\tR\rrejectedCodes\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\"\x97\x01\n" +
+	"\vErrorDetail\x12+\n" +
+	"\x06reason\x18\x01 \x01(\x0e2\x13.coupon.ErrorReasonR\x06reason\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12A\n" +
+	"\x10field_violations\x18\x03 \x03(\v2\x16.coupon.FieldViolationR\x0ffieldViolations\"H\n" +
+	"\x0eFieldViolation\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription*o\n" +
 	"\x0eCampaignStatus\x12\x0f\n" +
 	"\vUNSPECIFIED\x10\x00\x12\v\n" +
 	"\aWAITING\x10\x01\x12\n" +
 	"\n" +
 	"\x06ACTIVE\x10\x02\x12\r\n" +
-	"\tCOMPLETED\x10\x032\xf0\x01\n" +
+	"\tCOMPLETED\x10\x03\x12\t\n" +
+	"\x05ENDED\x10\x04\x12\n" +
+	"\n" +
+	"\x06PAUSED\x10\x05\x12\r\n" +
+	"\tCANCELLED\x10\x06*a\n" +
+	"\fCouponStatus\x12\x1d\n" +
+	"\x19COUPON_STATUS_UNSPECIFIED\x10\x00\x12\n" +
+	"\n" +
+	"\x06ISSUED\x10\x01\x12\f\n" +
+	"\bREDEEMED\x10\x02\x12\v\n" +
+	"\aEXPIRED\x10\x03\x12\v\n" +
+	"\aREVOKED\x10\x04*\xc5\x01\n" +
+	"\n" +
+	"CodeFormat\x12\x1b\n" +
+	"\x17CODE_FORMAT_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12CODE_FORMAT_HANGUL\x10\x01\x12\x1c\n" +
+	"\x18CODE_FORMAT_ALPHANUMERIC\x10\x02\x12\x1b\n" +
+	"\x17CODE_FORMAT_UNAMBIGUOUS\x10\x03\x12\x17\n" +
+	"\x13CODE_FORMAT_NUMERIC\x10\x04\x12\x18\n" +
+	"\x14CODE_FORMAT_TEMPLATE\x10\x05\x12\x14\n" +
+	"\x10CODE_FORMAT_POOL\x10\x06*\x93\x01\n" +
+	"\x0fCodeCheckResult\x12!\n" +
+	"\x1dCODE_CHECK_RESULT_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17CODE_CHECK_RESULT_VALID\x10\x01\x12\x1f\n" +
+	"\x1bCODE_CHECK_RESULT_MALFORMED\x10\x02\x12\x1f\n" +
+	"\x1bCODE_CHECK_RESULT_NOT_FOUND\x10\x03*\xb0\x05\n" +
+	"\vErrorReason\x12\x1c\n" +
+	"\x18ERROR_REASON_UNSPECIFIED\x10\x00\x12!\n" +
+	"\x1dERROR_REASON_INVALID_ARGUMENT\x10\x01\x12\x1f\n" +
+	"\x1bERROR_REASON_MALFORMED_CODE\x10\x02\x12#\n" +
+	"\x1fERROR_REASON_CAMPAIGN_NOT_FOUND\x10\x03\x12!\n" +
+	"\x1dERROR_REASON_COUPON_NOT_FOUND\x10\x04\x12%\n" +
+	"!ERROR_REASON_CAMPAIGN_NOT_STARTED\x10\x05\x12\x1f\n" +
+	"\x1bERROR_REASON_CAMPAIGN_ENDED\x10\x06\x12 \n" +
+	"\x1cERROR_REASON_CAMPAIGN_PAUSED\x10\a\x12#\n" +
+	"\x1fERROR_REASON_CAMPAIGN_CANCELLED\x10\b\x12'\n" +
+	"#ERROR_REASON_INVALID_CAMPAIGN_STATE\x10\t\x12\"\n" +
+	"\x1eERROR_REASON_CAMPAIGN_SOLD_OUT\x10\n" +
+	"\x12$\n" +
+	" ERROR_REASON_USER_LIMIT_EXCEEDED\x10\v\x12!\n" +
+	"\x1dERROR_REASON_COUPON_NOT_OWNED\x10\f\x12(\n" +
+	"$ERROR_REASON_COUPON_ALREADY_REDEEMED\x10\r\x12\x1f\n" +
+	"\x1bERROR_REASON_COUPON_EXPIRED\x10\x0e\x12\x1f\n" +
+	"\x1bERROR_REASON_COUPON_REVOKED\x10\x0f\x12#\n" +
+	"\x1fERROR_REASON_COUPON_UNAVAILABLE\x10\x10\x12!\n" +
+	"\x1dERROR_REASON_VERSION_CONFLICT\x10\x11\x12\x1e\n" +
+	"\x1aERROR_REASON_CODE_CONFLICT\x10\x122\xb3\b\n" +
 	"\rCouponService\x12O\n" +
 	"\x0eCreateCampaign\x12\x1d.coupon.CreateCampaignRequest\x1a\x1e.coupon.CreateCampaignResponse\x12F\n" +
 	"\vGetCampaign\x12\x1a.coupon.GetCampaignRequest\x1a\x1b.coupon.GetCampaignResponse\x12F\n" +
-	"\vIssueCoupon\x12\x1a.coupon.IssueCouponRequest\x1a\x1b.coupon.IssueCouponResponseB#Z!coupon-issuance-system/gen/couponb\x06proto3"
+	"\vIssueCoupon\x12\x1a.coupon.IssueCouponRequest\x1a\x1b.coupon.IssueCouponResponse\x12I\n" +
+	"\fRedeemCoupon\x12\x1b.coupon.RedeemCouponRequest\x1a\x1c.coupon.RedeemCouponResponse\x12L\n" +
+	"\rListCampaigns\x12\x1c.coupon.ListCampaignsRequest\x1a\x1d.coupon.ListCampaignsResponse\x12X\n" +
+	"\x11ListIssuedCoupons\x12 .coupon.ListIssuedCouponsRequest\x1a!.coupon.ListIssuedCouponsResponse\x12`\n" +
+	"\x13StreamIssuedCoupons\x12\".coupon.StreamIssuedCouponsRequest\x1a#.coupon.StreamIssuedCouponsResponse0\x01\x12L\n" +
+	"\rPauseCampaign\x12\x1c.coupon.PauseCampaignRequest\x1a\x1d.coupon.PauseCampaignResponse\x12O\n" +
+	"\x0eResumeCampaign\x12\x1d.coupon.ResumeCampaignRequest\x1a\x1e.coupon.ResumeCampaignResponse\x12O\n" +
+	"\x0eCancelCampaign\x12\x1d.coupon.CancelCampaignRequest\x1a\x1e.coupon.CancelCampaignResponse\x12O\n" +
+	"\x0eUpdateCampaign\x12\x1d.coupon.UpdateCampaignRequest\x1a\x1e.coupon.UpdateCampaignResponse\x12O\n" +
+	"\x0eValidateCoupon\x12\x1d.coupon.ValidateCouponRequest\x1a\x1e.coupon.ValidateCouponResponse\x12Z\n" +
+	"\x11ImportCouponCodes\x12 .coupon.ImportCouponCodesRequest\x1a!.coupon.ImportCouponCodesResponse(\x01B#Z!coupon-issuance-system/gen/couponb\x06proto3"
 
 var (
 	file_proto_coupon_proto_rawDescOnce sync.Once
@@ -638,36 +2677,99 @@ func file_proto_coupon_proto_rawDescGZIP() []byte {
 	return file_proto_coupon_proto_rawDescData
 }
 
-var file_proto_coupon_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_proto_coupon_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_proto_coupon_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_proto_coupon_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
 var file_proto_coupon_proto_goTypes = []any{
-	(CampaignStatus)(0),            // 0: coupon.CampaignStatus
-	(*Campaign)(nil),               // 1: coupon.Campaign
-	(*Coupon)(nil),                 // 2: coupon.Coupon
-	(*CreateCampaignRequest)(nil),  // 3: coupon.CreateCampaignRequest
-	(*CreateCampaignResponse)(nil), // 4: coupon.CreateCampaignResponse
-	(*GetCampaignRequest)(nil),     // 5: coupon.GetCampaignRequest
-	(*GetCampaignResponse)(nil),    // 6: coupon.GetCampaignResponse
-	(*IssueCouponRequest)(nil),     // 7: coupon.IssueCouponRequest
-	(*IssueCouponResponse)(nil),    // 8: coupon.IssueCouponResponse
+	(CampaignStatus)(0),                 // 0: coupon.CampaignStatus
+	(CouponStatus)(0),                   // 1: coupon.CouponStatus
+	(CodeFormat)(0),                     // 2: coupon.CodeFormat
+	(CodeCheckResult)(0),                // 3: coupon.CodeCheckResult
+	(ErrorReason)(0),                    // 4: coupon.ErrorReason
+	(*Campaign)(nil),                    // 5: coupon.Campaign
+	(*Coupon)(nil),                      // 6: coupon.Coupon
+	(*CreateCampaignRequest)(nil),       // 7: coupon.CreateCampaignRequest
+	(*CreateCampaignResponse)(nil),      // 8: coupon.CreateCampaignResponse
+	(*GetCampaignRequest)(nil),          // 9: coupon.GetCampaignRequest
+	(*GetCampaignResponse)(nil),         // 10: coupon.GetCampaignResponse
+	(*IssueCouponRequest)(nil),          // 11: coupon.IssueCouponRequest
+	(*IssueCouponResponse)(nil),         // 12: coupon.IssueCouponResponse
+	(*RedeemCouponRequest)(nil),         // 13: coupon.RedeemCouponRequest
+	(*RedeemCouponResponse)(nil),        // 14: coupon.RedeemCouponResponse
+	(*ListCampaignsRequest)(nil),        // 15: coupon.ListCampaignsRequest
+	(*ListCampaignsResponse)(nil),       // 16: coupon.ListCampaignsResponse
+	(*ListIssuedCouponsRequest)(nil),    // 17: coupon.ListIssuedCouponsRequest
+	(*ListIssuedCouponsResponse)(nil),   // 18: coupon.ListIssuedCouponsResponse
+	(*StreamIssuedCouponsRequest)(nil),  // 19: coupon.StreamIssuedCouponsRequest
+	(*StreamIssuedCouponsResponse)(nil), // 20: coupon.StreamIssuedCouponsResponse
+	(*PauseCampaignRequest)(nil),        // 21: coupon.PauseCampaignRequest
+	(*PauseCampaignResponse)(nil),       // 22: coupon.PauseCampaignResponse
+	(*ResumeCampaignRequest)(nil),       // 23: coupon.ResumeCampaignRequest
+	(*ResumeCampaignResponse)(nil),      // 24: coupon.ResumeCampaignResponse
+	(*CancelCampaignRequest)(nil),       // 25: coupon.CancelCampaignRequest
+	(*CancelCampaignResponse)(nil),      // 26: coupon.CancelCampaignResponse
+	(*UpdateCampaignRequest)(nil),       // 27: coupon.UpdateCampaignRequest
+	(*UpdateCampaignResponse)(nil),      // 28: coupon.UpdateCampaignResponse
+	(*ValidateCouponRequest)(nil),       // 29: coupon.ValidateCouponRequest
+	(*ValidateCouponResponse)(nil),      // 30: coupon.ValidateCouponResponse
+	(*ImportCouponCodesRequest)(nil),    // 31: coupon.ImportCouponCodesRequest
+	(*ImportCouponCodesResponse)(nil),   // 32: coupon.ImportCouponCodesResponse
+	(*ErrorDetail)(nil),                 // 33: coupon.ErrorDetail
+	(*FieldViolation)(nil),              // 34: coupon.FieldViolation
 }
 var file_proto_coupon_proto_depIdxs = []int32{
-	0, // 0: coupon.Campaign.status:type_name -> coupon.CampaignStatus
-	1, // 1: coupon.CreateCampaignResponse.campaign:type_name -> coupon.Campaign
-	1, // 2: coupon.GetCampaignResponse.campaign:type_name -> coupon.Campaign
-	2, // 3: coupon.GetCampaignResponse.issued_coupons:type_name -> coupon.Coupon
-	2, // 4: coupon.IssueCouponResponse.coupon:type_name -> coupon.Coupon
-	3, // 5: coupon.CouponService.CreateCampaign:input_type -> coupon.CreateCampaignRequest
-	5, // 6: coupon.CouponService.GetCampaign:input_type -> coupon.GetCampaignRequest
-	7, // 7: coupon.CouponService.IssueCoupon:input_type -> coupon.IssueCouponRequest
-	4, // 8: coupon.CouponService.CreateCampaign:output_type -> coupon.CreateCampaignResponse
-	6, // 9: coupon.CouponService.GetCampaign:output_type -> coupon.GetCampaignResponse
-	8, // 10: coupon.CouponService.IssueCoupon:output_type -> coupon.IssueCouponResponse
-	8, // [8:11] is the sub-list for method output_type
-	5, // [5:8] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	0,  // 0: coupon.Campaign.status:type_name -> coupon.CampaignStatus
+	2,  // 1: coupon.Campaign.code_format:type_name -> coupon.CodeFormat
+	1,  // 2: coupon.Coupon.status:type_name -> coupon.CouponStatus
+	2,  // 3: coupon.CreateCampaignRequest.code_format:type_name -> coupon.CodeFormat
+	5,  // 4: coupon.CreateCampaignResponse.campaign:type_name -> coupon.Campaign
+	5,  // 5: coupon.GetCampaignResponse.campaign:type_name -> coupon.Campaign
+	6,  // 6: coupon.GetCampaignResponse.issued_coupons:type_name -> coupon.Coupon
+	6,  // 7: coupon.IssueCouponResponse.coupon:type_name -> coupon.Coupon
+	6,  // 8: coupon.RedeemCouponResponse.coupon:type_name -> coupon.Coupon
+	0,  // 9: coupon.ListCampaignsRequest.statuses:type_name -> coupon.CampaignStatus
+	5,  // 10: coupon.ListCampaignsResponse.campaigns:type_name -> coupon.Campaign
+	6,  // 11: coupon.ListIssuedCouponsResponse.coupons:type_name -> coupon.Coupon
+	6,  // 12: coupon.StreamIssuedCouponsResponse.coupons:type_name -> coupon.Coupon
+	5,  // 13: coupon.PauseCampaignResponse.campaign:type_name -> coupon.Campaign
+	5,  // 14: coupon.ResumeCampaignResponse.campaign:type_name -> coupon.Campaign
+	5,  // 15: coupon.CancelCampaignResponse.campaign:type_name -> coupon.Campaign
+	5,  // 16: coupon.UpdateCampaignResponse.campaign:type_name -> coupon.Campaign
+	3,  // 17: coupon.ValidateCouponResponse.result:type_name -> coupon.CodeCheckResult
+	6,  // 18: coupon.ValidateCouponResponse.coupon:type_name -> coupon.Coupon
+	5,  // 19: coupon.ImportCouponCodesResponse.campaign:type_name -> coupon.Campaign
+	4,  // 20: coupon.ErrorDetail.reason:type_name -> coupon.ErrorReason
+	34, // 21: coupon.ErrorDetail.field_violations:type_name -> coupon.FieldViolation
+	7,  // 22: coupon.CouponService.CreateCampaign:input_type -> coupon.CreateCampaignRequest
+	9,  // 23: coupon.CouponService.GetCampaign:input_type -> coupon.GetCampaignRequest
+	11, // 24: coupon.CouponService.IssueCoupon:input_type -> coupon.IssueCouponRequest
+	13, // 25: coupon.CouponService.RedeemCoupon:input_type -> coupon.RedeemCouponRequest
+	15, // 26: coupon.CouponService.ListCampaigns:input_type -> coupon.ListCampaignsRequest
+	17, // 27: coupon.CouponService.ListIssuedCoupons:input_type -> coupon.ListIssuedCouponsRequest
+	19, // 28: coupon.CouponService.StreamIssuedCoupons:input_type -> coupon.StreamIssuedCouponsRequest
+	21, // 29: coupon.CouponService.PauseCampaign:input_type -> coupon.PauseCampaignRequest
+	23, // 30: coupon.CouponService.ResumeCampaign:input_type -> coupon.ResumeCampaignRequest
+	25, // 31: coupon.CouponService.CancelCampaign:input_type -> coupon.CancelCampaignRequest
+	27, // 32: coupon.CouponService.UpdateCampaign:input_type -> coupon.UpdateCampaignRequest
+	29, // 33: coupon.CouponService.ValidateCoupon:input_type -> coupon.ValidateCouponRequest
+	31, // 34: coupon.CouponService.ImportCouponCodes:input_type -> coupon.ImportCouponCodesRequest
+	8,  // 35: coupon.CouponService.CreateCampaign:output_type -> coupon.CreateCampaignResponse
+	10, // 36: coupon.CouponService.GetCampaign:output_type -> coupon.GetCampaignResponse
+	12, // 37: coupon.CouponService.IssueCoupon:output_type -> coupon.IssueCouponResponse
+	14, // 38: coupon.CouponService.RedeemCoupon:output_type -> coupon.RedeemCouponResponse
+	16, // 39: coupon.CouponService.ListCampaigns:output_type -> coupon.ListCampaignsResponse
+	18, // 40: coupon.CouponService.ListIssuedCoupons:output_type -> coupon.ListIssuedCouponsResponse
+	20, // 41: coupon.CouponService.StreamIssuedCoupons:output_type -> coupon.StreamIssuedCouponsResponse
+	22, // 42: coupon.CouponService.PauseCampaign:output_type -> coupon.PauseCampaignResponse
+	24, // 43: coupon.CouponService.ResumeCampaign:output_type -> coupon.ResumeCampaignResponse
+	26, // 44: coupon.CouponService.CancelCampaign:output_type -> coupon.CancelCampaignResponse
+	28, // 45: coupon.CouponService.UpdateCampaign:output_type -> coupon.UpdateCampaignResponse
+	30, // 46: coupon.CouponService.ValidateCoupon:output_type -> coupon.ValidateCouponResponse
+	32, // 47: coupon.CouponService.ImportCouponCodes:output_type -> coupon.ImportCouponCodesResponse
+	35, // [35:48] is the sub-list for method output_type
+	22, // [22:35] is the sub-list for method input_type
+	22, // [22:22] is the sub-list for extension type_name
+	22, // [22:22] is the sub-list for extension extendee
+	0,  // [0:22] is the sub-list for field type_name
 }
 
 func init() { file_proto_coupon_proto_init() }
@@ -675,13 +2777,14 @@ func file_proto_coupon_proto_init() {
 	if File_proto_coupon_proto != nil {
 		return
 	}
+	file_proto_coupon_proto_msgTypes[22].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_coupon_proto_rawDesc), len(file_proto_coupon_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   8,
+			NumEnums:      5,
+			NumMessages:   30,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -49,6 +49,36 @@ const (
 	// CouponServiceIssueCouponProcedure is the fully-qualified name of the CouponService's IssueCoupon
 	// RPC.
 	CouponServiceIssueCouponProcedure = "/coupon.CouponService/IssueCoupon"
+	// CouponServiceRedeemCouponProcedure is the fully-qualified name of the CouponService's
+	// RedeemCoupon RPC.
+	CouponServiceRedeemCouponProcedure = "/coupon.CouponService/RedeemCoupon"
+	// CouponServiceListCampaignsProcedure is the fully-qualified name of the CouponService's
+	// ListCampaigns RPC.
+	CouponServiceListCampaignsProcedure = "/coupon.CouponService/ListCampaigns"
+	// CouponServiceListIssuedCouponsProcedure is the fully-qualified name of the CouponService's
+	// ListIssuedCoupons RPC.
+	CouponServiceListIssuedCouponsProcedure = "/coupon.CouponService/ListIssuedCoupons"
+	// CouponServiceStreamIssuedCouponsProcedure is the fully-qualified name of the CouponService's
+	// StreamIssuedCoupons RPC.
+	CouponServiceStreamIssuedCouponsProcedure = "/coupon.CouponService/StreamIssuedCoupons"
+	// CouponServicePauseCampaignProcedure is the fully-qualified name of the CouponService's
+	// PauseCampaign RPC.
+	CouponServicePauseCampaignProcedure = "/coupon.CouponService/PauseCampaign"
+	// CouponServiceResumeCampaignProcedure is the fully-qualified name of the CouponService's
+	// ResumeCampaign RPC.
+	CouponServiceResumeCampaignProcedure = "/coupon.CouponService/ResumeCampaign"
+	// CouponServiceCancelCampaignProcedure is the fully-qualified name of the CouponService's
+	// CancelCampaign RPC.
+	CouponServiceCancelCampaignProcedure = "/coupon.CouponService/CancelCampaign"
+	// CouponServiceUpdateCampaignProcedure is the fully-qualified name of the CouponService's
+	// UpdateCampaign RPC.
+	CouponServiceUpdateCampaignProcedure = "/coupon.CouponService/UpdateCampaign"
+	// CouponServiceValidateCouponProcedure is the fully-qualified name of the CouponService's
+	// ValidateCoupon RPC.
+	CouponServiceValidateCouponProcedure = "/coupon.CouponService/ValidateCoupon"
+	// CouponServiceImportCouponCodesProcedure is the fully-qualified name of the CouponService's
+	// ImportCouponCodes RPC.
+	CouponServiceImportCouponCodesProcedure = "/coupon.CouponService/ImportCouponCodes"
 )
 
 // CouponServiceClient is a client for the coupon.CouponService service.
@@ -58,6 +88,19 @@ type CouponServiceClient interface {
 	CreateCampaign(context.Context, *connect.Request[coupon.CreateCampaignRequest]) (*connect.Response[coupon.CreateCampaignResponse], error)
 	GetCampaign(context.Context, *connect.Request[coupon.GetCampaignRequest]) (*connect.Response[coupon.GetCampaignResponse], error)
 	IssueCoupon(context.Context, *connect.Request[coupon.IssueCouponRequest]) (*connect.Response[coupon.IssueCouponResponse], error)
+	RedeemCoupon(context.Context, *connect.Request[coupon.RedeemCouponRequest]) (*connect.Response[coupon.RedeemCouponResponse], error)
+	ListCampaigns(context.Context, *connect.Request[coupon.ListCampaignsRequest]) (*connect.Response[coupon.ListCampaignsResponse], error)
+	ListIssuedCoupons(context.Context, *connect.Request[coupon.ListIssuedCouponsRequest]) (*connect.Response[coupon.ListIssuedCouponsResponse], error)
+	// 서버 스트리밍: 발급 순서대로 쿠폰을 묶음 단위로 전송
+	StreamIssuedCoupons(context.Context, *connect.Request[coupon.StreamIssuedCouponsRequest]) (*connect.ServerStreamForClient[coupon.StreamIssuedCouponsResponse], error)
+	PauseCampaign(context.Context, *connect.Request[coupon.PauseCampaignRequest]) (*connect.Response[coupon.PauseCampaignResponse], error)
+	ResumeCampaign(context.Context, *connect.Request[coupon.ResumeCampaignRequest]) (*connect.Response[coupon.ResumeCampaignResponse], error)
+	CancelCampaign(context.Context, *connect.Request[coupon.CancelCampaignRequest]) (*connect.Response[coupon.CancelCampaignResponse], error)
+	UpdateCampaign(context.Context, *connect.Request[coupon.UpdateCampaignRequest]) (*connect.Response[coupon.UpdateCampaignResponse], error)
+	// 쿠폰 코드 확인: 오타(형식/검사 문자 오류)와 발급되지 않은 코드를 구분
+	ValidateCoupon(context.Context, *connect.Request[coupon.ValidateCouponRequest]) (*connect.Response[coupon.ValidateCouponResponse], error)
+	// 클라이언트 스트리밍: 미리 만들어진 코드 목록을 여러 메시지로 나눠 POOL 형식 캠페인에 등록
+	ImportCouponCodes(context.Context) *connect.ClientStreamForClient[coupon.ImportCouponCodesRequest, coupon.ImportCouponCodesResponse]
 }
 
 // NewCouponServiceClient constructs a client for the coupon.CouponService service. By default, it
@@ -89,14 +132,84 @@ func NewCouponServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(couponServiceMethods.ByName("IssueCoupon")),
 			connect.WithClientOptions(opts...),
 		),
+		redeemCoupon: connect.NewClient[coupon.RedeemCouponRequest, coupon.RedeemCouponResponse](
+			httpClient,
+			baseURL+CouponServiceRedeemCouponProcedure,
+			connect.WithSchema(couponServiceMethods.ByName("RedeemCoupon")),
+			connect.WithClientOptions(opts...),
+		),
+		listCampaigns: connect.NewClient[coupon.ListCampaignsRequest, coupon.ListCampaignsResponse](
+			httpClient,
+			baseURL+CouponServiceListCampaignsProcedure,
+			connect.WithSchema(couponServiceMethods.ByName("ListCampaigns")),
+			connect.WithClientOptions(opts...),
+		),
+		listIssuedCoupons: connect.NewClient[coupon.ListIssuedCouponsRequest, coupon.ListIssuedCouponsResponse](
+			httpClient,
+			baseURL+CouponServiceListIssuedCouponsProcedure,
+			connect.WithSchema(couponServiceMethods.ByName("ListIssuedCoupons")),
+			connect.WithClientOptions(opts...),
+		),
+		streamIssuedCoupons: connect.NewClient[coupon.StreamIssuedCouponsRequest, coupon.StreamIssuedCouponsResponse](
+			httpClient,
+			baseURL+CouponServiceStreamIssuedCouponsProcedure,
+			connect.WithSchema(couponServiceMethods.ByName("StreamIssuedCoupons")),
+			connect.WithClientOptions(opts...),
+		),
+		pauseCampaign: connect.NewClient[coupon.PauseCampaignRequest, coupon.PauseCampaignResponse](
+			httpClient,
+			baseURL+CouponServicePauseCampaignProcedure,
+			connect.WithSchema(couponServiceMethods.ByName("PauseCampaign")),
+			connect.WithClientOptions(opts...),
+		),
+		resumeCampaign: connect.NewClient[coupon.ResumeCampaignRequest, coupon.ResumeCampaignResponse](
+			httpClient,
+			baseURL+CouponServiceResumeCampaignProcedure,
+			connect.WithSchema(couponServiceMethods.ByName("ResumeCampaign")),
+			connect.WithClientOptions(opts...),
+		),
+		cancelCampaign: connect.NewClient[coupon.CancelCampaignRequest, coupon.CancelCampaignResponse](
+			httpClient,
+			baseURL+CouponServiceCancelCampaignProcedure,
+			connect.WithSchema(couponServiceMethods.ByName("CancelCampaign")),
+			connect.WithClientOptions(opts...),
+		),
+		updateCampaign: connect.NewClient[coupon.UpdateCampaignRequest, coupon.UpdateCampaignResponse](
+			httpClient,
+			baseURL+CouponServiceUpdateCampaignProcedure,
+			connect.WithSchema(couponServiceMethods.ByName("UpdateCampaign")),
+			connect.WithClientOptions(opts...),
+		),
+		validateCoupon: connect.NewClient[coupon.ValidateCouponRequest, coupon.ValidateCouponResponse](
+			httpClient,
+			baseURL+CouponServiceValidateCouponProcedure,
+			connect.WithSchema(couponServiceMethods.ByName("ValidateCoupon")),
+			connect.WithClientOptions(opts...),
+		),
+		importCouponCodes: connect.NewClient[coupon.ImportCouponCodesRequest, coupon.ImportCouponCodesResponse](
+			httpClient,
+			baseURL+CouponServiceImportCouponCodesProcedure,
+			connect.WithSchema(couponServiceMethods.ByName("ImportCouponCodes")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // couponServiceClient implements CouponServiceClient.
 type couponServiceClient struct {
-	createCampaign *connect.Client[coupon.CreateCampaignRequest, coupon.CreateCampaignResponse]
-	getCampaign    *connect.Client[coupon.GetCampaignRequest, coupon.GetCampaignResponse]
-	issueCoupon    *connect.Client[coupon.IssueCouponRequest, coupon.IssueCouponResponse]
+	createCampaign      *connect.Client[coupon.CreateCampaignRequest, coupon.CreateCampaignResponse]
+	getCampaign         *connect.Client[coupon.GetCampaignRequest, coupon.GetCampaignResponse]
+	issueCoupon         *connect.Client[coupon.IssueCouponRequest, coupon.IssueCouponResponse]
+	redeemCoupon        *connect.Client[coupon.RedeemCouponRequest, coupon.RedeemCouponResponse]
+	listCampaigns       *connect.Client[coupon.ListCampaignsRequest, coupon.ListCampaignsResponse]
+	listIssuedCoupons   *connect.Client[coupon.ListIssuedCouponsRequest, coupon.ListIssuedCouponsResponse]
+	streamIssuedCoupons *connect.Client[coupon.StreamIssuedCouponsRequest, coupon.StreamIssuedCouponsResponse]
+	pauseCampaign       *connect.Client[coupon.PauseCampaignRequest, coupon.PauseCampaignResponse]
+	resumeCampaign      *connect.Client[coupon.ResumeCampaignRequest, coupon.ResumeCampaignResponse]
+	cancelCampaign      *connect.Client[coupon.CancelCampaignRequest, coupon.CancelCampaignResponse]
+	updateCampaign      *connect.Client[coupon.UpdateCampaignRequest, coupon.UpdateCampaignResponse]
+	validateCoupon      *connect.Client[coupon.ValidateCouponRequest, coupon.ValidateCouponResponse]
+	importCouponCodes   *connect.Client[coupon.ImportCouponCodesRequest, coupon.ImportCouponCodesResponse]
 }
 
 // CreateCampaign calls coupon.CouponService.CreateCampaign.
@@ -114,6 +227,56 @@ func (c *couponServiceClient) IssueCoupon(ctx context.Context, req *connect.Requ
 	return c.issueCoupon.CallUnary(ctx, req)
 }
 
+// RedeemCoupon calls coupon.CouponService.RedeemCoupon.
+func (c *couponServiceClient) RedeemCoupon(ctx context.Context, req *connect.Request[coupon.RedeemCouponRequest]) (*connect.Response[coupon.RedeemCouponResponse], error) {
+	return c.redeemCoupon.CallUnary(ctx, req)
+}
+
+// ListCampaigns calls coupon.CouponService.ListCampaigns.
+func (c *couponServiceClient) ListCampaigns(ctx context.Context, req *connect.Request[coupon.ListCampaignsRequest]) (*connect.Response[coupon.ListCampaignsResponse], error) {
+	return c.listCampaigns.CallUnary(ctx, req)
+}
+
+// ListIssuedCoupons calls coupon.CouponService.ListIssuedCoupons.
+func (c *couponServiceClient) ListIssuedCoupons(ctx context.Context, req *connect.Request[coupon.ListIssuedCouponsRequest]) (*connect.Response[coupon.ListIssuedCouponsResponse], error) {
+	return c.listIssuedCoupons.CallUnary(ctx, req)
+}
+
+// StreamIssuedCoupons calls coupon.CouponService.StreamIssuedCoupons.
+func (c *couponServiceClient) StreamIssuedCoupons(ctx context.Context, req *connect.Request[coupon.StreamIssuedCouponsRequest]) (*connect.ServerStreamForClient[coupon.StreamIssuedCouponsResponse], error) {
+	return c.streamIssuedCoupons.CallServerStream(ctx, req)
+}
+
+// PauseCampaign calls coupon.CouponService.PauseCampaign.
+func (c *couponServiceClient) PauseCampaign(ctx context.Context, req *connect.Request[coupon.PauseCampaignRequest]) (*connect.Response[coupon.PauseCampaignResponse], error) {
+	return c.pauseCampaign.CallUnary(ctx, req)
+}
+
+// ResumeCampaign calls coupon.CouponService.ResumeCampaign.
+func (c *couponServiceClient) ResumeCampaign(ctx context.Context, req *connect.Request[coupon.ResumeCampaignRequest]) (*connect.Response[coupon.ResumeCampaignResponse], error) {
+	return c.resumeCampaign.CallUnary(ctx, req)
+}
+
+// CancelCampaign calls coupon.CouponService.CancelCampaign.
+func (c *couponServiceClient) CancelCampaign(ctx context.Context, req *connect.Request[coupon.CancelCampaignRequest]) (*connect.Response[coupon.CancelCampaignResponse], error) {
+	return c.cancelCampaign.CallUnary(ctx, req)
+}
+
+// UpdateCampaign calls coupon.CouponService.UpdateCampaign.
+func (c *couponServiceClient) UpdateCampaign(ctx context.Context, req *connect.Request[coupon.UpdateCampaignRequest]) (*connect.Response[coupon.UpdateCampaignResponse], error) {
+	return c.updateCampaign.CallUnary(ctx, req)
+}
+
+// ValidateCoupon calls coupon.CouponService.ValidateCoupon.
+func (c *couponServiceClient) ValidateCoupon(ctx context.Context, req *connect.Request[coupon.ValidateCouponRequest]) (*connect.Response[coupon.ValidateCouponResponse], error) {
+	return c.validateCoupon.CallUnary(ctx, req)
+}
+
+// ImportCouponCodes calls coupon.CouponService.ImportCouponCodes.
+func (c *couponServiceClient) ImportCouponCodes(ctx context.Context) *connect.ClientStreamForClient[coupon.ImportCouponCodesRequest, coupon.ImportCouponCodesResponse] {
+	return c.importCouponCodes.CallClientStream(ctx)
+}
+
 // CouponServiceHandler is an implementation of the coupon.CouponService service.
 type CouponServiceHandler interface {
 	// rpc: 원격 호출할 수 있는 메서드 정의
@@ -121,6 +284,19 @@ type CouponServiceHandler interface {
 	CreateCampaign(context.Context, *connect.Request[coupon.CreateCampaignRequest]) (*connect.Response[coupon.CreateCampaignResponse], error)
 	GetCampaign(context.Context, *connect.Request[coupon.GetCampaignRequest]) (*connect.Response[coupon.GetCampaignResponse], error)
 	IssueCoupon(context.Context, *connect.Request[coupon.IssueCouponRequest]) (*connect.Response[coupon.IssueCouponResponse], error)
+	RedeemCoupon(context.Context, *connect.Request[coupon.RedeemCouponRequest]) (*connect.Response[coupon.RedeemCouponResponse], error)
+	ListCampaigns(context.Context, *connect.Request[coupon.ListCampaignsRequest]) (*connect.Response[coupon.ListCampaignsResponse], error)
+	ListIssuedCoupons(context.Context, *connect.Request[coupon.ListIssuedCouponsRequest]) (*connect.Response[coupon.ListIssuedCouponsResponse], error)
+	// 서버 스트리밍: 발급 순서대로 쿠폰을 묶음 단위로 전송
+	StreamIssuedCoupons(context.Context, *connect.Request[coupon.StreamIssuedCouponsRequest], *connect.ServerStream[coupon.StreamIssuedCouponsResponse]) error
+	PauseCampaign(context.Context, *connect.Request[coupon.PauseCampaignRequest]) (*connect.Response[coupon.PauseCampaignResponse], error)
+	ResumeCampaign(context.Context, *connect.Request[coupon.ResumeCampaignRequest]) (*connect.Response[coupon.ResumeCampaignResponse], error)
+	CancelCampaign(context.Context, *connect.Request[coupon.CancelCampaignRequest]) (*connect.Response[coupon.CancelCampaignResponse], error)
+	UpdateCampaign(context.Context, *connect.Request[coupon.UpdateCampaignRequest]) (*connect.Response[coupon.UpdateCampaignResponse], error)
+	// 쿠폰 코드 확인: 오타(형식/검사 문자 오류)와 발급되지 않은 코드를 구분
+	ValidateCoupon(context.Context, *connect.Request[coupon.ValidateCouponRequest]) (*connect.Response[coupon.ValidateCouponResponse], error)
+	// 클라이언트 스트리밍: 미리 만들어진 코드 목록을 여러 메시지로 나눠 POOL 형식 캠페인에 등록
+	ImportCouponCodes(context.Context, *connect.ClientStream[coupon.ImportCouponCodesRequest]) (*connect.Response[coupon.ImportCouponCodesResponse], error)
 }
 
 // NewCouponServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -148,6 +324,66 @@ func NewCouponServiceHandler(svc CouponServiceHandler, opts ...connect.HandlerOp
 		connect.WithSchema(couponServiceMethods.ByName("IssueCoupon")),
 		connect.WithHandlerOptions(opts...),
 	)
+	couponServiceRedeemCouponHandler := connect.NewUnaryHandler(
+		CouponServiceRedeemCouponProcedure,
+		svc.RedeemCoupon,
+		connect.WithSchema(couponServiceMethods.ByName("RedeemCoupon")),
+		connect.WithHandlerOptions(opts...),
+	)
+	couponServiceListCampaignsHandler := connect.NewUnaryHandler(
+		CouponServiceListCampaignsProcedure,
+		svc.ListCampaigns,
+		connect.WithSchema(couponServiceMethods.ByName("ListCampaigns")),
+		connect.WithHandlerOptions(opts...),
+	)
+	couponServiceListIssuedCouponsHandler := connect.NewUnaryHandler(
+		CouponServiceListIssuedCouponsProcedure,
+		svc.ListIssuedCoupons,
+		connect.WithSchema(couponServiceMethods.ByName("ListIssuedCoupons")),
+		connect.WithHandlerOptions(opts...),
+	)
+	couponServiceStreamIssuedCouponsHandler := connect.NewServerStreamHandler(
+		CouponServiceStreamIssuedCouponsProcedure,
+		svc.StreamIssuedCoupons,
+		connect.WithSchema(couponServiceMethods.ByName("StreamIssuedCoupons")),
+		connect.WithHandlerOptions(opts...),
+	)
+	couponServicePauseCampaignHandler := connect.NewUnaryHandler(
+		CouponServicePauseCampaignProcedure,
+		svc.PauseCampaign,
+		connect.WithSchema(couponServiceMethods.ByName("PauseCampaign")),
+		connect.WithHandlerOptions(opts...),
+	)
+	couponServiceResumeCampaignHandler := connect.NewUnaryHandler(
+		CouponServiceResumeCampaignProcedure,
+		svc.ResumeCampaign,
+		connect.WithSchema(couponServiceMethods.ByName("ResumeCampaign")),
+		connect.WithHandlerOptions(opts...),
+	)
+	couponServiceCancelCampaignHandler := connect.NewUnaryHandler(
+		CouponServiceCancelCampaignProcedure,
+		svc.CancelCampaign,
+		connect.WithSchema(couponServiceMethods.ByName("CancelCampaign")),
+		connect.WithHandlerOptions(opts...),
+	)
+	couponServiceUpdateCampaignHandler := connect.NewUnaryHandler(
+		CouponServiceUpdateCampaignProcedure,
+		svc.UpdateCampaign,
+		connect.WithSchema(couponServiceMethods.ByName("UpdateCampaign")),
+		connect.WithHandlerOptions(opts...),
+	)
+	couponServiceValidateCouponHandler := connect.NewUnaryHandler(
+		CouponServiceValidateCouponProcedure,
+		svc.ValidateCoupon,
+		connect.WithSchema(couponServiceMethods.ByName("ValidateCoupon")),
+		connect.WithHandlerOptions(opts...),
+	)
+	couponServiceImportCouponCodesHandler := connect.NewClientStreamHandler(
+		CouponServiceImportCouponCodesProcedure,
+		svc.ImportCouponCodes,
+		connect.WithSchema(couponServiceMethods.ByName("ImportCouponCodes")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/coupon.CouponService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case CouponServiceCreateCampaignProcedure:
@@ -156,6 +392,26 @@ func NewCouponServiceHandler(svc CouponServiceHandler, opts ...connect.HandlerOp
 			couponServiceGetCampaignHandler.ServeHTTP(w, r)
 		case CouponServiceIssueCouponProcedure:
 			couponServiceIssueCouponHandler.ServeHTTP(w, r)
+		case CouponServiceRedeemCouponProcedure:
+			couponServiceRedeemCouponHandler.ServeHTTP(w, r)
+		case CouponServiceListCampaignsProcedure:
+			couponServiceListCampaignsHandler.ServeHTTP(w, r)
+		case CouponServiceListIssuedCouponsProcedure:
+			couponServiceListIssuedCouponsHandler.ServeHTTP(w, r)
+		case CouponServiceStreamIssuedCouponsProcedure:
+			couponServiceStreamIssuedCouponsHandler.ServeHTTP(w, r)
+		case CouponServicePauseCampaignProcedure:
+			couponServicePauseCampaignHandler.ServeHTTP(w, r)
+		case CouponServiceResumeCampaignProcedure:
+			couponServiceResumeCampaignHandler.ServeHTTP(w, r)
+		case CouponServiceCancelCampaignProcedure:
+			couponServiceCancelCampaignHandler.ServeHTTP(w, r)
+		case CouponServiceUpdateCampaignProcedure:
+			couponServiceUpdateCampaignHandler.ServeHTTP(w, r)
+		case CouponServiceValidateCouponProcedure:
+			couponServiceValidateCouponHandler.ServeHTTP(w, r)
+		case CouponServiceImportCouponCodesProcedure:
+			couponServiceImportCouponCodesHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -176,3 +432,43 @@ func (UnimplementedCouponServiceHandler) GetCampaign(context.Context, *connect.R
 func (UnimplementedCouponServiceHandler) IssueCoupon(context.Context, *connect.Request[coupon.IssueCouponRequest]) (*connect.Response[coupon.IssueCouponResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coupon.CouponService.IssueCoupon is not implemented"))
 }
+
+func (UnimplementedCouponServiceHandler) RedeemCoupon(context.Context, *connect.Request[coupon.RedeemCouponRequest]) (*connect.Response[coupon.RedeemCouponResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coupon.CouponService.RedeemCoupon is not implemented"))
+}
+
+func (UnimplementedCouponServiceHandler) ListCampaigns(context.Context, *connect.Request[coupon.ListCampaignsRequest]) (*connect.Response[coupon.ListCampaignsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coupon.CouponService.ListCampaigns is not implemented"))
+}
+
+func (UnimplementedCouponServiceHandler) ListIssuedCoupons(context.Context, *connect.Request[coupon.ListIssuedCouponsRequest]) (*connect.Response[coupon.ListIssuedCouponsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coupon.CouponService.ListIssuedCoupons is not implemented"))
+}
+
+func (UnimplementedCouponServiceHandler) StreamIssuedCoupons(context.Context, *connect.Request[coupon.StreamIssuedCouponsRequest], *connect.ServerStream[coupon.StreamIssuedCouponsResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("coupon.CouponService.StreamIssuedCoupons is not implemented"))
+}
+
+func (UnimplementedCouponServiceHandler) PauseCampaign(context.Context, *connect.Request[coupon.PauseCampaignRequest]) (*connect.Response[coupon.PauseCampaignResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coupon.CouponService.PauseCampaign is not implemented"))
+}
+
+func (UnimplementedCouponServiceHandler) ResumeCampaign(context.Context, *connect.Request[coupon.ResumeCampaignRequest]) (*connect.Response[coupon.ResumeCampaignResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coupon.CouponService.ResumeCampaign is not implemented"))
+}
+
+func (UnimplementedCouponServiceHandler) CancelCampaign(context.Context, *connect.Request[coupon.CancelCampaignRequest]) (*connect.Response[coupon.CancelCampaignResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coupon.CouponService.CancelCampaign is not implemented"))
+}
+
+func (UnimplementedCouponServiceHandler) UpdateCampaign(context.Context, *connect.Request[coupon.UpdateCampaignRequest]) (*connect.Response[coupon.UpdateCampaignResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coupon.CouponService.UpdateCampaign is not implemented"))
+}
+
+func (UnimplementedCouponServiceHandler) ValidateCoupon(context.Context, *connect.Request[coupon.ValidateCouponRequest]) (*connect.Response[coupon.ValidateCouponResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coupon.CouponService.ValidateCoupon is not implemented"))
+}
+
+func (UnimplementedCouponServiceHandler) ImportCouponCodes(context.Context, *connect.ClientStream[coupon.ImportCouponCodesRequest]) (*connect.Response[coupon.ImportCouponCodesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coupon.CouponService.ImportCouponCodes is not implemented"))
+}
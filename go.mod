@@ -4,8 +4,14 @@ go 1.24.3
 
 require (
 	connectrpc.com/connect v1.18.1
-	golang.org/x/net v0.40.0
+	github.com/lib/pq v1.10.9
+	github.com/redis/go-redis/v9 v9.9.0
+	golang.org/x/text v0.25.0
 	google.golang.org/protobuf v1.36.6
 )
 
-require golang.org/x/text v0.25.0 // indirect
+require (
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
+	golang.org/x/net v0.40.0 // indirect
+)
@@ -0,0 +1,142 @@
+package clock
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Clock 현재 시각을 알려주는 시계
+// 캠페인 시작/종료 판정, 발급/사용 시각 기록 등 시간에 따라 결과가 달라지는 모든 곳은 time.Now 대신 Clock 을 주입받아 사용
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// System 실제 시스템 시계
+func System() Clock {
+	return systemClock{}
+}
+
+// Notifier 시각을 직접 옮길 수 있는 시계 (Fake, Adjustable)
+// Changed 는 다음에 시각이 옮겨질 때 닫히는 채널. 시계 기준으로 기다리는 쪽은 이 채널로 다시 계산할 시점을 알 수 있음
+type Notifier interface {
+	Changed() <-chan struct{}
+}
+
+// ChangedOf clk 가 Notifier 면 Changed(), 아니면 nil (nil 채널은 select 에서 영원히 받지 않음)
+func ChangedOf(clk Clock) <-chan struct{} {
+	if notifier, ok := clk.(Notifier); ok {
+		return notifier.Changed()
+	}
+	return nil
+}
+
+// OrSystem clk 가 nil 이면 시스템 시계 (옵션 구조체의 Clock 기본값 처리용)
+func OrSystem(clk Clock) Clock {
+	if clk == nil {
+		return System()
+	}
+	return clk
+}
+
+// Fake 직접 움직이기 전까지 멈춰 있는 시계 (테스트용)
+type Fake struct {
+	mu      sync.Mutex
+	now     time.Time
+	changed chan struct{}
+}
+
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now, changed: make(chan struct{})}
+}
+
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Set 현재 시각을 now 로 변경
+func (f *Fake) Set(now time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = now
+	f.notifyLocked()
+}
+
+// Advance 현재 시각을 d 만큼 이동
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+	f.notifyLocked()
+}
+
+func (f *Fake) Changed() <-chan struct{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.changed
+}
+
+// notifyLocked 기다리는 쪽을 깨우고 다음 변경용 채널로 교체 (mu 를 잡은 상태에서 호출)
+func (f *Fake) notifyLocked() {
+	close(f.changed)
+	f.changed = make(chan struct{})
+}
+
+// Adjustable 기준 시계에 오프셋을 더한 시계 (오픈 리허설용)
+// 실제 시간처럼 계속 흐르면서도 특정 시각으로 옮기거나(Set) 앞당길(Advance) 수 있음
+type Adjustable struct {
+	base   Clock
+	offset atomic.Int64 // time.Duration
+
+	mu      sync.Mutex // changed 보호
+	changed chan struct{}
+}
+
+func NewAdjustable(base Clock, offset time.Duration) *Adjustable {
+	a := &Adjustable{base: base, changed: make(chan struct{})}
+	a.offset.Store(int64(offset))
+	return a
+}
+
+func (a *Adjustable) Now() time.Time {
+	return a.base.Now().Add(a.Offset())
+}
+
+// Offset 기준 시계와의 차이
+func (a *Adjustable) Offset() time.Duration {
+	return time.Duration(a.offset.Load())
+}
+
+// Set 현재 시각이 now 가 되도록 오프셋 조정 (이후로도 시간은 계속 흐름)
+func (a *Adjustable) Set(now time.Time) {
+	a.offset.Store(int64(now.Sub(a.base.Now())))
+	a.notify()
+}
+
+// Advance 현재 시각을 d 만큼 이동
+func (a *Adjustable) Advance(d time.Duration) {
+	a.offset.Add(int64(d))
+	a.notify()
+}
+
+func (a *Adjustable) Changed() <-chan struct{} {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.changed
+}
+
+// notify 기다리는 쪽을 깨우고 다음 변경용 채널로 교체
+func (a *Adjustable) notify() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	close(a.changed)
+	a.changed = make(chan struct{})
+}
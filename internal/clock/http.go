@@ -0,0 +1,99 @@
+package clock
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// state /debug/clock 응답
+type state struct {
+	Now    time.Time `json:"now"`
+	Offset string    `json:"offset"`
+}
+
+// NewHandler 서버 시계 조회/조작 핸들러 (-clock-control 로 켰을 때만 등록)
+//   - GET: 현재 시각과 실제 시간과의 차이
+//   - POST ?set=<RFC3339>: 현재 시각을 지정한 시각으로 변경
+//   - POST ?advance=<duration>: 현재 시각을 지정한 만큼 이동 (예: 90s, -1h)
+func NewHandler(clk *Adjustable) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+
+		case http.MethodPost:
+			query := r.URL.Query()
+			switch {
+			case query.Has("set"):
+				now, err := time.Parse(time.RFC3339, query.Get("set"))
+				if err != nil {
+					http.Error(w, fmt.Sprintf("set 은 RFC3339 형식이어야 합니다: %v", err), http.StatusBadRequest)
+					return
+				}
+				clk.Set(now)
+
+			case query.Has("advance"):
+				d, err := time.ParseDuration(query.Get("advance"))
+				if err != nil {
+					http.Error(w, fmt.Sprintf("advance 는 duration 형식이어야 합니다: %v", err), http.StatusBadRequest)
+					return
+				}
+				clk.Advance(d)
+
+			default:
+				http.Error(w, "set 또는 advance 를 지정해야 합니다", http.StatusBadRequest)
+				return
+			}
+
+		default:
+			w.Header().Set("Allow", "GET, POST")
+			http.Error(w, "지원하지 않는 메서드입니다", http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(state{Now: clk.Now(), Offset: clk.Offset().String()})
+	})
+}
+
+// Remote 서버 시계(/debug/clock)를 읽고 움직이는 클라이언트 (cmd/client, cmd/loadtest 용)
+// 서버가 시계 조작을 허용하지 않으면(-clock-control 미지정) 로컬 시계를 사용하고 Advance 는 그만큼 기다림
+type Remote struct {
+	client *http.Client
+	url    string
+}
+
+func NewRemote(client *http.Client, serverURL string) *Remote {
+	return &Remote{client: client, url: serverURL + "/debug/clock"}
+}
+
+func (r *Remote) Now() time.Time {
+	resp, err := r.client.Get(r.url)
+	if err != nil {
+		return time.Now()
+	}
+	defer resp.Body.Close()
+
+	var s state
+	if resp.StatusCode != http.StatusOK || json.NewDecoder(resp.Body).Decode(&s) != nil {
+		return time.Now()
+	}
+	return s.Now
+}
+
+// Advance 서버 시각을 d 만큼 이동. 조작할 수 없는 서버면 실제로 d 만큼 기다림
+// 반환값은 기다리지 않고 시계를 움직였는지 여부
+func (r *Remote) Advance(d time.Duration) bool {
+	resp, err := r.client.PostForm(r.url+"?advance="+url.QueryEscape(d.String()), nil)
+	if err == nil {
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusOK {
+			return true
+		}
+	}
+
+	time.Sleep(d)
+	return false
+}
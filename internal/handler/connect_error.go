@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"connectrpc.com/connect"
+	"context"
+	"coupon-issuance-system/gen/coupon"
+	"coupon-issuance-system/internal/model"
+	"errors"
+	"google.golang.org/protobuf/proto"
+)
+
+// errorCodes 도메인 오류 종류별 Connect 오류 코드. 없는 종류는 현재 상태에서 할 수 없는 요청(CodeFailedPrecondition)
+var errorCodes = map[coupon.ErrorReason]connect.Code{
+	coupon.ErrorReason_ERROR_REASON_INVALID_ARGUMENT:    connect.CodeInvalidArgument,
+	coupon.ErrorReason_ERROR_REASON_MALFORMED_CODE:      connect.CodeInvalidArgument,
+	coupon.ErrorReason_ERROR_REASON_CAMPAIGN_NOT_FOUND:  connect.CodeNotFound,
+	coupon.ErrorReason_ERROR_REASON_COUPON_NOT_FOUND:    connect.CodeNotFound,
+	coupon.ErrorReason_ERROR_REASON_CAMPAIGN_SOLD_OUT:   connect.CodeResourceExhausted,
+	coupon.ErrorReason_ERROR_REASON_USER_LIMIT_EXCEEDED: connect.CodeResourceExhausted,
+	coupon.ErrorReason_ERROR_REASON_COUPON_NOT_OWNED:    connect.CodePermissionDenied,
+	coupon.ErrorReason_ERROR_REASON_VERSION_CONFLICT:    connect.CodeAborted,
+	coupon.ErrorReason_ERROR_REASON_CODE_CONFLICT:       connect.CodeAlreadyExists,
+}
+
+// connectError 서비스 오류를 Connect 오류로 변환
+// 도메인 오류는 종류에 맞는 코드로 바꾸고 details 에 ErrorDetail(종류, 메시지, 필드별 위반 사항)과 기존 응답 메시지(legacy)를 담음
+// 기존 클라이언트는 details 의 응답 메시지에서 message 등 예전 필드를 그대로 읽을 수 있음
+// 클라이언트가 끊거나 시간이 초과되어 중단된 요청은 CodeCanceled / CodeDeadlineExceeded, 그 밖의 오류는 CodeInternal
+func connectError(err error, legacy proto.Message) *connect.Error {
+	var domainErr *model.DomainError
+	if !errors.As(err, &domainErr) {
+		switch {
+		case errors.Is(err, context.Canceled):
+			return connect.NewError(connect.CodeCanceled, err)
+		case errors.Is(err, context.DeadlineExceeded):
+			return connect.NewError(connect.CodeDeadlineExceeded, err)
+		}
+		return connect.NewError(connect.CodeInternal, err)
+	}
+
+	code, exists := errorCodes[domainErr.Reason]
+	if !exists {
+		code = connect.CodeFailedPrecondition
+	}
+
+	connectErr := connect.NewError(code, err)
+	if detail, detailErr := connect.NewErrorDetail(&coupon.ErrorDetail{
+		Reason:          domainErr.Reason,
+		Message:         domainErr.Message,
+		FieldViolations: domainErr.Violations,
+	}); detailErr == nil {
+		connectErr.AddDetail(detail)
+	}
+
+	// 응답 없이 실패한 경우(nil 포인터)는 담지 않음
+	if legacy != nil && legacy.ProtoReflect().IsValid() {
+		if detail, detailErr := connect.NewErrorDetail(legacy); detailErr == nil {
+			connectErr.AddDetail(detail)
+		}
+	}
+
+	return connectErr
+}
@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"connectrpc.com/connect"
+	"coupon-issuance-system/gen/coupon"
+	"coupon-issuance-system/internal/model"
+)
+
+// 클라이언트가 끊거나 시간이 초과된 요청은 서버 오류(CodeInternal)로 바꾸지 않음
+func TestConnectErrorCodes(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code connect.Code
+	}{
+		{"취소", fmt.Errorf("쿠폰 조회 실패: %w", context.Canceled), connect.CodeCanceled},
+		{"시간 초과", fmt.Errorf("쿠폰 조회 실패: %w", context.DeadlineExceeded), connect.CodeDeadlineExceeded},
+		{"도메인 오류", model.ErrCampaignNotFound, connect.CodeNotFound},
+		{"그 밖의 오류", fmt.Errorf("연결 실패"), connect.CodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := connectError(tt.err, (*coupon.IssueCouponResponse)(nil)).Code(); code != tt.code {
+				t.Errorf("오류 코드 예상 %s, 실제 %s", tt.code, code)
+			}
+		})
+	}
+}
@@ -6,9 +6,13 @@ import (
 	"coupon-issuance-system/gen/coupon"
 	"coupon-issuance-system/gen/coupon/couponconnect"
 	"coupon-issuance-system/internal/service"
+	"io"
 	"log"
 )
 
+// idempotencyKeyHeader IssueCoupon 재시도 식별용 HTTP 헤더
+const idempotencyKeyHeader = "Idempotency-Key"
+
 type CouponServiceHandler struct {
 	service *service.CouponService
 }
@@ -32,7 +36,7 @@ func (h *CouponServiceHandler) CreateCampaign(
 	response, err := h.service.CreateCampaign(ctx, req.Msg)
 	if err != nil {
 		log.Printf("CreateCampaign 처리 중 오류: %v", err)
-		return nil, connect.NewError(connect.CodeInternal, err)
+		return nil, connectError(err, response)
 	}
 
 	log.Printf("CreateCampaign 응답: %+v", response)
@@ -49,7 +53,7 @@ func (h *CouponServiceHandler) GetCampaign(
 	response, err := h.service.GetCampaign(ctx, req.Msg)
 	if err != nil {
 		log.Printf("GetCampaign 처리 중 오류: %v", err)
-		return nil, connect.NewError(connect.CodeInternal, err)
+		return nil, connectError(err, response)
 	}
 
 	log.Printf("GetCampaign 응답: 캠페인=%s, 발급된쿠폰수=%d",
@@ -63,23 +67,214 @@ func (h *CouponServiceHandler) IssueCoupon(
 	req *connect.Request[coupon.IssueCouponRequest],
 ) (*connect.Response[coupon.IssueCouponResponse], error) {
 
-	log.Printf("IssueCoupon 요청: CampaignID=%s, UserID=%s",
-		req.Msg.CampaignId, req.Msg.UserId)
+	// 메시지에 키가 없으면 Idempotency-Key 헤더를 사용
+	if req.Msg.IdempotencyKey == "" {
+		req.Msg.IdempotencyKey = req.Header().Get(idempotencyKeyHeader)
+	}
+
+	log.Printf("IssueCoupon 요청: CampaignID=%s, UserID=%s, IdempotencyKey=%s",
+		req.Msg.CampaignId, req.Msg.UserId, req.Msg.IdempotencyKey)
 
 	response, err := h.service.IssueCoupon(ctx, req.Msg)
 	if err != nil {
-		log.Printf("IssueCoupon 처리 중 오류: %v", err)
-		return nil, connect.NewError(connect.CodeInternal, err)
+		log.Printf("IssueCoupon 실패: UserID=%s, 이유=%v", req.Msg.UserId, err)
+		return nil, connectError(err, response)
+	}
+
+	log.Printf("IssueCoupon 성공: UserID=%s, CouponCode=%s",
+		req.Msg.UserId, response.Coupon.CouponCode)
+
+	return connect.NewResponse(response), nil
+}
+
+func (h *CouponServiceHandler) RedeemCoupon(
+	ctx context.Context,
+	req *connect.Request[coupon.RedeemCouponRequest],
+) (*connect.Response[coupon.RedeemCouponResponse], error) {
+
+	log.Printf("RedeemCoupon 요청: CouponCode=%s, UserID=%s, OrderID=%s",
+		req.Msg.CouponCode, req.Msg.UserId, req.Msg.OrderId)
+
+	response, err := h.service.RedeemCoupon(ctx, req.Msg)
+	if err != nil {
+		log.Printf("RedeemCoupon 실패: UserID=%s, 이유=%v", req.Msg.UserId, err)
+		return nil, connectError(err, response)
+	}
+
+	log.Printf("RedeemCoupon 성공: UserID=%s, CouponCode=%s",
+		req.Msg.UserId, response.Coupon.CouponCode)
+
+	return connect.NewResponse(response), nil
+}
+
+func (h *CouponServiceHandler) ListCampaigns(
+	ctx context.Context,
+	req *connect.Request[coupon.ListCampaignsRequest],
+) (*connect.Response[coupon.ListCampaignsResponse], error) {
+
+	log.Printf("ListCampaigns 요청: %+v", req.Msg)
+
+	response, err := h.service.ListCampaigns(ctx, req.Msg)
+	if err != nil {
+		log.Printf("ListCampaigns 처리 중 오류: %v", err)
+		return nil, connectError(err, response)
+	}
+
+	log.Printf("ListCampaigns 응답: 캠페인수=%d, 다음페이지=%t",
+		len(response.Campaigns), response.NextPageToken != "")
+
+	return connect.NewResponse(response), nil
+}
+
+func (h *CouponServiceHandler) ListIssuedCoupons(
+	ctx context.Context,
+	req *connect.Request[coupon.ListIssuedCouponsRequest],
+) (*connect.Response[coupon.ListIssuedCouponsResponse], error) {
+
+	log.Printf("ListIssuedCoupons 요청: %+v", req.Msg)
+
+	response, err := h.service.ListIssuedCoupons(ctx, req.Msg)
+	if err != nil {
+		log.Printf("ListIssuedCoupons 처리 중 오류: %v", err)
+		return nil, connectError(err, response)
+	}
+
+	log.Printf("ListIssuedCoupons 응답: 쿠폰수=%d, 다음페이지=%t",
+		len(response.Coupons), response.NextPageToken != "")
+
+	return connect.NewResponse(response), nil
+}
+
+func (h *CouponServiceHandler) StreamIssuedCoupons(
+	ctx context.Context,
+	req *connect.Request[coupon.StreamIssuedCouponsRequest],
+	stream *connect.ServerStream[coupon.StreamIssuedCouponsResponse], // 서버 스트리밍. Send 로 여러 번 응답
+) error {
+
+	log.Printf("StreamIssuedCoupons 요청: %+v", req.Msg)
+
+	sent := 0
+	err := h.service.StreamIssuedCoupons(ctx, req.Msg, func(batch *coupon.StreamIssuedCouponsResponse) error {
+		sent += len(batch.Coupons)
+		return stream.Send(batch)
+	})
+	if err != nil {
+		log.Printf("StreamIssuedCoupons 처리 중 오류: %v", err)
+		return connectError(err, nil)
+	}
+
+	log.Printf("StreamIssuedCoupons 완료: 전송한쿠폰수=%d", sent)
+	return nil
+}
+
+func (h *CouponServiceHandler) PauseCampaign(
+	ctx context.Context,
+	req *connect.Request[coupon.PauseCampaignRequest],
+) (*connect.Response[coupon.PauseCampaignResponse], error) {
+
+	log.Printf("PauseCampaign 요청: %+v", req.Msg)
+
+	response, err := h.service.PauseCampaign(ctx, req.Msg)
+	if err != nil {
+		log.Printf("PauseCampaign 처리 중 오류: %v", err)
+		return nil, connectError(err, response)
+	}
+
+	log.Printf("PauseCampaign 응답: 성공=%t, 메시지=%s", response.Success, response.Message)
+	return connect.NewResponse(response), nil
+}
+
+func (h *CouponServiceHandler) ResumeCampaign(
+	ctx context.Context,
+	req *connect.Request[coupon.ResumeCampaignRequest],
+) (*connect.Response[coupon.ResumeCampaignResponse], error) {
+
+	log.Printf("ResumeCampaign 요청: %+v", req.Msg)
+
+	response, err := h.service.ResumeCampaign(ctx, req.Msg)
+	if err != nil {
+		log.Printf("ResumeCampaign 처리 중 오류: %v", err)
+		return nil, connectError(err, response)
+	}
+
+	log.Printf("ResumeCampaign 응답: 성공=%t, 메시지=%s", response.Success, response.Message)
+	return connect.NewResponse(response), nil
+}
+
+func (h *CouponServiceHandler) CancelCampaign(
+	ctx context.Context,
+	req *connect.Request[coupon.CancelCampaignRequest],
+) (*connect.Response[coupon.CancelCampaignResponse], error) {
+
+	log.Printf("CancelCampaign 요청: %+v", req.Msg)
+
+	response, err := h.service.CancelCampaign(ctx, req.Msg)
+	if err != nil {
+		log.Printf("CancelCampaign 처리 중 오류: %v", err)
+		return nil, connectError(err, response)
 	}
 
-	if response.Success {
-		log.Printf("IssueCoupon 성공: UserID=%s, CouponCode=%s",
-			req.Msg.UserId, response.Coupon.CouponCode)
-	} else {
-		log.Printf("IssueCoupon 실패: UserID=%s, 이유=%s",
-			req.Msg.UserId, response.Message)
+	log.Printf("CancelCampaign 응답: 성공=%t, 메시지=%s", response.Success, response.Message)
+	return connect.NewResponse(response), nil
+}
+
+func (h *CouponServiceHandler) UpdateCampaign(
+	ctx context.Context,
+	req *connect.Request[coupon.UpdateCampaignRequest],
+) (*connect.Response[coupon.UpdateCampaignResponse], error) {
+
+	log.Printf("UpdateCampaign 요청: %+v", req.Msg)
+
+	response, err := h.service.UpdateCampaign(ctx, req.Msg)
+	if err != nil {
+		log.Printf("UpdateCampaign 처리 중 오류: %v", err)
+		return nil, connectError(err, response)
+	}
+
+	log.Printf("UpdateCampaign 응답: 성공=%t, 메시지=%s", response.Success, response.Message)
+	return connect.NewResponse(response), nil
+}
+
+func (h *CouponServiceHandler) ValidateCoupon(
+	ctx context.Context,
+	req *connect.Request[coupon.ValidateCouponRequest],
+) (*connect.Response[coupon.ValidateCouponResponse], error) {
+
+	log.Printf("ValidateCoupon 요청: CouponCode=%s", req.Msg.CouponCode)
+
+	response, err := h.service.ValidateCoupon(ctx, req.Msg)
+	if err != nil {
+		log.Printf("ValidateCoupon 처리 중 오류: %v", err)
+		return nil, connectError(err, response)
+	}
+
+	log.Printf("ValidateCoupon 응답: 결과=%s", response.Result)
+	return connect.NewResponse(response), nil
+}
+
+func (h *CouponServiceHandler) ImportCouponCodes(
+	ctx context.Context,
+	stream *connect.ClientStream[coupon.ImportCouponCodesRequest], // 클라이언트 스트리밍. Receive 로 여러 번 수신
+) (*connect.Response[coupon.ImportCouponCodesResponse], error) {
+
+	log.Printf("ImportCouponCodes 요청 시작")
+
+	response, err := h.service.ImportCouponCodes(ctx, func() (*coupon.ImportCouponCodesRequest, error) {
+		if !stream.Receive() {
+			if err := stream.Err(); err != nil {
+				return nil, err
+			}
+			return nil, io.EOF // 클라이언트가 전송을 마침
+		}
+		return stream.Msg(), nil
+	})
+	if err != nil {
+		log.Printf("ImportCouponCodes 처리 중 오류: %v", err)
+		return nil, connectError(err, response)
 	}
 
+	log.Printf("ImportCouponCodes 응답: 성공=%t, 등록한코드수=%d, 메시지=%s",
+		response.Success, response.ImportedCount, response.Message)
 	return connect.NewResponse(response), nil
 }
 
@@ -2,10 +2,15 @@ package model
 
 import (
 	pb "coupon-issuance-system/gen/coupon"
+	"fmt"
 	"log"
+	"math"
 	"time"
 )
 
+// DefaultMaxPerUser 사용자당 발급 한도를 지정하지 않았을 때 적용되는 기본값
+const DefaultMaxPerUser int32 = 1
+
 type Campaign struct {
 	*pb.Campaign // 임베딩할 때 필드명을 명시하지 않으면, Go는 타입명을 필드명으로 자동 사용
 }
@@ -14,53 +19,269 @@ func NewCampaign(pbCampaign *pb.Campaign) *Campaign {
 	return &Campaign{Campaign: pbCampaign}
 }
 
-func (c *Campaign) CanIssueCoupon() (bool, string) {
-	c.UpdateStatusIfNeeded()
+// CanIssueCoupon now 시점의 발급 가능 여부. 불가능하면 사유를 담은 *DomainError
+func (c *Campaign) CanIssueCoupon(now time.Time) error {
+	c.UpdateStatusIfNeeded(now)
 
 	switch c.Status {
 	case pb.CampaignStatus_UNSPECIFIED:
-		return false, "캠페인이 아직 시작되지 않았습니다"
+		return ErrCampaignNotStarted
 
 	case pb.CampaignStatus_WAITING:
-		return false, "캠페인이 아직 활성상태가 아닙니다"
+		return NewDomainError(pb.ErrorReason_ERROR_REASON_CAMPAIGN_NOT_STARTED, "캠페인이 아직 활성상태가 아닙니다")
 
 	case pb.CampaignStatus_ACTIVE:
 		if c.IssuedQuantity >= c.TotalQuantity {
-			return false, "쿠폰이 모두 소진되었습니다"
+			return ErrCampaignSoldOut
 		}
 
 	case pb.CampaignStatus_COMPLETED:
-		return false, "캠페인이 종료되었습니다"
+		return NewDomainError(pb.ErrorReason_ERROR_REASON_CAMPAIGN_SOLD_OUT, "캠페인이 종료되었습니다")
+
+	case pb.CampaignStatus_ENDED:
+		return ErrCampaignEnded
+
+	case pb.CampaignStatus_PAUSED:
+		return ErrCampaignPaused
+
+	case pb.CampaignStatus_CANCELLED:
+		return ErrCampaignCancelled
 	}
 
-	return true, ""
+	return nil
 }
 
-func (c *Campaign) UpdateStatusIfNeeded() {
-	now := time.Now().Unix()
+// CanIssueCouponTo 캠페인 발급 가능 여부 + 사용자당 발급 한도 확인
+// userIssuedCount 는 해당 사용자가 이 캠페인에서 이미 발급받은 쿠폰 수
+func (c *Campaign) CanIssueCouponTo(userIssuedCount int32, now time.Time) error {
+	if err := c.CanIssueCoupon(now); err != nil {
+		return err
+	}
 
-	if c.Status == pb.CampaignStatus_WAITING && now >= c.StartTime {
+	if userIssuedCount >= c.EffectiveMaxPerUser() {
+		return ErrUserLimitExceeded
+	}
 
-		c.Status = pb.CampaignStatus_ACTIVE
-		log.Printf("Campaign status 변경. before : %s, after : %s\n", pb.CampaignStatus_WAITING, c.Status)
+	return nil
+}
+
+// EffectiveMaxPerUser 사용자당 발급 한도 (미지정 시 기본값)
+func (c *Campaign) EffectiveMaxPerUser() int32 {
+	if c.MaxPerUser <= 0 {
+		return DefaultMaxPerUser
+	}
+	return c.MaxPerUser
+}
 
-	} else if c.Status == pb.CampaignStatus_ACTIVE && c.IssuedQuantity >= c.TotalQuantity {
+// UpdateStatusIfNeeded now 시점에 맞게 상태 갱신 (시작 시간 도래, 종료 시간 경과, 소진)
+// 시작/종료 시간은 밀리초 단위로 비교하므로 시작 시간이 속한 1초 안에서도 정확히 그 시각부터 발급 가능
+func (c *Campaign) UpdateStatusIfNeeded(now time.Time) {
+	if c.Status == pb.CampaignStatus_ACTIVE && c.IssuedQuantity >= c.TotalQuantity {
 
 		c.Status = pb.CampaignStatus_COMPLETED
 		log.Printf("Campaign status 변경. before : %s, after : %s\n", pb.CampaignStatus_ACTIVE, c.Status)
 
+	} else if (c.Status == pb.CampaignStatus_WAITING || c.Status == pb.CampaignStatus_ACTIVE) && c.HasEnded(now) {
+
+		before := c.Status
+		c.Status = pb.CampaignStatus_ENDED
+		log.Printf("Campaign status 변경. before : %s, after : %s\n", before, c.Status)
+
+	} else if c.Status == pb.CampaignStatus_WAITING && !c.BeforeStart(now) {
+
+		c.Status = pb.CampaignStatus_ACTIVE
+		log.Printf("Campaign status 변경. before : %s, after : %s\n", pb.CampaignStatus_WAITING, c.Status)
+
 	}
 }
 
-func (c *Campaign) IssueCoupon() (bool, string) {
-	canIssue, failMsg := c.CanIssueCoupon()
-	if !canIssue {
-		return false, failMsg
+// HasEnded 종료 시간이 지정되어 있고 now 가 종료 시간 이후인지 여부
+func (c *Campaign) HasEnded(now time.Time) bool {
+	endTime := c.EndTimeMillis()
+	return endTime > 0 && now.UnixMilli() >= endTime
+}
+
+// BeforeStart now 가 시작 시간 이전인지 여부
+func (c *Campaign) BeforeStart(now time.Time) bool {
+	return now.UnixMilli() < c.StartTimeMillis()
+}
+
+// StartTimeMillis 시작 시간 (Unix 밀리초)
+func (c *Campaign) StartTimeMillis() int64 {
+	return Millis(c.StartTime, c.StartTimeMs)
+}
+
+// EndTimeMillis 종료 시간 (Unix 밀리초, 0이면 종료 시간 없음)
+func (c *Campaign) EndTimeMillis() int64 {
+	return Millis(c.EndTime, c.EndTimeMs)
+}
+
+// SetStartTime 시작 시간(Unix 밀리초)과 예전 클라이언트용 초 단위 필드를 함께 변경
+func (c *Campaign) SetStartTime(millis int64) {
+	c.StartTimeMs = millis
+	c.StartTime = Seconds(millis)
+}
+
+// SetEndTime 종료 시간(Unix 밀리초, 0이면 종료 시간 없음)과 초 단위 필드를 함께 변경
+func (c *Campaign) SetEndTime(millis int64) {
+	c.EndTimeMs = millis
+	c.EndTime = Seconds(millis)
+}
+
+// FillMillis 밀리초 필드가 생기기 전에 저장된 캠페인의 밀리초 필드를 초 단위 필드로 채움
+func (c *Campaign) FillMillis() {
+	c.StartTimeMs = c.StartTimeMillis()
+	c.EndTimeMs = c.EndTimeMillis()
+}
+
+func (c *Campaign) IssueCoupon(now time.Time) error {
+	if err := c.CanIssueCoupon(now); err != nil {
+		return err
 	}
 
 	c.IssuedQuantity++
 	log.Printf("쿠폰이 발급되었습니다. 현재 발급된 쿠폰 수량: %d", c.IssuedQuantity)
 
-	c.UpdateStatusIfNeeded()
-	return true, ""
+	c.UpdateStatusIfNeeded(now)
+	return nil
+}
+
+// Pause 발급 일시 중지 (WAITING, ACTIVE → PAUSED)
+func (c *Campaign) Pause(now time.Time) error {
+	c.UpdateStatusIfNeeded(now)
+
+	if c.Status != pb.CampaignStatus_WAITING && c.Status != pb.CampaignStatus_ACTIVE {
+		return invalidCampaignState("대기중이거나 진행중인 캠페인만 일시 중지할 수 있습니다")
+	}
+
+	c.changeStatus(pb.CampaignStatus_PAUSED)
+	return nil
+}
+
+// Resume 일시 중지 해제 (PAUSED → WAITING 또는 ACTIVE)
+// 중지된 동안 시작 시간/종료 시간이 지났거나 이미 소진된 경우는 UpdateStatusIfNeeded 가 이어서 반영
+func (c *Campaign) Resume(now time.Time) error {
+	if c.Status != pb.CampaignStatus_PAUSED {
+		return invalidCampaignState("일시 중지된 캠페인만 재개할 수 있습니다")
+	}
+
+	if c.BeforeStart(now) {
+		c.changeStatus(pb.CampaignStatus_WAITING)
+	} else {
+		c.changeStatus(pb.CampaignStatus_ACTIVE)
+	}
+
+	c.UpdateStatusIfNeeded(now)
+	return nil
+}
+
+// Cancel 캠페인 취소. 이미 취소된 캠페인을 제외한 모든 상태에서 가능하며 되돌릴 수 없음
+func (c *Campaign) Cancel() error {
+	if c.Status == pb.CampaignStatus_CANCELLED {
+		return invalidCampaignState("이미 취소된 캠페인입니다")
+	}
+
+	c.changeStatus(pb.CampaignStatus_CANCELLED)
+	return nil
+}
+
+// CampaignChanges 관리자 수정 요청. nil 인 필드는 변경하지 않음
+type CampaignChanges struct {
+	Name          *string
+	StartTime     *int64 // Unix 밀리초
+	TotalQuantity *int32
+}
+
+// ApplyChanges 낙관적 동시성 제어(version) 하에 캠페인 정보 수정
+//   - 이름, 시작 시간: 시작 전에만 변경 가능
+//   - 총 수량: 이미 발급된 수량 미만으로는 변경 불가. 소진(COMPLETED)된 캠페인은 수량을 늘리면 다시 ACTIVE
+//   - 종료(ENDED) 또는 취소(CANCELLED)된 캠페인은 수정 불가
+func (c *Campaign) ApplyChanges(expectedVersion int64, changes CampaignChanges, now time.Time) error {
+	if c.Version != expectedVersion {
+		return NewDomainError(pb.ErrorReason_ERROR_REASON_VERSION_CONFLICT,
+			fmt.Sprintf("%s (현재 version: %d)", ErrVersionConflict.Message, c.Version))
+	}
+
+	c.UpdateStatusIfNeeded(now)
+
+	if c.Status == pb.CampaignStatus_ENDED || c.Status == pb.CampaignStatus_CANCELLED {
+		return invalidCampaignState("종료되었거나 취소된 캠페인은 수정할 수 없습니다")
+	}
+
+	notStarted := c.Status == pb.CampaignStatus_WAITING || (c.Status == pb.CampaignStatus_PAUSED && c.BeforeStart(now))
+
+	if (changes.Name != nil || changes.StartTime != nil) && !notStarted {
+		return invalidCampaignState("이름과 시작 시간은 캠페인 시작 전에만 변경할 수 있습니다")
+	}
+
+	if changes.StartTime != nil {
+		if *changes.StartTime < now.Truncate(time.Second).UnixMilli() { // 초 단위로 보낸 현재 시각도 허용
+			return invalidField("start_time", "시작 시간은 현재 시간 이후여야 합니다")
+		}
+		if endTime := c.EndTimeMillis(); endTime > 0 && *changes.StartTime >= endTime {
+			return invalidField("start_time", "시작 시간은 종료 시간 이전이어야 합니다")
+		}
+	}
+
+	if changes.TotalQuantity != nil && c.CodeFormat == pb.CodeFormat_CODE_FORMAT_POOL {
+		return invalidField("total_quantity", "코드 목록으로 발급하는 캠페인의 총 수량은 등록한 코드 수로 정해집니다")
+	}
+
+	if changes.TotalQuantity != nil && *changes.TotalQuantity < c.IssuedQuantity {
+		return invalidCampaignState(fmt.Sprintf("발급 수량은 이미 발급된 수량(%d개)보다 적을 수 없습니다", c.IssuedQuantity))
+	}
+
+	if changes.Name != nil {
+		c.Name = *changes.Name
+	}
+	if changes.StartTime != nil {
+		c.SetStartTime(*changes.StartTime)
+	}
+	if changes.TotalQuantity != nil {
+		c.TotalQuantity = *changes.TotalQuantity
+
+		// 소진되어 완료된 캠페인에 수량이 추가되면 다시 발급 가능 상태로
+		if c.Status == pb.CampaignStatus_COMPLETED && c.IssuedQuantity < c.TotalQuantity {
+			c.changeStatus(pb.CampaignStatus_ACTIVE)
+		}
+	}
+
+	c.Version++
+	c.UpdateStatusIfNeeded(now)
+	return nil
+}
+
+// AddPoolCodes 코드 풀에 count 개의 코드 등록 (POOL 형식 캠페인의 총 수량 = 등록한 코드 수)
+//   - 종료(ENDED) 또는 취소(CANCELLED)된 캠페인에는 등록 불가
+//   - 소진(COMPLETED)된 캠페인은 코드가 추가되면 다시 ACTIVE
+func (c *Campaign) AddPoolCodes(count int32, now time.Time) error {
+	if c.CodeFormat != pb.CodeFormat_CODE_FORMAT_POOL {
+		return invalidCampaignState("코드 목록은 POOL 형식 캠페인에만 등록할 수 있습니다")
+	}
+
+	c.UpdateStatusIfNeeded(now)
+
+	if c.Status == pb.CampaignStatus_ENDED || c.Status == pb.CampaignStatus_CANCELLED {
+		return invalidCampaignState("종료되었거나 취소된 캠페인에는 코드를 등록할 수 없습니다")
+	}
+
+	if int64(c.TotalQuantity)+int64(count) > math.MaxInt32 {
+		return InvalidArgument(fmt.Sprintf("캠페인에 등록할 수 있는 코드 수(%d개)를 넘었습니다", math.MaxInt32))
+	}
+
+	c.TotalQuantity += count
+
+	if c.Status == pb.CampaignStatus_COMPLETED && c.IssuedQuantity < c.TotalQuantity {
+		c.changeStatus(pb.CampaignStatus_ACTIVE)
+	}
+
+	c.Version++
+	c.UpdateStatusIfNeeded(now)
+	return nil
+}
+
+func (c *Campaign) changeStatus(status pb.CampaignStatus) {
+	before := c.Status
+	c.Status = status
+	log.Printf("Campaign status 변경. before : %s, after : %s\n", before, c.Status)
 }
@@ -0,0 +1,73 @@
+package model
+
+import (
+	pb "coupon-issuance-system/gen/coupon"
+	"log"
+	"time"
+)
+
+type Coupon struct {
+	*pb.Coupon
+}
+
+func NewCoupon(pbCoupon *pb.Coupon) *Coupon {
+	return &Coupon{Coupon: pbCoupon}
+}
+
+// CanRedeem 쿠폰 사용 가능 여부 확인. userID 는 발급 대상과 일치해야 함
+func (c *Coupon) CanRedeem(userID string) error {
+	if c.IssuedTo != userID {
+		return ErrCouponNotOwned
+	}
+
+	switch c.Status {
+	case pb.CouponStatus_COUPON_STATUS_UNSPECIFIED, pb.CouponStatus_ISSUED:
+		return nil
+
+	case pb.CouponStatus_REDEEMED:
+		return ErrCouponAlreadyRedeemed
+
+	case pb.CouponStatus_EXPIRED:
+		return ErrCouponExpired
+
+	case pb.CouponStatus_REVOKED:
+		return ErrCouponRevoked
+	}
+
+	return ErrCouponUnavailable
+}
+
+// Redeem now 시각에 쿠폰 사용 처리 (ISSUED → REDEEMED). 호출자가 동시성 제어를 책임짐
+func (c *Coupon) Redeem(userID, orderID string, now time.Time) error {
+	if err := c.CanRedeem(userID); err != nil {
+		return err
+	}
+
+	before := c.Status
+	c.Status = pb.CouponStatus_REDEEMED
+	c.RedeemedAt = now.Unix()
+	c.RedeemedAtMs = now.UnixMilli()
+	c.OrderId = orderID
+	log.Printf("Coupon status 변경. code : %s, before : %s, after : %s\n", c.CouponCode, before, c.Status)
+
+	return nil
+}
+
+// FillMillis 밀리초 필드가 생기기 전에 저장된 쿠폰의 밀리초 필드를 초 단위 필드로 채움
+func (c *Coupon) FillMillis() {
+	c.IssuedAtMs = Millis(c.IssuedAt, c.IssuedAtMs)
+	c.RedeemedAtMs = Millis(c.RedeemedAt, c.RedeemedAtMs)
+}
+
+// Revoke 쿠폰 회수 (ISSUED → REVOKED). 이미 사용/만료/회수된 쿠폰은 그대로 두고 false 반환
+func (c *Coupon) Revoke() bool {
+	if c.Status != pb.CouponStatus_COUPON_STATUS_UNSPECIFIED && c.Status != pb.CouponStatus_ISSUED {
+		return false
+	}
+
+	before := c.Status
+	c.Status = pb.CouponStatus_REVOKED
+	log.Printf("Coupon status 변경. code : %s, before : %s, after : %s\n", c.CouponCode, before, c.Status)
+
+	return true
+}
@@ -0,0 +1,58 @@
+package model
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// fullWidthOffset 전각 영문/숫자(U+FF01~U+FF5E)와 대응하는 ASCII 문자의 코드 포인트 차이
+const fullWidthOffset = 0xFEE0
+
+// NormalizeCouponCode 쿠폰 코드의 정규형. 생성, 저장, 조회 모두 정규형을 사용하므로 눈으로 보기에 같은 코드는 같은 쿠폰이 됨
+//   - 유니코드 NFC: 자모가 나뉘어 입력된 한글(NFD, 예: macOS 에서 복사한 코드)을 완성형으로 합침
+//   - 전각 영문/숫자는 ASCII 로 (예: "ＡＢ１２" → "AB12")
+//   - 공백, 보이지 않는 서식 문자(zero width space 등), 하이픈류('-', '‐', '–', '−' 등) 제거
+//
+// 영문 대소문자는 그대로 둠 (다른 코드로 취급)
+func NormalizeCouponCode(code string) string {
+	if isCanonicalASCII(code) {
+		return code // 대부분의 입력은 이미 정규형
+	}
+
+	var normalized strings.Builder
+	normalized.Grow(len(code))
+
+	for _, r := range norm.NFC.String(code) {
+		switch {
+		case r >= '！' && r <= '～' && isFullWidthAlphanumeric(r-fullWidthOffset):
+			normalized.WriteRune(r - fullWidthOffset)
+		case isCodeSeparator(r):
+			continue
+		default:
+			normalized.WriteRune(r)
+		}
+	}
+
+	return normalized.String()
+}
+
+func isCanonicalASCII(code string) bool {
+	for i := 0; i < len(code); i++ {
+		if c := code[i]; c >= utf8.RuneSelf || c == '-' || c <= ' ' || c == 0x7f {
+			return false
+		}
+	}
+	return true
+}
+
+func isFullWidthAlphanumeric(r rune) bool {
+	return r >= '0' && r <= '9' || r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z'
+}
+
+// isCodeSeparator 코드를 읽기 쉽게 나누거나 복사 과정에서 끼어드는 문자
+func isCodeSeparator(r rune) bool {
+	return unicode.IsSpace(r) || unicode.Is(unicode.Cf, r) || unicode.Is(unicode.Pd, r) || r == '−'
+}
@@ -0,0 +1,71 @@
+package model
+
+import (
+	"errors"
+
+	pb "coupon-issuance-system/gen/coupon"
+)
+
+// DomainError 도메인 규칙에 따른 요청 실패 (발급 불가 상태, 존재하지 않는 쿠폰, 잘못된 입력 등)
+// Reason 은 클라이언트가 분기할 수 있는 실패 종류, Message 는 사용자 안내 문구 (응답 message 필드에도 그대로 사용)
+// 저장소 장애 같은 시스템 오류와는 errors.As 또는 IsDomainError 로 구분
+type DomainError struct {
+	Reason     pb.ErrorReason
+	Message    string
+	Violations []*pb.FieldViolation // 요청 값 오류의 필드별 위반 사항 (특정 필드의 문제가 아니면 비어 있음)
+}
+
+func NewDomainError(reason pb.ErrorReason, message string) *DomainError {
+	return &DomainError{Reason: reason, Message: message}
+}
+
+func (e *DomainError) Error() string {
+	return e.Message
+}
+
+// Is 실패 종류가 같으면 메시지와 관계없이 같은 오류로 취급 (errors.Is(err, model.ErrCampaignSoldOut))
+func (e *DomainError) Is(target error) bool {
+	t, ok := target.(*DomainError)
+	return ok && t.Reason == e.Reason
+}
+
+// IsDomainError err 가 도메인 규칙에 따른 실패인지 여부 (false 면 시스템 오류)
+func IsDomainError(err error) bool {
+	var domainErr *DomainError
+	return errors.As(err, &domainErr)
+}
+
+// 종류별 대표 오류. 같은 종류라도 상황에 따라 메시지가 다르면 NewDomainError 로 만들고, 종류는 errors.Is 로 비교
+var (
+	ErrInvalidArgument    = NewDomainError(pb.ErrorReason_ERROR_REASON_INVALID_ARGUMENT, "잘못된 요청입니다")
+	ErrCampaignNotFound   = NewDomainError(pb.ErrorReason_ERROR_REASON_CAMPAIGN_NOT_FOUND, "존재하지 않는 캠페인입니다")
+	ErrCampaignNotStarted = NewDomainError(pb.ErrorReason_ERROR_REASON_CAMPAIGN_NOT_STARTED, "캠페인이 아직 시작되지 않았습니다")
+	ErrCampaignEnded      = NewDomainError(pb.ErrorReason_ERROR_REASON_CAMPAIGN_ENDED, "캠페인 기간이 종료되었습니다")
+	ErrCampaignPaused     = NewDomainError(pb.ErrorReason_ERROR_REASON_CAMPAIGN_PAUSED, "캠페인이 일시 중지되었습니다")
+	ErrCampaignCancelled  = NewDomainError(pb.ErrorReason_ERROR_REASON_CAMPAIGN_CANCELLED, "캠페인이 취소되었습니다")
+	ErrCampaignSoldOut    = NewDomainError(pb.ErrorReason_ERROR_REASON_CAMPAIGN_SOLD_OUT, "쿠폰이 모두 소진되었습니다")
+	ErrUserLimitExceeded  = NewDomainError(pb.ErrorReason_ERROR_REASON_USER_LIMIT_EXCEEDED, "사용자당 발급 가능한 쿠폰 수량을 초과했습니다")
+	ErrVersionConflict    = NewDomainError(pb.ErrorReason_ERROR_REASON_VERSION_CONFLICT, "다른 요청이 먼저 캠페인을 수정했습니다. 최신 정보를 다시 조회해 주세요")
+
+	ErrCouponNotFound        = NewDomainError(pb.ErrorReason_ERROR_REASON_COUPON_NOT_FOUND, "해당 쿠폰이 존재하지 않습니다")
+	ErrCouponNotOwned        = NewDomainError(pb.ErrorReason_ERROR_REASON_COUPON_NOT_OWNED, "본인에게 발급된 쿠폰만 사용할 수 있습니다")
+	ErrCouponAlreadyRedeemed = NewDomainError(pb.ErrorReason_ERROR_REASON_COUPON_ALREADY_REDEEMED, "이미 사용된 쿠폰입니다")
+	ErrCouponExpired         = NewDomainError(pb.ErrorReason_ERROR_REASON_COUPON_EXPIRED, "만료된 쿠폰입니다")
+	ErrCouponRevoked         = NewDomainError(pb.ErrorReason_ERROR_REASON_COUPON_REVOKED, "회수된 쿠폰입니다")
+	ErrCouponUnavailable     = NewDomainError(pb.ErrorReason_ERROR_REASON_COUPON_UNAVAILABLE, "사용할 수 없는 쿠폰 상태입니다")
+)
+
+// InvalidArgument 요청 값 오류. violations 는 문제가 된 필드별 위반 사항
+func InvalidArgument(message string, violations ...*pb.FieldViolation) *DomainError {
+	return &DomainError{Reason: pb.ErrorReason_ERROR_REASON_INVALID_ARGUMENT, Message: message, Violations: violations}
+}
+
+// invalidField 필드 하나의 값 오류
+func invalidField(field, description string) *DomainError {
+	return InvalidArgument(description, &pb.FieldViolation{Field: field, Description: description})
+}
+
+// invalidCampaignState 현재 캠페인 상태에서 할 수 없는 변경
+func invalidCampaignState(message string) *DomainError {
+	return NewDomainError(pb.ErrorReason_ERROR_REASON_INVALID_CAMPAIGN_STATE, message)
+}
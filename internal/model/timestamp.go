@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// 시각 필드는 밀리초 필드(*_ms)가 기준이고, 초 단위 필드는 예전 클라이언트를 위해 함께 채움
+// 밀리초 필드가 생기기 전에 저장된 데이터는 밀리초 필드가 0 이므로 초 단위 필드로 계산
+
+// Millis 밀리초 필드가 있으면 그 값, 없으면 초 단위 필드를 밀리초로 바꾼 값
+func Millis(seconds, millis int64) int64 {
+	if millis != 0 {
+		return millis
+	}
+	return seconds * 1000
+}
+
+// Seconds 밀리초를 초 단위 필드 값으로 (내림)
+func Seconds(millis int64) int64 {
+	return time.UnixMilli(millis).Unix()
+}
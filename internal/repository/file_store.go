@@ -0,0 +1,654 @@
+package repository
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"coupon-issuance-system/gen/coupon"
+	"coupon-issuance-system/internal/clock"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+/*
+# 파일 기반 영속 저장소 (WAL + 스냅샷)
+
+데이터 디렉터리 구성
+  - wal-000007.log      : 세그먼트. 변경 기록을 한 줄에 하나씩 추가 (가장 큰 번호가 현재 기록 중인 세그먼트)
+  - snapshot-000006.log : 세그먼트 000006 까지의 모든 기록을 압축한 스냅샷 (형식은 세그먼트와 동일)
+
+기록 한 줄 형식: "<crc32 8자리 hex> <JSON>\n"
+  - 프로세스가 기록 도중 죽으면 마지막 줄이 잘리거나 CRC 가 맞지 않음 → 부팅 시 해당 지점부터 잘라냄
+
+발급 흐름
+  캠페인 락 → 메모리 상태 변경 → WAL 기록(+fsync 정책) → 응답
+  WAL 기록이 실패하면 메모리 상태를 되돌리고 발급 실패로 응답하므로, 응답한 쿠폰은 항상 WAL 에 있음
+
+압축
+  세그먼트 기록 수가 SegmentRecords 를 넘으면 새 세그먼트로 교체하고,
+  백그라운드에서 (이전 스냅샷 + 닫힌 세그먼트들) 을 새 스냅샷으로 합친 뒤 합쳐진 세그먼트를 삭제
+*/
+
+// SyncPolicy WAL fsync 정책
+type SyncPolicy int
+
+const (
+	// SyncAlways 기록마다 fsync. 응답한 발급은 OS 크래시/정전 후에도 보존
+	SyncAlways SyncPolicy = iota
+	// SyncInterval SyncInterval 주기로 fsync. 프로세스 크래시에는 안전하지만 OS 크래시 시 마지막 주기의 기록은 유실될 수 있음
+	SyncInterval
+	// SyncNone fsync 하지 않고 OS 에 맡김. 프로세스 크래시에만 안전
+	SyncNone
+)
+
+// ParseSyncPolicy "always", "interval", "none" 문자열을 SyncPolicy 로 변환
+func ParseSyncPolicy(value string) (SyncPolicy, error) {
+	switch value {
+	case "always":
+		return SyncAlways, nil
+	case "interval":
+		return SyncInterval, nil
+	case "none":
+		return SyncNone, nil
+	}
+	return SyncAlways, fmt.Errorf("알 수 없는 fsync 정책입니다: %s (always, interval, none 중 하나)", value)
+}
+
+const (
+	defaultSyncInterval   = 100 * time.Millisecond
+	defaultSegmentRecords = 10000
+	snapshotCouponBatch   = 1000 // 스냅샷에서 한 줄에 담는 쿠폰 수
+)
+
+// FileStoreOptions 파일 저장소 설정
+type FileStoreOptions struct {
+	Dir            string        // 데이터 디렉터리 (없으면 생성)
+	SyncPolicy     SyncPolicy    // fsync 정책
+	SyncInterval   time.Duration // SyncInterval 정책의 fsync 주기 (0이면 100ms)
+	SegmentRecords int           // 세그먼트당 최대 기록 수. 넘으면 세그먼트를 교체하고 스냅샷으로 압축 (0이면 10000)
+	Clock          clock.Clock   // 캠페인 상태 갱신과 발급/사용 시각 기준 (nil 이면 시스템 시계)
+}
+
+// FileStore WAL 과 스냅샷으로 메모리 저장소를 영속화하는 저장소
+// 조회와 동시성 제어는 메모리 저장소가 그대로 담당하고, FileStore 는 변경 기록만 담당
+type FileStore struct {
+	opts         FileStoreOptions
+	campaignRepo *MemoryCampaignRepository
+	couponRepo   *MemoryCouponRepository
+
+	mutex   sync.Mutex // 아래 필드 보호
+	file    *os.File   // 현재 기록 중인 세그먼트
+	segment int        // 현재 세그먼트 번호
+	records int        // 현재 세그먼트의 기록 수
+	dirty   bool       // fsync 되지 않은 기록 존재 여부
+	failed  error      // 기록 실패 이후에는 WAL 이 손상되지 않도록 더 이상 기록하지 않음
+	closed  bool
+
+	compactMutex sync.Mutex // 압축은 한 번에 하나씩
+	stop         chan struct{}
+	wg           sync.WaitGroup
+}
+
+// OpenFileStore 스냅샷과 세그먼트를 순서대로 재생하여 상태를 복구하고 새 세그먼트를 열어 기록을 시작
+func OpenFileStore(opts FileStoreOptions) (*FileStore, error) {
+	if opts.SyncInterval <= 0 {
+		opts.SyncInterval = defaultSyncInterval
+	}
+	if opts.SegmentRecords <= 0 {
+		opts.SegmentRecords = defaultSegmentRecords
+	}
+	opts.Clock = clock.OrSystem(opts.Clock)
+
+	if err := os.MkdirAll(opts.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("데이터 디렉터리 생성 실패: %w", err)
+	}
+
+	campaignRepo := NewMemoryCampaignRepository(opts.Clock)
+	couponRepo := NewMemoryCouponRepository(campaignRepo)
+
+	lastSegment, err := replayDir(opts.Dir, campaignRepo, couponRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &FileStore{
+		opts:         opts,
+		campaignRepo: campaignRepo,
+		couponRepo:   couponRepo,
+		segment:      lastSegment,
+		stop:         make(chan struct{}),
+	}
+
+	// 이전 세그먼트에 이어 쓰지 않고 항상 새 세그먼트에 기록
+	if err := s.openNextSegment(); err != nil {
+		return nil, err
+	}
+
+	campaignRepo.journal = s // 복구가 끝난 뒤에 연결해야 재생한 기록이 다시 기록되지 않음
+
+	log.Printf("파일 저장소 복구 완료. 경로: %s, 캠페인: %d개, 쿠폰: %d개",
+		opts.Dir, len(campaignRepo.campaigns), couponRepo.couponCount())
+
+	if opts.SyncPolicy == SyncInterval {
+		s.wg.Add(1)
+		go s.syncLoop()
+	}
+
+	// 이전 실행에서 압축되지 않은 세그먼트 정리
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.compact()
+	}()
+
+	return s, nil
+}
+
+// CampaignRepository 영속화되는 캠페인 저장소
+func (s *FileStore) CampaignRepository() CampaignRepository {
+	return s.campaignRepo
+}
+
+// CouponRepository 영속화되는 쿠폰 저장소
+func (s *FileStore) CouponRepository() CouponRepository {
+	return s.couponRepo
+}
+
+// Close 진행 중인 압축을 기다리고 남은 기록을 fsync 한 뒤 파일을 닫음
+func (s *FileStore) Close() error {
+	s.mutex.Lock()
+	if s.closed {
+		s.mutex.Unlock()
+		return nil
+	}
+	s.closed = true
+	s.mutex.Unlock()
+
+	close(s.stop)
+	s.wg.Wait()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := s.file.Sync(); err != nil {
+		s.file.Close()
+		return fmt.Errorf("WAL fsync 실패: %w", err)
+	}
+	return s.file.Close()
+}
+
+// append journal 구현. 저장소 락을 잡은 상태에서 호출됨
+func (s *FileStore) append(rec journalRecord) error {
+	line, err := encodeRecord(rec)
+	if err != nil {
+		return fmt.Errorf("WAL 기록 직렬화 실패: %w", err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.closed {
+		return errors.New("파일 저장소가 닫혔습니다")
+	}
+	if s.failed != nil {
+		return fmt.Errorf("이전 WAL 기록 실패로 기록할 수 없습니다: %w", s.failed)
+	}
+
+	// 버퍼 없이 바로 write 하므로 응답 시점에는 최소한 OS 페이지 캐시에 있음 (프로세스 크래시에 안전)
+	if _, err := s.file.Write(line); err != nil {
+		s.failed = err
+		return fmt.Errorf("WAL 기록 실패: %w", err)
+	}
+
+	if s.opts.SyncPolicy == SyncAlways {
+		if err := s.file.Sync(); err != nil {
+			s.failed = err
+			return fmt.Errorf("WAL fsync 실패: %w", err)
+		}
+	} else {
+		s.dirty = true
+	}
+
+	s.records++
+	if s.records >= s.opts.SegmentRecords {
+		if err := s.rotateLocked(); err != nil {
+			log.Printf("WAL 세그먼트 교체 실패: %v", err)
+		} else {
+			s.wg.Add(1)
+			go func() {
+				defer s.wg.Done()
+				s.compact()
+			}()
+		}
+	}
+
+	return nil
+}
+
+// rotateLocked 현재 세그먼트를 닫고 새 세그먼트를 엶 (mutex 를 잡은 상태에서 호출)
+func (s *FileStore) rotateLocked() error {
+	if err := s.file.Sync(); err != nil {
+		return err
+	}
+	s.dirty = false
+
+	if err := s.file.Close(); err != nil {
+		return err
+	}
+
+	return s.openNextSegment()
+}
+
+func (s *FileStore) openNextSegment() error {
+	next := s.segment + 1
+
+	file, err := os.OpenFile(filepath.Join(s.opts.Dir, segmentFileName(next)), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("WAL 세그먼트 생성 실패: %w", err)
+	}
+	if err := syncDir(s.opts.Dir); err != nil {
+		file.Close()
+		return err
+	}
+
+	s.file = file
+	s.segment = next
+	s.records = 0
+	return nil
+}
+
+// syncLoop SyncInterval 정책에서 주기적으로 fsync
+func (s *FileStore) syncLoop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.opts.SyncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			s.mutex.Lock()
+			if s.dirty && s.failed == nil {
+				if err := s.file.Sync(); err != nil {
+					s.failed = err
+					log.Printf("WAL fsync 실패: %v", err)
+				}
+				s.dirty = false
+			}
+			s.mutex.Unlock()
+		}
+	}
+}
+
+// compact 이전 스냅샷 + 닫힌 세그먼트들을 새 스냅샷으로 합치고 합쳐진 파일을 삭제
+// 현재 기록 중인 세그먼트는 건드리지 않으므로 발급 경로를 막지 않음
+func (s *FileStore) compact() {
+	s.compactMutex.Lock()
+	defer s.compactMutex.Unlock()
+
+	s.mutex.Lock()
+	activeSegment := s.segment
+	s.mutex.Unlock()
+
+	snapshot, segments, err := listDataFiles(s.opts.Dir)
+	if err != nil {
+		log.Printf("스냅샷 압축 실패: %v", err)
+		return
+	}
+
+	var closed []int
+	for _, segment := range segments {
+		if segment > snapshot && segment < activeSegment {
+			closed = append(closed, segment)
+		}
+	}
+	if len(closed) == 0 {
+		return
+	}
+
+	// 별도의 메모리 저장소에 재생한 뒤 그 상태를 그대로 스냅샷으로 기록
+	campaignRepo := NewMemoryCampaignRepository(s.opts.Clock)
+	couponRepo := NewMemoryCouponRepository(campaignRepo)
+
+	if snapshot > 0 {
+		if _, err := replayFile(filepath.Join(s.opts.Dir, snapshotFileName(snapshot)), campaignRepo, couponRepo, false); err != nil {
+			log.Printf("스냅샷 압축 실패: %v", err)
+			return
+		}
+	}
+	for _, segment := range closed {
+		if _, err := replayFile(filepath.Join(s.opts.Dir, segmentFileName(segment)), campaignRepo, couponRepo, false); err != nil {
+			log.Printf("스냅샷 압축 실패: %v", err)
+			return
+		}
+	}
+
+	newSnapshot := closed[len(closed)-1]
+	if err := writeSnapshot(s.opts.Dir, newSnapshot, campaignRepo, couponRepo); err != nil {
+		log.Printf("스냅샷 기록 실패: %v", err)
+		return
+	}
+
+	// 새 스냅샷이 자리 잡은 뒤에만 이전 파일 삭제 (중간에 죽어도 재생 결과는 같음)
+	for _, segment := range closed {
+		os.Remove(filepath.Join(s.opts.Dir, segmentFileName(segment)))
+	}
+	if snapshot > 0 {
+		os.Remove(filepath.Join(s.opts.Dir, snapshotFileName(snapshot)))
+	}
+
+	log.Printf("스냅샷 압축 완료. 세그먼트 %d개 → %s", len(closed), snapshotFileName(newSnapshot))
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////
+// 파일 형식
+
+func segmentFileName(segment int) string {
+	return fmt.Sprintf("wal-%06d.log", segment)
+}
+
+func snapshotFileName(segment int) string {
+	return fmt.Sprintf("snapshot-%06d.log", segment)
+}
+
+// listDataFiles 가장 최근 스냅샷 번호(없으면 0)와 세그먼트 번호 목록(오름차순)
+func listDataFiles(dir string) (snapshot int, segments []int, err error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, nil, fmt.Errorf("데이터 디렉터리 조회 실패: %w", err)
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if number, ok := parseFileNumber(name, "snapshot-"); ok {
+			snapshot = max(snapshot, number)
+		} else if number, ok := parseFileNumber(name, "wal-"); ok {
+			segments = append(segments, number)
+		}
+	}
+
+	sort.Ints(segments)
+	return snapshot, segments, nil
+}
+
+func parseFileNumber(name, prefix string) (int, bool) {
+	if !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ".log") {
+		return 0, false
+	}
+
+	number, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(name, prefix), ".log"))
+	if err != nil {
+		return 0, false
+	}
+	return number, true
+}
+
+// replayDir 스냅샷 → 이후 세그먼트 순서로 재생하고 마지막 세그먼트 번호를 반환
+func replayDir(dir string, campaignRepo *MemoryCampaignRepository, couponRepo *MemoryCouponRepository) (int, error) {
+	snapshot, segments, err := listDataFiles(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	lastSegment := snapshot
+
+	if snapshot > 0 {
+		if _, err := replayFile(filepath.Join(dir, snapshotFileName(snapshot)), campaignRepo, couponRepo, false); err != nil {
+			return 0, err
+		}
+	}
+
+	var pending []int
+	for _, segment := range segments {
+		if segment > snapshot {
+			pending = append(pending, segment)
+		}
+	}
+
+	for i, segment := range pending {
+		// 마지막 세그먼트만 기록 도중 끊긴 꼬리를 허용 (그 이전 세그먼트는 정상적으로 닫혔어야 함)
+		isLast := i == len(pending)-1
+		if _, err := replayFile(filepath.Join(dir, segmentFileName(segment)), campaignRepo, couponRepo, isLast); err != nil {
+			return 0, err
+		}
+		lastSegment = segment
+	}
+
+	return lastSegment, nil
+}
+
+// replayFile 파일의 기록을 순서대로 반영하고 반영한 기록 수를 반환
+// allowTornTail 이면 손상된 줄을 만났을 때 그 지점부터 파일을 잘라내고 정상 종료
+func replayFile(
+	path string,
+	campaignRepo *MemoryCampaignRepository,
+	couponRepo *MemoryCouponRepository,
+	allowTornTail bool,
+) (int, error) {
+
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, fmt.Errorf("파일 열기 실패: %w", err)
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	var offset int64
+	count := 0
+
+	for {
+		line, readErr := reader.ReadBytes('\n')
+		if len(line) == 0 && readErr == io.EOF {
+			return count, nil
+		}
+
+		rec, decodeErr := decodeRecord(line)
+		if decodeErr == nil && readErr == nil {
+			if err := applyRecord(campaignRepo, couponRepo, rec); err != nil {
+				return count, fmt.Errorf("%s 의 %d번째 기록 반영 실패: %w", filepath.Base(path), count+1, err)
+			}
+			offset += int64(len(line))
+			count++
+			continue
+		}
+
+		if readErr != nil && readErr != io.EOF {
+			return count, fmt.Errorf("%s 읽기 실패: %w", filepath.Base(path), readErr)
+		}
+		if !allowTornTail {
+			return count, fmt.Errorf("%s 의 %d번째 기록이 손상되었습니다", filepath.Base(path), count+1)
+		}
+
+		log.Printf("WAL 끝부분이 손상되어 잘라냅니다. 파일: %s, 위치: %d", filepath.Base(path), offset)
+		if err := os.Truncate(path, offset); err != nil {
+			return count, fmt.Errorf("손상된 WAL 정리 실패: %w", err)
+		}
+		return count, nil
+	}
+}
+
+// writeSnapshot 저장소 상태를 임시 파일에 기록하고 fsync 후 이름을 바꿔 원자적으로 교체
+func writeSnapshot(dir string, segment int, campaignRepo *MemoryCampaignRepository, couponRepo *MemoryCouponRepository) error {
+	tmpPath := filepath.Join(dir, snapshotFileName(segment)+".tmp")
+
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpPath) // rename 에 성공하면 아무 일도 하지 않음
+
+	writer := bufio.NewWriter(file)
+	write := func(rec journalRecord) error {
+		line, err := encodeRecord(rec)
+		if err != nil {
+			return err
+		}
+		_, err = writer.Write(line)
+		return err
+	}
+
+	campaignIDs := make([]string, 0, len(campaignRepo.campaigns))
+	for id := range campaignRepo.campaigns {
+		campaignIDs = append(campaignIDs, id)
+	}
+	sort.Strings(campaignIDs)
+
+	for _, id := range campaignIDs {
+		if err := write(journalRecord{kind: recordCampaignPut, campaign: campaignRepo.campaigns[id]}); err != nil {
+			file.Close()
+			return err
+		}
+	}
+
+	// 삭제된 캠페인의 쿠폰도 코드 유일성을 위해 유지
+	couponCampaignIDs := make([]string, 0, len(couponRepo.buckets))
+	for id := range couponRepo.buckets {
+		couponCampaignIDs = append(couponCampaignIDs, id)
+	}
+	sort.Strings(couponCampaignIDs)
+
+	for _, id := range couponCampaignIDs {
+		coupons := couponRepo.buckets[id].coupons
+		for start := 0; start < len(coupons); start += snapshotCouponBatch {
+			end := min(start+snapshotCouponBatch, len(coupons))
+			if err := write(journalRecord{kind: recordCouponPut, coupons: coupons[start:end]}); err != nil {
+				file.Close()
+				return err
+			}
+		}
+	}
+
+	// 코드 풀은 발급된 코드를 빼고 남은 코드만 (쿠폰 뒤에 기록해야 재생 시 풀 위치가 맞음)
+	for _, id := range couponCampaignIDs {
+		bucket := couponRepo.buckets[id]
+		remaining := bucket.pool[bucket.poolNext:]
+		for start := 0; start < len(remaining); start += snapshotCouponBatch {
+			end := min(start+snapshotCouponBatch, len(remaining))
+			if err := write(journalRecord{kind: recordCodePool, campaignID: id, codes: remaining[start:end]}); err != nil {
+				file.Close()
+				return err
+			}
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmpPath, filepath.Join(dir, snapshotFileName(segment))); err != nil {
+		return err
+	}
+	return syncDir(dir)
+}
+
+// syncDir 파일 생성/이름 변경이 디렉터리 엔트리에 반영되도록 디렉터리를 fsync
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
+
+// recordJSON 기록 한 줄의 JSON 형식. 메시지는 protojson 으로 직렬화
+type recordJSON struct {
+	Kind       recordKind        `json:"k"`
+	CampaignID string            `json:"id,omitempty"`
+	Campaign   json.RawMessage   `json:"campaign,omitempty"`
+	Coupons    []json.RawMessage `json:"coupons,omitempty"`
+	Codes      []string          `json:"codes,omitempty"`
+}
+
+func encodeRecord(rec journalRecord) ([]byte, error) {
+	encoded := recordJSON{Kind: rec.kind, CampaignID: rec.campaignID, Codes: rec.codes}
+
+	if rec.campaign != nil {
+		raw, err := protojson.Marshal(rec.campaign)
+		if err != nil {
+			return nil, err
+		}
+		encoded.Campaign = raw
+	}
+
+	for _, cp := range rec.coupons {
+		raw, err := protojson.Marshal(cp)
+		if err != nil {
+			return nil, err
+		}
+		encoded.Coupons = append(encoded.Coupons, raw)
+	}
+
+	payload, err := json.Marshal(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	line := make([]byte, 0, len(payload)+10)
+	line = fmt.Appendf(line, "%08x ", crc32.ChecksumIEEE(payload))
+	line = append(line, payload...)
+	return append(line, '\n'), nil
+}
+
+func decodeRecord(line []byte) (journalRecord, error) {
+	line = bytes.TrimSuffix(line, []byte("\n"))
+	if len(line) < 10 || line[8] != ' ' {
+		return journalRecord{}, errors.New("잘못된 기록 형식")
+	}
+
+	checksum, err := strconv.ParseUint(string(line[:8]), 16, 32)
+	if err != nil {
+		return journalRecord{}, err
+	}
+
+	payload := line[9:]
+	if crc32.ChecksumIEEE(payload) != uint32(checksum) {
+		return journalRecord{}, errors.New("CRC 불일치")
+	}
+
+	var decoded recordJSON
+	if err := json.Unmarshal(payload, &decoded); err != nil {
+		return journalRecord{}, err
+	}
+
+	rec := journalRecord{kind: decoded.Kind, campaignID: decoded.CampaignID, codes: decoded.Codes}
+
+	if decoded.Campaign != nil {
+		rec.campaign = &coupon.Campaign{}
+		if err := protojson.Unmarshal(decoded.Campaign, rec.campaign); err != nil {
+			return journalRecord{}, err
+		}
+	}
+
+	for _, raw := range decoded.Coupons {
+		cp := &coupon.Coupon{}
+		if err := protojson.Unmarshal(raw, cp); err != nil {
+			return journalRecord{}, err
+		}
+		rec.coupons = append(rec.coupons, cp)
+	}
+
+	return rec, nil
+}
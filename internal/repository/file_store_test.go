@@ -0,0 +1,245 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"coupon-issuance-system/gen/coupon"
+	"coupon-issuance-system/internal/model"
+)
+
+func openTestFileStore(t *testing.T, dir string, segmentRecords int) *FileStore {
+	t.Helper()
+
+	store, err := OpenFileStore(FileStoreOptions{Dir: dir, SyncPolicy: SyncNone, SegmentRecords: segmentRecords})
+	if err != nil {
+		t.Fatalf("파일 저장소 열기 실패: %v", err)
+	}
+	return store
+}
+
+// 재시작 후에도 발급/사용 상태가 그대로 복구되어야 함
+func TestFileStoreReopen(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	store := openTestFileStore(t, dir, 0)
+	store.CampaignRepository().Save(ctx, &coupon.Campaign{
+		CampaignId:    "f1",
+		TotalQuantity: 3,
+		Status:        coupon.CampaignStatus_ACTIVE,
+		StartTime:     time.Now().Unix(),
+	})
+
+	for i := 0; i < 3; i++ {
+		if _, err := store.CouponRepository().IssueCoupon(ctx, "f1", fmt.Sprintf("user-%d", i), fmt.Sprintf("CODE%d", i)); err != nil {
+			t.Fatalf("발급 실패: %v", err)
+		}
+	}
+	if _, err := store.CouponRepository().RedeemCoupon(ctx, "CODE0", "user-0", "order-1"); err != nil {
+		t.Fatalf("사용 실패: %v", err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened := openTestFileStore(t, dir, 0)
+	defer reopened.Close()
+
+	campaign, err := reopened.CampaignRepository().GetByID(ctx, "f1")
+	if err != nil || campaign.IssuedQuantity != 3 || campaign.Status != coupon.CampaignStatus_COMPLETED {
+		t.Fatalf("캠페인 복구 실패: %v %v", campaign, err)
+	}
+
+	redeemed, _ := reopened.CouponRepository().GetByCode(ctx, "CODE0")
+	if redeemed == nil || redeemed.Status != coupon.CouponStatus_REDEEMED || redeemed.OrderId != "order-1" {
+		t.Fatalf("쿠폰 사용 상태 복구 실패: %v", redeemed)
+	}
+
+	// 복구된 발급 수량 기준으로 초과 발급이 막혀야 함
+	if _, err := reopened.CouponRepository().IssueCoupon(ctx, "f1", "user-9", "CODE9"); !errors.Is(err, model.ErrCampaignSoldOut) {
+		t.Fatal("복구 후 초과 발급됨")
+	}
+}
+
+// 세그먼트가 스냅샷으로 압축된 뒤에도 같은 상태로 복구되어야 함
+func TestFileStoreCompaction(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	store := openTestFileStore(t, dir, 5)
+	store.CampaignRepository().Save(ctx, &coupon.Campaign{
+		CampaignId:    "f2",
+		TotalQuantity: 50,
+		Status:        coupon.CampaignStatus_ACTIVE,
+		StartTime:     time.Now().Unix(),
+	})
+	for i := 0; i < 30; i++ {
+		store.CouponRepository().IssueCoupon(ctx, "f2", fmt.Sprintf("user-%d", i), fmt.Sprintf("CODE%02d", i))
+	}
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	snapshots, _ := filepath.Glob(filepath.Join(dir, "snapshot-*.log"))
+	if len(snapshots) != 1 {
+		t.Fatalf("스냅샷 1개 예상, 실제 %d개", len(snapshots))
+	}
+
+	reopened := openTestFileStore(t, dir, 5)
+	defer reopened.Close()
+
+	campaign, _ := reopened.CampaignRepository().GetByID(ctx, "f2")
+	coupons, _ := reopened.CouponRepository().GetByCampaignID(ctx, "f2")
+	if campaign == nil || campaign.IssuedQuantity != 30 || len(coupons) != 30 {
+		t.Fatalf("압축 후 복구 실패: %v, 쿠폰 %d개", campaign, len(coupons))
+	}
+}
+
+// 기록 도중 죽어서 잘린 마지막 줄은 버리고 그 이전 기록까지 복구
+func TestFileStoreTornTail(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	store := openTestFileStore(t, dir, 0)
+	store.CampaignRepository().Save(ctx, &coupon.Campaign{
+		CampaignId:    "f3",
+		TotalQuantity: 10,
+		Status:        coupon.CampaignStatus_ACTIVE,
+		StartTime:     time.Now().Unix(),
+	})
+	store.CouponRepository().IssueCoupon(ctx, "f3", "user-1", "TORN1")
+	store.Close()
+
+	segments, _ := filepath.Glob(filepath.Join(dir, "wal-*.log"))
+	last := segments[len(segments)-1]
+	f, err := os.OpenFile(last, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`0badc0de {"k":"issue","campa`)
+	f.Close()
+
+	reopened := openTestFileStore(t, dir, 0)
+	defer reopened.Close()
+
+	campaign, _ := reopened.CampaignRepository().GetByID(ctx, "f3")
+	if campaign == nil || campaign.IssuedQuantity != 1 {
+		t.Fatalf("잘린 기록 이전 상태 복구 실패: %v", campaign)
+	}
+	if _, err := reopened.CouponRepository().IssueCoupon(ctx, "f3", "user-2", "TORN2"); err != nil {
+		t.Fatalf("복구 후 발급 실패: %v", err)
+	}
+}
+
+// 코드 풀은 재시작과 스냅샷 압축 후에도 발급되지 않은 코드와 다음 발급 위치가 그대로 복구되어야 함
+func TestFileStoreCodePool(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	store := openTestFileStore(t, dir, 3)
+	store.CampaignRepository().Save(ctx, &coupon.Campaign{
+		CampaignId: "f4",
+		Status:     coupon.CampaignStatus_ACTIVE,
+		StartTime:  time.Now().Unix(),
+		CodeFormat: coupon.CodeFormat_CODE_FORMAT_POOL,
+	})
+
+	var codes []string
+	for i := 0; i < 10; i++ {
+		codes = append(codes, fmt.Sprintf("CARD%02d", i))
+	}
+	if campaign, err := store.CouponRepository().ImportCodePool(ctx, "f4", codes); campaign == nil {
+		t.Fatalf("코드 풀 등록 실패: %v", err)
+	}
+	for i := 0; i < 4; i++ {
+		if _, err := store.CouponRepository().IssuePooledCoupon(ctx, "f4", fmt.Sprintf("user-%d", i)); err != nil {
+			t.Fatalf("발급 실패: %v", err)
+		}
+	}
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened := openTestFileStore(t, dir, 3)
+	defer reopened.Close()
+
+	issued, err := reopened.CouponRepository().IssuePooledCoupon(ctx, "f4", "user-4")
+	if issued == nil || issued.CouponCode != "CARD04" {
+		t.Fatalf("복구 후 다음 코드가 아님: %v, %v", issued, err)
+	}
+
+	// 발급되지 않은 코드도 여전히 예약되어 있어야 함
+	if err := reopened.CouponRepository().Save(ctx, &coupon.Coupon{CouponCode: "CARD09", CampaignId: "other"}); err != ErrDuplicateCouponCode {
+		t.Errorf("복구 후 코드 풀의 코드가 예약되지 않음: %v", err)
+	}
+}
+
+// 정규형이 생기기 전의 코드('-' 포함)는 같은 쿠폰의 기록이면 정규형으로 합치고,
+// 다른 캠페인의 쿠폰과 겹치면 덮어쓰지 않고 복구를 중단
+func TestFileStoreLegacyCodeConflict(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	store := openTestFileStore(t, dir, 0)
+	for _, id := range []string{"x", "y"} {
+		store.CampaignRepository().Save(ctx, &coupon.Campaign{
+			CampaignId:    id,
+			TotalQuantity: 3,
+			Status:        coupon.CampaignStatus_ACTIVE,
+			StartTime:     time.Now().Unix(),
+		})
+	}
+	if _, err := store.CouponRepository().IssueCoupon(ctx, "x", "user-1", "AB12"); err != nil {
+		t.Fatalf("발급 실패: %v", err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	appendLegacyRecord := func(cp *coupon.Coupon) {
+		t.Helper()
+		_, segments, err := listDataFiles(dir)
+		if err != nil || len(segments) == 0 {
+			t.Fatalf("세그먼트 조회 실패: %v", err)
+		}
+		line, err := encodeRecord(journalRecord{kind: recordCouponPut, coupons: []*coupon.Coupon{cp}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		file, err := os.OpenFile(filepath.Join(dir, segmentFileName(segments[len(segments)-1])), os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer file.Close()
+		if _, err := file.Write(line); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	appendLegacyRecord(&coupon.Coupon{CouponCode: "AB-12", CampaignId: "x", IssuedTo: "user-1", Status: coupon.CouponStatus_REDEEMED})
+	reopened := openTestFileStore(t, dir, 0)
+	redeemed, _ := reopened.CouponRepository().GetByCode(ctx, "AB12")
+	if redeemed == nil || redeemed.Status != coupon.CouponStatus_REDEEMED {
+		t.Fatalf("같은 쿠폰의 예전 코드 기록이 합쳐지지 않음: %v", redeemed)
+	}
+	if err := reopened.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	appendLegacyRecord(&coupon.Coupon{CouponCode: "AB-12", CampaignId: "y", IssuedTo: "user-2", Status: coupon.CouponStatus_ISSUED})
+	store, err := OpenFileStore(FileStoreOptions{Dir: dir, SyncPolicy: SyncNone})
+	if err == nil {
+		store.Close()
+		t.Fatal("다른 캠페인의 쿠폰과 겹치는 코드가 복구됨")
+	}
+	if !strings.Contains(err.Error(), "코드 충돌") {
+		t.Errorf("복구 실패 사유가 다름: %v", err)
+	}
+}
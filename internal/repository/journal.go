@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"fmt"
+
+	"coupon-issuance-system/gen/coupon"
+	"coupon-issuance-system/internal/model"
+)
+
+// recordKind 저널 기록 종류
+type recordKind string
+
+const (
+	recordCampaignPut    recordKind = "campaign"        // 캠페인 전체 상태 저장 (생성/수정/상태 전이)
+	recordCampaignDelete recordKind = "campaign_delete" // 캠페인 삭제
+	recordCouponPut      recordKind = "coupons"         // 쿠폰 전체 상태 저장 (사용/회수 등)
+	recordCouponIssued   recordKind = "issue"           // 쿠폰 발급 (발급 후 캠페인 상태 + 새 쿠폰)
+	recordCodePool       recordKind = "code_pool"       // 코드 풀 등록 (등록 후 캠페인 상태 + 추가한 코드)
+)
+
+// journalRecord 저장소 변경 사항 하나
+// 모든 기록은 변경 이후의 전체 상태를 담으므로 같은 순서로 다시 적용하면 항상 같은 결과가 나옴 (멱등)
+type journalRecord struct {
+	kind       recordKind
+	campaignID string           // recordCampaignDelete, recordCodePool
+	campaign   *coupon.Campaign // recordCampaignPut, recordCouponIssued, recordCodePool
+	coupons    []*coupon.Coupon // recordCouponPut, recordCouponIssued
+	codes      []string         // recordCodePool (스냅샷에서는 campaign 없이 아직 발급되지 않은 코드만)
+}
+
+// journal 메모리 저장소의 변경 사항을 영속화하는 훅
+// append 는 저장소 락을 잡은 상태에서 호출되며, 오류를 반환하면 저장소는 해당 변경을 되돌림
+// append 가 반환된 이후에는 record 의 메시지가 계속 변경될 수 있으므로 호출 안에서 직렬화를 끝내야 함
+type journal interface {
+	append(rec journalRecord) error
+}
+
+// applyRecord 복구 시 기록을 비즈니스 규칙 검증 없이 그대로 반영
+// 정규화한 코드가 다른 쿠폰의 코드와 겹치면 데이터를 덮어쓰지 않고 오류를 반환 (복구 중단)
+func applyRecord(campaignRepo *MemoryCampaignRepository, couponRepo *MemoryCouponRepository, rec journalRecord) error {
+	switch rec.kind {
+	case recordCampaignPut, recordCouponIssued, recordCodePool:
+		if rec.campaign != nil {
+			model.NewCampaign(rec.campaign).FillMillis() // 밀리초 필드가 생기기 전의 기록
+			campaignRepo.campaigns[rec.campaign.CampaignId] = rec.campaign
+		}
+
+	case recordCampaignDelete:
+		delete(campaignRepo.campaigns, rec.campaignID)
+	}
+
+	for _, cp := range rec.coupons {
+		if err := couponRepo.putCoupon(cp); err != nil {
+			return err
+		}
+	}
+
+	if len(rec.codes) > 0 {
+		return couponRepo.putPool(rec.campaignID, rec.codes)
+	}
+	return nil
+}
+
+// codeConflictError 정규화한 코드가 복구된 다른 쿠폰/코드 풀의 코드와 겹침
+// 코드 정규형이 생기기 전에는 '-' 가 있는 TEMPLATE 코드와 없는 코드가 서로 다른 코드였으므로 생길 수 있음
+func codeConflictError(original, normalized, campaignID string, existing couponRef) error {
+	owner := "다른 캠페인의 코드 풀"
+	if existing.index >= 0 && existing.index < len(existing.bucket.coupons) {
+		owner = "캠페인 " + existing.bucket.coupons[existing.index].CampaignId + " 의 쿠폰"
+	}
+	return fmt.Errorf("복구 중 쿠폰 코드 충돌: 캠페인 %s 의 코드 %s 의 정규형 %s 이(가) %s 와(과) 겹칩니다. 겹치는 코드를 정리한 뒤 다시 시작하세요",
+		campaignID, original, normalized, owner)
+}
+
+// putCoupon 쿠폰을 코드 기준으로 덮어쓰거나 새로 추가 (복구 전용, 다른 고루틴과 공유되기 전에만 호출)
+// 코드 풀에서 발급된 코드는 풀의 다음 코드이므로 풀 위치를 함께 옮김
+// 코드 정규형이 생기기 전에 기록된 코드('-' 포함 등)는 정규형으로 바꿔 넣고, 밀리초 필드가 없으면 초 단위 필드로 채움
+// 같은 캠페인, 같은 사용자의 쿠폰일 때만 같은 쿠폰의 새 기록(사용, 회수 등)으로 보고 교체
+func (r *MemoryCouponRepository) putCoupon(cp *coupon.Coupon) error {
+	original := cp.CouponCode
+	cp.CouponCode = model.NormalizeCouponCode(cp.CouponCode)
+	model.NewCoupon(cp).FillMillis()
+
+	bucket := r.bucket(cp.CampaignId, true)
+	ref, exists := r.lookupCode(cp.CouponCode)
+	if exists && ref.index != pooledCodeIndex {
+		existing := ref.bucket.coupons[ref.index]
+		if existing.CampaignId != cp.CampaignId || existing.IssuedTo != cp.IssuedTo {
+			return codeConflictError(original, cp.CouponCode, cp.CampaignId, ref)
+		}
+		ref.bucket.coupons[ref.index] = cp // 발급 순서는 유지한 채 내용만 교체
+		return nil
+	}
+	if exists && ref.bucket != bucket {
+		return codeConflictError(original, cp.CouponCode, cp.CampaignId, ref)
+	}
+
+	r.assignCode(cp.CouponCode, couponRef{bucket: bucket, index: len(bucket.coupons)})
+	bucket.appendLocked(cp)
+
+	if exists && bucket.poolNext < len(bucket.pool) && bucket.pool[bucket.poolNext] == cp.CouponCode {
+		bucket.poolNext++
+	}
+	return nil
+}
+
+// putPool 코드 풀 뒤에 코드를 추가 (복구 전용). 정규화한 코드가 이미 다른 쿠폰/코드 풀에 있으면 오류
+func (r *MemoryCouponRepository) putPool(campaignID string, codes []string) error {
+	bucket := r.bucket(campaignID, true)
+	for i, code := range codes {
+		normalized := model.NormalizeCouponCode(code)
+		if ref, exists := r.lookupCode(normalized); exists {
+			return codeConflictError(code, normalized, campaignID, ref)
+		}
+		codes[i] = normalized
+		r.assignCode(normalized, couponRef{bucket: bucket, index: pooledCodeIndex})
+	}
+	bucket.pool = append(bucket.pool, codes...)
+	return nil
+}
+
+// couponCount 저장된 전체 쿠폰 수 (복구 로그용)
+func (r *MemoryCouponRepository) couponCount() int {
+	r.bucketsMutex.RLock()
+	buckets := make([]*couponBucket, 0, len(r.buckets))
+	for _, bucket := range r.buckets {
+		buckets = append(buckets, bucket)
+	}
+	r.bucketsMutex.RUnlock()
+
+	count := 0
+	for _, bucket := range buckets {
+		bucket.mutex.RLock()
+		count += len(bucket.coupons)
+		bucket.mutex.RUnlock()
+	}
+	return count
+}
@@ -0,0 +1,27 @@
+package repository_test
+
+import (
+	"testing"
+
+	"coupon-issuance-system/internal/clock"
+	"coupon-issuance-system/internal/repository"
+	"coupon-issuance-system/internal/repository/repositorytest"
+)
+
+func TestMemoryRepositoryConformance(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) (repository.CampaignRepository, repository.CouponRepository) {
+		campaignRepo := repository.NewMemoryCampaignRepository(clock.System())
+		return campaignRepo, repository.NewMemoryCouponRepository(campaignRepo)
+	})
+}
+
+func TestFileStoreConformance(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) (repository.CampaignRepository, repository.CouponRepository) {
+		store, err := repository.OpenFileStore(repository.FileStoreOptions{Dir: t.TempDir(), SyncPolicy: repository.SyncNone})
+		if err != nil {
+			t.Fatalf("파일 저장소 열기 실패: %v", err)
+		}
+		t.Cleanup(func() { store.Close() })
+		return store.CampaignRepository(), store.CouponRepository()
+	})
+}
@@ -3,98 +3,425 @@ package repository
 import (
 	"context"
 	"coupon-issuance-system/internal/model"
+	"errors"
 	"fmt"
-	//"log"
+	"hash/fnv"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
-	"time"
 
 	"coupon-issuance-system/gen/coupon"
+	"coupon-issuance-system/internal/clock"
+	"google.golang.org/protobuf/proto"
 )
 
+/*
+# 메모리 저장소 동시성 설계
+
+## 데이터 소유
+- 캠페인: 맵에 저장된 *coupon.Campaign 은 저장 이후 절대 변경하지 않음 (copy-on-write)
+  변경은 항상 복사본에 적용한 뒤 맵의 포인터를 교체하고, 조회는 복사본을 반환
+- 쿠폰: 캠페인별 couponBucket 이 발급 순서 목록과 사용자별 발급 수를 소유
+  버킷 안의 쿠폰도 변경 시 복사본으로 교체하고, 조회는 복사본을 반환
+- 코드 인덱스: 코드 해시로 나눈 codeShard 들이 code → (버킷, 위치) 를 소유 (전체 캠페인 공통 유일성)
+  코드 풀(POOL 형식 캠페인)에 등록된 코드도 발급 전부터 인덱스에 올라가므로 다른 캠페인이 같은 코드를 쓸 수 없음
+
+## 락 계층 (위에서 아래 방향으로만 중첩해서 잡을 수 있음)
+ 1. 캠페인 락      MemoryCampaignRepository.campaignMutexes[id]  캠페인 쓰기(발급, 상태 전이, 저장/삭제) 직렬화
+ 2. 버킷 락        couponBucket.mutex                            캠페인의 쿠폰 목록/사용자별 발급 수
+ 3. 코드 샤드 락   codeShard.mutex                               코드 인덱스
+ (말단) 맵 보호 락 MemoryCampaignRepository.mutex, campaignMutexLock, MemoryCouponRepository.bucketsMutex
+   → 맵 조회/교체 동안만 잡고, 잡은 상태에서 다른 락을 잡지 않음
+ 저널(FileStore) 기록은 필요한 락을 모두 잡은 상태에서 마지막에 호출되며, 저널 내부 락도 말단
+
+## 예
+- 발급: 캠페인 락 → 버킷 락 → 코드 샤드 락(코드 예약) → 저널 → 버킷에 추가 → 캠페인 교체
+- 사용: 코드 샤드 락(위치 조회 후 해제) → 버킷 락 → 저널 → 쿠폰 교체
+- 코드 풀 등록: 캠페인 락 → 버킷 락 → 코드 샤드 락(코드마다 예약) → 저널 → 풀에 추가 → 캠페인 교체
+- 서로 다른 캠페인의 발급은 코드 샤드 락(짧은 맵 연산)에서만 만나므로 병렬로 진행됨
+*/
+
 type MemoryCampaignRepository struct {
-	campaigns map[string]*coupon.Campaign
-	mutex     sync.RWMutex
+	campaigns         map[string]*coupon.Campaign // 저장된 캠페인은 불변 (교체만 가능)
+	mutex             sync.RWMutex                // 캠페인 맵 뮤텍스
+	campaignMutexes   map[string]*sync.Mutex      // 캠페인별 뮤텍스 맵 (발급/상태 전이 직렬화)
+	campaignMutexLock sync.Mutex                  // 캠페인 뮤텍스 맵 보호
+	journal           journal                     // 변경 사항 영속화 훅 (메모리 전용이면 nil)
+	clock             clock.Clock                 // 상태 갱신과 발급/사용 시각 기준 (쿠폰 저장소도 같은 시계 사용)
 }
 
-func NewMemoryCampaignRepository() *MemoryCampaignRepository {
+func NewMemoryCampaignRepository(clk clock.Clock) *MemoryCampaignRepository {
 	return &MemoryCampaignRepository{
-		campaigns: make(map[string]*coupon.Campaign),
+		clock:           clk,
+		campaigns:       make(map[string]*coupon.Campaign),
+		campaignMutexes: make(map[string]*sync.Mutex),
 	}
 }
 
 func (r *MemoryCampaignRepository) Save(ctx context.Context, campaign *coupon.Campaign) error {
-	r.mutex.Lock()
-	defer r.mutex.Unlock()
+	stored := proto.Clone(campaign).(*coupon.Campaign) // 호출자가 이후에 값을 바꿔도 저장된 캠페인은 그대로
 
-	r.campaigns[campaign.CampaignId] = campaign
+	unlock := r.lockCampaign(campaign.CampaignId)
+	defer unlock()
+
+	if err := r.record(journalRecord{kind: recordCampaignPut, campaign: stored}); err != nil {
+		return err
+	}
+
+	r.store(stored)
 	return nil
 }
 
 func (r *MemoryCampaignRepository) GetByID(ctx context.Context, id string) (*coupon.Campaign, error) {
-	r.mutex.RLock()
-	defer r.mutex.RUnlock()
-
-	campaign, exists := r.campaigns[id]
+	stored, exists := r.load(id)
 	if !exists {
-		return nil, fmt.Errorf("해당 캠페인이 존재하지 않습니다. id: %s", id)
+		return nil, fmt.Errorf("%w. id: %s", model.ErrCampaignNotFound, id)
 	}
 
-	model.NewCampaign(campaign).UpdateStatusIfNeeded() // 상태 업데이트 (lazy evaluation)
+	campaign := proto.Clone(stored).(*coupon.Campaign)
+	model.NewCampaign(campaign).UpdateStatusIfNeeded(r.clock.Now()) // 상태 업데이트 (lazy evaluation, 저장은 다음 변경 시)
 
 	return campaign, nil
 }
 
+// CampaignFilter 캠페인 목록 조회 조건. 0 값 필드는 조건에서 제외
+type CampaignFilter struct {
+	Statuses      []coupon.CampaignStatus
+	NameContains  string
+	StartTimeFrom int64
+	StartTimeTo   int64
+}
+
+// CampaignCursor (created_at, campaign_id) 정렬 기준에서 마지막으로 반환한 캠페인 위치
+// 새 캠페인은 항상 뒤에 정렬되므로 페이지를 넘기는 도중 저장돼도 결과가 밀리지 않음
+type CampaignCursor struct {
+	CreatedAt  int64
+	CampaignID string
+}
+
+// CouponCursor 캠페인 쿠폰 목록에서 마지막으로 반환한 쿠폰의 위치 (0 이면 처음부터)
+// 위치 값은 저장소마다 다르지만(메모리: 캠페인 안의 발급 순번, PostgreSQL: seq) 발급이 커밋된 순서대로 커지므로
+// 페이지를 넘기는 도중 발급된 쿠폰도 건너뛰지 않음
+type CouponCursor int64
+
+// List 조건에 맞는 캠페인을 created_at 오름차순으로 최대 limit 개 반환
+// after 가 nil 이 아니면 해당 위치 이후부터 조회. hasMore 는 다음 페이지 존재 여부
+func (r *MemoryCampaignRepository) List(
+	ctx context.Context,
+	filter CampaignFilter,
+	after *CampaignCursor,
+	limit int,
+) (campaigns []*coupon.Campaign, hasMore bool, err error) {
+
+	r.mutex.RLock()
+	stored := make([]*coupon.Campaign, 0, len(r.campaigns))
+	for _, campaign := range r.campaigns {
+		stored = append(stored, campaign)
+	}
+	r.mutex.RUnlock()
+
+	now := r.clock.Now()
+	var matched []*coupon.Campaign
+	for _, campaign := range stored {
+		if after != nil && !isAfterCursor(campaign, after) {
+			continue
+		}
+
+		campaign = proto.Clone(campaign).(*coupon.Campaign)
+		model.NewCampaign(campaign).UpdateStatusIfNeeded(now) // 상태 필터 전에 최신 상태 반영
+
+		if filter.matches(campaign) {
+			matched = append(matched, campaign)
+		}
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		if matched[i].CreatedAt != matched[j].CreatedAt {
+			return matched[i].CreatedAt < matched[j].CreatedAt
+		}
+		return matched[i].CampaignId < matched[j].CampaignId
+	})
+
+	if len(matched) > limit {
+		return matched[:limit], true, nil
+	}
+	return matched, false, nil
+}
+
+func (f CampaignFilter) matches(campaign *coupon.Campaign) bool {
+	if len(f.Statuses) > 0 && !slices.Contains(f.Statuses, campaign.Status) {
+		return false
+	}
+	if f.NameContains != "" && !strings.Contains(campaign.Name, f.NameContains) {
+		return false
+	}
+	if f.StartTimeFrom != 0 && campaign.StartTime < f.StartTimeFrom {
+		return false
+	}
+	if f.StartTimeTo != 0 && campaign.StartTime > f.StartTimeTo {
+		return false
+	}
+	return true
+}
+
+func isAfterCursor(campaign *coupon.Campaign, cursor *CampaignCursor) bool {
+	if campaign.CreatedAt != cursor.CreatedAt {
+		return campaign.CreatedAt > cursor.CreatedAt
+	}
+	return campaign.CampaignId > cursor.CampaignID
+}
+
 func (r *MemoryCampaignRepository) Update(ctx context.Context, campaign *coupon.Campaign) error {
-	r.mutex.Lock()
-	defer r.mutex.Unlock()
+	stored := proto.Clone(campaign).(*coupon.Campaign)
+
+	unlock := r.lockCampaign(campaign.CampaignId)
+	defer unlock()
 
-	if _, exists := r.campaigns[campaign.CampaignId]; !exists {
-		return fmt.Errorf("해당 캠페인이 존재하지 않습니다. id: %s", campaign.CampaignId)
+	if _, exists := r.load(campaign.CampaignId); !exists {
+		return fmt.Errorf("%w. id: %s", model.ErrCampaignNotFound, campaign.CampaignId)
 	}
 
-	r.campaigns[campaign.CampaignId] = campaign
+	if err := r.record(journalRecord{kind: recordCampaignPut, campaign: stored}); err != nil {
+		return err
+	}
+
+	r.store(stored)
 	return nil
 }
 
 func (r *MemoryCampaignRepository) Delete(ctx context.Context, id string) error {
-	r.mutex.Lock()
-	defer r.mutex.Unlock()
+	unlock := r.lockCampaign(id)
+	defer unlock()
 
-	if _, exists := r.campaigns[id]; !exists {
-		return fmt.Errorf("해당 캠페인이 존재하지 않습니다. id: %s", id)
+	if _, exists := r.load(id); !exists {
+		return fmt.Errorf("%w. id: %s", model.ErrCampaignNotFound, id)
 	}
 
+	if err := r.record(journalRecord{kind: recordCampaignDelete, campaignID: id}); err != nil {
+		return err
+	}
+
+	r.mutex.Lock()
 	delete(r.campaigns, id)
+	r.mutex.Unlock()
 	return nil
 }
 
+// Modify 캠페인별 뮤텍스를 잡은 상태에서 복사본에 modify 를 적용하고, 성공하면 저장된 캠페인을 교체
+// 쿠폰 발급과 같은 뮤텍스를 사용하므로 반환 시점에는 진행 중이던 발급이 모두 끝나 있음
+func (r *MemoryCampaignRepository) Modify(
+	ctx context.Context,
+	id string,
+	modify CampaignModifier,
+) (*coupon.Campaign, error) {
+
+	unlock := r.lockCampaign(id)
+	defer unlock()
+
+	stored, exists := r.load(id)
+	if !exists {
+		return nil, model.ErrCampaignNotFound
+	}
+
+	working := proto.Clone(stored).(*coupon.Campaign)
+
+	if err := modify(model.NewCampaign(working)); err != nil {
+		return nil, err
+	}
+
+	if err := r.record(journalRecord{kind: recordCampaignPut, campaign: working}); err != nil {
+		return nil, err
+	}
+
+	r.store(working)
+	return proto.Clone(working).(*coupon.Campaign), nil
+}
+
+// record 저널이 설정되어 있으면 변경 사항을 기록
+func (r *MemoryCampaignRepository) record(rec journalRecord) error {
+	if r.journal == nil {
+		return nil
+	}
+	return r.journal.append(rec)
+}
+
+// load 저장된 (불변) 캠페인 조회. 반환값을 변경하면 안 됨
+func (r *MemoryCampaignRepository) load(id string) (*coupon.Campaign, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	campaign, exists := r.campaigns[id]
+	return campaign, exists
+}
+
+// store 저장된 캠페인 교체. 캠페인 락을 잡은 상태에서만 호출
+func (r *MemoryCampaignRepository) store(campaign *coupon.Campaign) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.campaigns[campaign.CampaignId] = campaign
+}
+
+// lockCampaign 캠페인 락(락 계층 1)을 잡고 해제 함수를 반환
+func (r *MemoryCampaignRepository) lockCampaign(id string) (unlock func()) {
+	campaignMutex := r.getCampaignMutex(id)
+	campaignMutex.Lock()
+	return campaignMutex.Unlock
+}
+
+func (r *MemoryCampaignRepository) getCampaignMutex(campaignID string) *sync.Mutex {
+	r.campaignMutexLock.Lock()
+
+	campaignMutex, exists := r.campaignMutexes[campaignID]
+	if !exists {
+		campaignMutex = &sync.Mutex{} // 없으면 새로 생성
+		r.campaignMutexes[campaignID] = campaignMutex
+	}
+
+	r.campaignMutexLock.Unlock()
+	return campaignMutex
+}
+
 ////////////////////////////////////////////////////////////////////////////////////////////
 
+const codeShardCount = 64
+
+// couponBucket 한 캠페인의 쿠폰 (락 계층 2)
+type couponBucket struct {
+	mutex      sync.RWMutex
+	coupons    []*coupon.Coupon // 발급 순서. 원소는 불변 (교체만 가능)
+	userCounts map[string]int32 // userID -> 발급 수량 (사용자당 한도 확인용)
+	pool       []string         // 코드 풀. pool[poolNext:] 가 아직 발급되지 않은 코드 (등록 순서)
+	poolNext   int
+}
+
+// couponRef 코드 인덱스가 가리키는 쿠폰 위치
+// index 가 아직 bucket.coupons 범위 밖이면 발급이 진행 중인(예약된) 코드, pooledCodeIndex 이면 코드 풀에서 발급을 기다리는 코드
+type couponRef struct {
+	bucket *couponBucket
+	index  int
+}
+
+const pooledCodeIndex = -1
+
+// isIssued 발급이 끝난 쿠폰을 가리키는지 여부 (버킷 락을 잡은 상태에서 호출)
+func (ref couponRef) isIssued() bool {
+	return ref.index >= 0 && ref.index < len(ref.bucket.coupons)
+}
+
+// codeShard 코드 인덱스 조각 (락 계층 3)
+type codeShard struct {
+	mutex sync.Mutex
+	refs  map[string]couponRef
+}
+
 type MemoryCouponRepository struct {
-	coupons           map[string][]*coupon.Coupon // campaignID -> coupons
-	couponsByCode     map[string]*coupon.Coupon   // couponCode -> coupon , 중복이지만 인덱싱 기능
-	campaigns         map[string]*coupon.Campaign // campaignRepo.campaigns
-	mutex             sync.RWMutex                // 전체 데이터 뮤텍스
-	campaignMutexes   map[string]*sync.Mutex      // 캠페인별 뮤텍스 맵
-	campaignMutexLock sync.Mutex                  // 캠페인 뮤텍스 맵 보호
+	buckets      map[string]*couponBucket // campaignID -> 쿠폰 버킷 (캠페인이 삭제되어도 코드 유일성을 위해 유지)
+	bucketsMutex sync.RWMutex             // 버킷 맵 보호 (말단 락)
+	codeShards   [codeShardCount]codeShard
+	campaignRepo *MemoryCampaignRepository // 캠페인 락과 캠페인 상태 조회
 }
 
 func NewMemoryCouponRepository(campaignRepo *MemoryCampaignRepository) *MemoryCouponRepository {
-	return &MemoryCouponRepository{
-		coupons:         make(map[string][]*coupon.Coupon),
-		couponsByCode:   make(map[string]*coupon.Coupon),
-		campaigns:       campaignRepo.campaigns,
-		campaignMutexes: make(map[string]*sync.Mutex),
+	r := &MemoryCouponRepository{
+		buckets:      make(map[string]*couponBucket),
+		campaignRepo: campaignRepo,
 	}
+	for i := range r.codeShards {
+		r.codeShards[i].refs = make(map[string]couponRef)
+	}
+	return r
 }
 
-func (r *MemoryCouponRepository) Save(ctx context.Context, coupon *coupon.Coupon) error {
-	r.mutex.Lock()
-	defer r.mutex.Unlock()
+// bucket 캠페인의 쿠폰 버킷. create 가 false 이면 없을 때 nil
+func (r *MemoryCouponRepository) bucket(campaignID string, create bool) *couponBucket {
+	r.bucketsMutex.RLock()
+	bucket, exists := r.buckets[campaignID]
+	r.bucketsMutex.RUnlock()
+
+	if exists || !create {
+		return bucket
+	}
+
+	r.bucketsMutex.Lock()
+	defer r.bucketsMutex.Unlock()
+
+	if bucket, exists = r.buckets[campaignID]; !exists {
+		bucket = &couponBucket{userCounts: make(map[string]int32)}
+		r.buckets[campaignID] = bucket
+	}
+	return bucket
+}
+
+func (r *MemoryCouponRepository) codeShard(code string) *codeShard {
+	h := fnv.New32a()
+	h.Write([]byte(code))
+	return &r.codeShards[h.Sum32()%codeShardCount]
+}
+
+// reserveCode 코드가 비어 있으면 ref 로 예약하고 true 반환 (버킷 락을 잡은 상태에서 호출)
+func (r *MemoryCouponRepository) reserveCode(code string, ref couponRef) bool {
+	shard := r.codeShard(code)
+	shard.mutex.Lock()
+	defer shard.mutex.Unlock()
+
+	if _, exists := shard.refs[code]; exists {
+		return false
+	}
+	shard.refs[code] = ref
+	return true
+}
+
+// assignCode 코드가 가리키는 위치를 바꿈 (코드 풀의 코드를 발급하거나 되돌릴 때. 버킷 락을 잡은 상태에서 호출)
+func (r *MemoryCouponRepository) assignCode(code string, ref couponRef) {
+	shard := r.codeShard(code)
+	shard.mutex.Lock()
+	defer shard.mutex.Unlock()
+
+	shard.refs[code] = ref
+}
 
-	r.coupons[coupon.CampaignId] = append(r.coupons[coupon.CampaignId], coupon)
-	r.couponsByCode[coupon.CouponCode] = coupon
+func (r *MemoryCouponRepository) releaseCode(code string) {
+	shard := r.codeShard(code)
+	shard.mutex.Lock()
+	defer shard.mutex.Unlock()
+
+	delete(shard.refs, code)
+}
+
+func (r *MemoryCouponRepository) lookupCode(code string) (couponRef, bool) {
+	shard := r.codeShard(code)
+	shard.mutex.Lock()
+	defer shard.mutex.Unlock()
+
+	ref, exists := shard.refs[code]
+	return ref, exists
+}
+
+// appendLocked 쿠폰을 버킷에 추가 (버킷 쓰기 락을 잡은 상태에서 호출)
+func (b *couponBucket) appendLocked(cp *coupon.Coupon) {
+	b.coupons = append(b.coupons, cp)
+	b.userCounts[cp.IssuedTo]++
+}
 
+func (r *MemoryCouponRepository) Save(ctx context.Context, cp *coupon.Coupon) error {
+	stored := proto.Clone(cp).(*coupon.Coupon)
+
+	bucket := r.bucket(cp.CampaignId, true)
+	bucket.mutex.Lock()
+	defer bucket.mutex.Unlock()
+
+	if !r.reserveCode(cp.CouponCode, couponRef{bucket: bucket, index: len(bucket.coupons)}) {
+		return ErrDuplicateCouponCode
+	}
+
+	if err := r.campaignRepo.record(journalRecord{kind: recordCouponPut, coupons: []*coupon.Coupon{stored}}); err != nil {
+		r.releaseCode(cp.CouponCode)
+		return err
+	}
+
+	bucket.appendLocked(stored)
 	return nil
 }
 
@@ -103,27 +430,56 @@ func (r *MemoryCouponRepository) GetByCampaignID(
 	campaignID string,
 ) ([]*coupon.Coupon, error) {
 
-	r.mutex.RLock()
-	defer r.mutex.RUnlock()
-
-	coupons, exists := r.coupons[campaignID]
-	if !exists {
+	bucket := r.bucket(campaignID, false)
+	if bucket == nil {
 		return []*coupon.Coupon{}, nil
 	}
 
-	return coupons, nil
+	bucket.mutex.RLock()
+	defer bucket.mutex.RUnlock()
+
+	return cloneCoupons(bucket.coupons), nil
 }
 
-func (r *MemoryCouponRepository) GetByCode(ctx context.Context, code string) (*coupon.Coupon, error) {
-	r.mutex.RLock()
-	defer r.mutex.RUnlock()
+// ListByCampaignID 캠페인의 쿠폰을 발급 순서대로 after 다음부터 최대 limit 개 반환
+// 쿠폰 목록은 뒤에 추가만 되므로 지금까지 반환한 쿠폰 수가 커서 역할을 함
+// 전체가 아닌 해당 페이지만 복사하므로 대량 발급 캠페인에서도 읽기 락 점유 시간이 짧음
+func (r *MemoryCouponRepository) ListByCampaignID(
+	ctx context.Context,
+	campaignID string,
+	after CouponCursor,
+	limit int,
+) (coupons []*coupon.Coupon, next CouponCursor, hasMore bool, err error) {
 
-	cp, exists := r.couponsByCode[code]
-	if !exists {
-		return nil, fmt.Errorf("해당 쿠폰이 존재하지 않습니다. code: %s", code)
+	bucket := r.bucket(campaignID, false)
+	if bucket == nil {
+		return []*coupon.Coupon{}, after, false, nil
+	}
+
+	bucket.mutex.RLock()
+	defer bucket.mutex.RUnlock()
+
+	all := bucket.coupons
+	if after < 0 || int(after) >= len(all) {
+		return []*coupon.Coupon{}, after, false, nil
 	}
 
-	return cp, nil
+	end := min(int(after)+limit, len(all))
+	return cloneCoupons(all[after:end]), CouponCursor(end), end < len(all), nil
+}
+
+func (r *MemoryCouponRepository) GetByCode(ctx context.Context, code string) (*coupon.Coupon, error) {
+	ref, exists := r.lookupCode(code)
+	if exists {
+		ref.bucket.mutex.RLock()
+		defer ref.bucket.mutex.RUnlock()
+
+		if ref.isIssued() {
+			return proto.Clone(ref.bucket.coupons[ref.index]).(*coupon.Coupon), nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w. code: %s", model.ErrCouponNotFound, code)
 }
 
 func (r *MemoryCouponRepository) IssueCoupon(
@@ -131,52 +487,276 @@ func (r *MemoryCouponRepository) IssueCoupon(
 	campaignID,
 	userID,
 	couponCode string,
-) (*coupon.Coupon, string, error) {
+) (*coupon.Coupon, error) {
+
+	return r.issue(campaignID, userID, func(working *coupon.Campaign, ref couponRef) (string, error) {
+		if !r.reserveCode(couponCode, ref) {
+			return "", ErrDuplicateCouponCode
+		}
+		return couponCode, nil
+	}, r.releaseReservedCode)
+}
 
-	// 캠페인별 뮤텍스 가져오기
-	campaignMutex := r.getCampaignMutex(campaignID)
-	campaignMutex.Lock()
-	defer campaignMutex.Unlock()
+// IssueSequencedCoupon 캠페인 락 안에서 코드 순번을 증가시키며 codeFor 로 만든 코드를 예약
+func (r *MemoryCouponRepository) IssueSequencedCoupon(
+	ctx context.Context,
+	campaignID,
+	userID string,
+	codeFor SequencedCode,
+) (*coupon.Coupon, error) {
+
+	return r.issue(campaignID, userID, func(working *coupon.Campaign, ref couponRef) (string, error) {
+		for skip := 0; skip <= maxSequenceSkips; skip++ {
+			couponCode, err := codeFor(working.CodeSequence)
+			if err != nil {
+				return "", err
+			}
+
+			working.CodeSequence++ // 건너뛴 순번도 다시 쓰지 않음 (발급이 성공할 때만 저장됨)
+			if r.reserveCode(couponCode, ref) {
+				return couponCode, nil
+			}
+		}
+		return "", ErrDuplicateCouponCode
+	}, r.releaseReservedCode)
+}
+
+// IssuePooledCoupon 버킷 락 안에서 코드 풀의 다음 코드를 꺼내 발급. 발급이 실패하면 코드를 풀의 같은 자리로 되돌림
+func (r *MemoryCouponRepository) IssuePooledCoupon(
+	ctx context.Context,
+	campaignID,
+	userID string,
+) (*coupon.Coupon, error) {
+
+	issued, err := r.issue(campaignID, userID, func(working *coupon.Campaign, ref couponRef) (string, error) {
+		bucket := ref.bucket
+		if bucket.poolNext >= len(bucket.pool) {
+			return "", errCodePoolExhausted
+		}
+
+		couponCode := bucket.pool[bucket.poolNext]
+		bucket.poolNext++
+		r.assignCode(couponCode, ref)
+		return couponCode, nil
+
+	}, func(couponCode string, ref couponRef) {
+		ref.bucket.poolNext--
+		r.assignCode(couponCode, couponRef{bucket: ref.bucket, index: pooledCodeIndex})
+	})
+
+	if errors.Is(err, errCodePoolExhausted) {
+		return nil, model.ErrCampaignSoldOut
+	}
+	return issued, err
+}
+
+func (r *MemoryCouponRepository) releaseReservedCode(couponCode string, _ couponRef) {
+	r.releaseCode(couponCode)
+}
+
+// issue 발급 공통 처리. reserve 는 캠페인 복사본을 받아 코드를 정하고 코드 인덱스에 예약해야 하며,
+// 예약 이후 발급이 실패하면 release 로 예약을 되돌림
+func (r *MemoryCouponRepository) issue(
+	campaignID,
+	userID string,
+	reserve func(working *coupon.Campaign, ref couponRef) (string, error),
+	release func(couponCode string, ref couponRef),
+) (*coupon.Coupon, error) {
+
+	// 락 순서: 캠페인 락 → 버킷 락 → 코드 샤드 락
+	unlock := r.campaignRepo.lockCampaign(campaignID)
+	defer unlock()
 
-	pbCampaign, exists := r.campaigns[campaignID]
+	stored, exists := r.campaignRepo.load(campaignID)
 	if !exists {
-		return nil, "존재하지 않는 캠페인입니다", nil
+		return nil, model.ErrCampaignNotFound
+	}
+
+	bucket := r.bucket(campaignID, true)
+	bucket.mutex.Lock()
+	defer bucket.mutex.Unlock()
+
+	now := r.campaignRepo.clock.Now()
+	working := proto.Clone(stored).(*coupon.Campaign)
+	domainCampaign := model.NewCampaign(working)
+
+	// 쿠폰 발급 가능 여부 확인 (수량 + 사용자당 한도를 같은 임계 구역에서 확인)
+	if err := domainCampaign.CanIssueCouponTo(bucket.userCounts[userID], now); err != nil {
+		return nil, err
 	}
-	domainCampaign := model.NewCampaign(pbCampaign)
 
-	// 쿠폰 발급 가능 여부 확인
-	canIssue, failMsg := domainCampaign.CanIssueCoupon()
-	if !canIssue {
-		return nil, failMsg, nil
+	// 코드 중복이면 수량을 증가시키기 전에 거절
+	ref := couponRef{bucket: bucket, index: len(bucket.coupons)}
+	couponCode, err := reserve(working, ref)
+	if err != nil {
+		return nil, err
 	}
 
-	// 쿠폰 생성 및 저장
-	success, failMsg := domainCampaign.IssueCoupon()
-	if !success {
-		return nil, failMsg, nil
+	if err := domainCampaign.IssueCoupon(now); err != nil {
+		release(couponCode, ref)
+		return nil, err
 	}
 
-	newCoupon := &coupon.Coupon{
+	issued := &coupon.Coupon{
 		CouponCode: couponCode,
 		CampaignId: campaignID,
-		IssuedAt:   time.Now().Unix(),
+		IssuedAt:   now.Unix(),
+		IssuedAtMs: now.UnixMilli(),
 		IssuedTo:   userID,
+		Status:     coupon.CouponStatus_ISSUED,
+	}
+
+	// 응답하기 전에 영속화. 기록에 실패하면 예약한 코드를 풀고 발급 실패로 처리 (복사본은 버려짐)
+	rec := journalRecord{kind: recordCouponIssued, campaign: working, coupons: []*coupon.Coupon{issued}}
+	if err := r.campaignRepo.record(rec); err != nil {
+		release(couponCode, ref)
+		return nil, err
 	}
-	r.coupons[campaignID] = append(r.coupons[campaignID], newCoupon)
-	r.couponsByCode[couponCode] = newCoupon
 
-	return newCoupon, "", nil
+	bucket.appendLocked(issued)
+	r.campaignRepo.store(working)
+
+	return proto.Clone(issued).(*coupon.Coupon), nil
 }
 
-func (r *MemoryCouponRepository) getCampaignMutex(campaignID string) *sync.Mutex {
-	r.campaignMutexLock.Lock()
+// ImportCodePool 캠페인 락과 버킷 락 안에서 모든 코드를 코드 인덱스에 예약한 뒤에만 풀에 추가
+// 하나라도 이미 인덱스에 있으면(발급된 코드, 다른 풀의 코드, 요청 안 중복) 이번에 예약한 코드를 모두 해제
+func (r *MemoryCouponRepository) ImportCodePool(
+	ctx context.Context,
+	campaignID string,
+	codes []string,
+) (*coupon.Campaign, error) {
 
-	campaignMutex, exists := r.campaignMutexes[campaignID]
+	unlock := r.campaignRepo.lockCampaign(campaignID)
+	defer unlock()
+
+	stored, exists := r.campaignRepo.load(campaignID)
 	if !exists {
-		campaignMutex = &sync.Mutex{} // 없으면 새로 생성
-		r.campaignMutexes[campaignID] = campaignMutex
+		return nil, model.ErrCampaignNotFound
 	}
 
-	r.campaignMutexLock.Unlock()
-	return campaignMutex
+	working := proto.Clone(stored).(*coupon.Campaign)
+	if err := model.NewCampaign(working).AddPoolCodes(int32(len(codes)), r.campaignRepo.clock.Now()); err != nil {
+		return nil, err
+	}
+
+	bucket := r.bucket(campaignID, true)
+	bucket.mutex.Lock()
+	defer bucket.mutex.Unlock()
+
+	pooled := couponRef{bucket: bucket, index: pooledCodeIndex}
+	reserved := make([]string, 0, len(codes))
+	var conflicts []string
+
+	for _, code := range codes {
+		if r.reserveCode(code, pooled) {
+			reserved = append(reserved, code)
+		} else if len(conflicts) < maxReportedPoolConflicts {
+			conflicts = append(conflicts, code)
+		}
+	}
+
+	releaseAll := func() {
+		for _, code := range reserved {
+			r.releaseCode(code)
+		}
+	}
+
+	if len(reserved) < len(codes) {
+		releaseAll()
+		return nil, &CodePoolConflictError{Codes: conflicts}
+	}
+
+	if err := r.campaignRepo.record(journalRecord{kind: recordCodePool, campaignID: campaignID, campaign: working, codes: codes}); err != nil {
+		releaseAll()
+		return nil, err
+	}
+
+	bucket.pool = append(bucket.pool, codes...)
+	r.campaignRepo.store(working)
+
+	return proto.Clone(working).(*coupon.Campaign), nil
+}
+
+// RedeemCoupon 쿠폰 사용 처리
+// 버킷 쓰기 락 안에서 상태 확인과 교체를 처리하여 동시 중복 사용을 막음
+func (r *MemoryCouponRepository) RedeemCoupon(
+	ctx context.Context,
+	couponCode,
+	userID,
+	orderID string,
+) (*coupon.Coupon, error) {
+
+	ref, exists := r.lookupCode(couponCode)
+	if !exists {
+		return nil, model.ErrCouponNotFound
+	}
+
+	ref.bucket.mutex.Lock()
+	defer ref.bucket.mutex.Unlock()
+
+	if !ref.isIssued() {
+		return nil, model.ErrCouponNotFound
+	}
+
+	working := proto.Clone(ref.bucket.coupons[ref.index]).(*coupon.Coupon)
+
+	if err := model.NewCoupon(working).Redeem(userID, orderID, r.campaignRepo.clock.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := r.campaignRepo.record(journalRecord{kind: recordCouponPut, coupons: []*coupon.Coupon{working}}); err != nil {
+		return nil, err
+	}
+
+	ref.bucket.coupons[ref.index] = working
+	return proto.Clone(working).(*coupon.Coupon), nil
 }
+
+// RevokeByCampaignID 캠페인의 사용되지 않은 쿠폰을 모두 회수 (이미 사용된 쿠폰은 유지)
+func (r *MemoryCouponRepository) RevokeByCampaignID(ctx context.Context, campaignID string) (int32, error) {
+	bucket := r.bucket(campaignID, false)
+	if bucket == nil {
+		return 0, nil
+	}
+
+	bucket.mutex.Lock()
+	defer bucket.mutex.Unlock()
+
+	var revoked []*coupon.Coupon
+	var positions []int
+	for i, cp := range bucket.coupons {
+		working := proto.Clone(cp).(*coupon.Coupon)
+		if model.NewCoupon(working).Revoke() {
+			revoked = append(revoked, working)
+			positions = append(positions, i)
+		}
+	}
+
+	if len(revoked) == 0 {
+		return 0, nil
+	}
+
+	if err := r.campaignRepo.record(journalRecord{kind: recordCouponPut, coupons: revoked}); err != nil {
+		return 0, err
+	}
+
+	for i, position := range positions {
+		bucket.coupons[position] = revoked[i]
+	}
+	return int32(len(revoked)), nil
+}
+
+func cloneCoupons(coupons []*coupon.Coupon) []*coupon.Coupon {
+	cloned := make([]*coupon.Coupon, len(coupons))
+	for i, cp := range coupons {
+		cloned[i] = proto.Clone(cp).(*coupon.Coupon)
+	}
+	return cloned
+}
+
+// 컴파일 타임 인터페이스 검증
+var (
+	_ CampaignRepository = (*MemoryCampaignRepository)(nil)
+	_ CouponRepository   = (*MemoryCouponRepository)(nil)
+)
@@ -2,17 +2,20 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"testing"
 	"time"
 
 	"coupon-issuance-system/gen/coupon"
+	"coupon-issuance-system/internal/clock"
+	"coupon-issuance-system/internal/model"
 )
 
 // 캠페인 저장
 func TestBasicCampaignOperations(t *testing.T) {
-	repo := NewMemoryCampaignRepository()
+	repo := NewMemoryCampaignRepository(clock.System())
 	ctx := context.Background()
 
 	campaign := &coupon.Campaign{
@@ -32,7 +35,7 @@ func TestBasicCampaignOperations(t *testing.T) {
 }
 
 func TestAtomicCouponIssue(t *testing.T) {
-	campaignRepo := NewMemoryCampaignRepository()
+	campaignRepo := NewMemoryCampaignRepository(clock.System())
 	couponRepo := NewMemoryCouponRepository(campaignRepo)
 	ctx := context.Background()
 
@@ -63,7 +66,7 @@ func TestAtomicCouponIssue(t *testing.T) {
 			userID := fmt.Sprintf("user-%d", index)
 			couponCode := fmt.Sprintf("CODE%d", index)
 
-			issuedCoupon, _, _ := couponRepo.IssueCoupon(ctx, "t2", userID, couponCode)
+			issuedCoupon, _ := couponRepo.IssueCoupon(ctx, "t2", userID, couponCode)
 
 			if issuedCoupon != nil {
 				mu.Lock() // 다른 고루틴 대기
@@ -94,3 +97,280 @@ func TestAtomicCouponIssue(t *testing.T) {
 
 	t.Logf("동시성 테스트 통과: %d개 요청 중 %d개 성공", numRequests, successCount)
 }
+
+// 같은 사용자가 동시에 여러 번 요청해도 사용자당 한도만큼만 발급
+func TestMaxPerUserIssue(t *testing.T) {
+	campaignRepo := NewMemoryCampaignRepository(clock.System())
+	couponRepo := NewMemoryCouponRepository(campaignRepo)
+	ctx := context.Background()
+
+	campaign := &coupon.Campaign{
+		CampaignId:     "t3",
+		TotalQuantity:  10,
+		IssuedQuantity: 0,
+		Status:         coupon.CampaignStatus_ACTIVE,
+		StartTime:      time.Now().Unix(),
+		MaxPerUser:     2,
+	}
+	campaignRepo.Save(ctx, campaign)
+
+	numRequests := 20
+	var wg sync.WaitGroup
+	successCount := 0
+	var mu sync.Mutex
+
+	for i := 0; i < numRequests; i++ {
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+
+			couponCode := fmt.Sprintf("CODE%d", index)
+			issuedCoupon, _ := couponRepo.IssueCoupon(ctx, "t3", "same-user", couponCode)
+
+			if issuedCoupon != nil {
+				mu.Lock()
+				successCount++
+				mu.Unlock()
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	if successCount != 2 {
+		t.Errorf("예상: 2개 성공, 실제: %d개 성공", successCount)
+	}
+
+	// 한도 초과 시 전용 실패 사유 반환
+	_, err := couponRepo.IssueCoupon(ctx, "t3", "same-user", "CODE-EXTRA")
+	if !errors.Is(err, model.ErrUserLimitExceeded) || err.Error() != "사용자당 발급 가능한 쿠폰 수량을 초과했습니다" {
+		t.Errorf("예상하지 못한 실패 사유: %v", err)
+	}
+
+	// 다른 사용자는 여전히 발급 가능
+	other, _ := couponRepo.IssueCoupon(ctx, "t3", "other-user", "CODE-OTHER")
+	if other == nil {
+		t.Error("다른 사용자의 발급이 거부됨")
+	}
+}
+
+// 같은 쿠폰을 동시에 여러 번 사용해도 한 번만 성공
+func TestConcurrentRedeemCoupon(t *testing.T) {
+	campaignRepo := NewMemoryCampaignRepository(clock.System())
+	couponRepo := NewMemoryCouponRepository(campaignRepo)
+	ctx := context.Background()
+
+	campaignRepo.Save(ctx, &coupon.Campaign{
+		CampaignId:    "t4",
+		TotalQuantity: 1,
+		Status:        coupon.CampaignStatus_ACTIVE,
+		StartTime:     time.Now().Unix(),
+	})
+
+	issued, _ := couponRepo.IssueCoupon(ctx, "t4", "user-1", "REDEEM1")
+	if issued == nil || issued.Status != coupon.CouponStatus_ISSUED {
+		t.Fatal("쿠폰 발급 실패")
+	}
+
+	// 다른 사용자는 사용할 수 없음
+	if redeemed, _ := couponRepo.RedeemCoupon(ctx, "REDEEM1", "user-2", "order-x"); redeemed != nil {
+		t.Fatal("다른 사용자가 쿠폰을 사용함")
+	}
+
+	numRequests := 20
+	var wg sync.WaitGroup
+	successCount := 0
+	var mu sync.Mutex
+
+	for i := 0; i < numRequests; i++ {
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+
+			redeemed, _ := couponRepo.RedeemCoupon(ctx, "REDEEM1", "user-1", fmt.Sprintf("order-%d", index))
+			if redeemed != nil {
+				mu.Lock()
+				successCount++
+				mu.Unlock()
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	if successCount != 1 {
+		t.Errorf("예상: 1번 사용 성공, 실제: %d번", successCount)
+	}
+
+	saved, _ := couponRepo.GetByCode(ctx, "REDEEM1")
+	if saved.Status != coupon.CouponStatus_REDEEMED || saved.OrderId == "" || saved.RedeemedAt == 0 {
+		t.Errorf("쿠폰 사용 정보가 기록되지 않음: %v", saved)
+	}
+}
+
+// 종료 시간이 지나면 소진되지 않았어도 발급 불가
+func TestCampaignEndTime(t *testing.T) {
+	campaignRepo := NewMemoryCampaignRepository(clock.System())
+	couponRepo := NewMemoryCouponRepository(campaignRepo)
+	ctx := context.Background()
+
+	now := time.Now().Unix()
+	campaignRepo.Save(ctx, &coupon.Campaign{
+		CampaignId:    "t5",
+		TotalQuantity: 10,
+		Status:        coupon.CampaignStatus_ACTIVE,
+		StartTime:     now - 10,
+		EndTime:       now - 1,
+	})
+
+	issued, err := couponRepo.IssueCoupon(ctx, "t5", "user-1", "ENDED1")
+	if issued != nil || !errors.Is(err, model.ErrCampaignEnded) {
+		t.Errorf("종료된 캠페인에서 발급됨: %v, %v", issued, err)
+	}
+
+	saved, _ := campaignRepo.GetByID(ctx, "t5")
+	if saved.Status != coupon.CampaignStatus_ENDED {
+		t.Errorf("예상 상태: ENDED, 실제: %s", saved.Status)
+	}
+}
+
+// 일시 중지 → 재개 → 취소(회수) 흐름
+func TestPauseResumeCancelCampaign(t *testing.T) {
+	campaignRepo := NewMemoryCampaignRepository(clock.System())
+	couponRepo := NewMemoryCouponRepository(campaignRepo)
+	ctx := context.Background()
+
+	campaignRepo.Save(ctx, &coupon.Campaign{
+		CampaignId:    "t6",
+		TotalQuantity: 100,
+		Status:        coupon.CampaignStatus_ACTIVE,
+		StartTime:     time.Now().Unix(),
+	})
+
+	// 발급 도중 일시 중지: 중지 이후 발급은 모두 거절되어야 함
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+			couponRepo.IssueCoupon(ctx, "t6", fmt.Sprintf("user-%d", index), fmt.Sprintf("PAUSE%d", index))
+		}(i)
+	}
+
+	paused, err := campaignRepo.Modify(ctx, "t6", func(c *model.Campaign) error { return c.Pause(time.Now()) })
+	if paused == nil {
+		t.Fatalf("일시 중지 실패: %v", err)
+	}
+	issuedAtPause := paused.IssuedQuantity
+	wg.Wait()
+
+	_, err = couponRepo.IssueCoupon(ctx, "t6", "late-user", "PAUSE-LATE")
+	if !errors.Is(err, model.ErrCampaignPaused) {
+		t.Errorf("일시 중지 중 발급 실패 사유가 다름: %v", err)
+	}
+
+	current, _ := campaignRepo.GetByID(ctx, "t6")
+	if current.IssuedQuantity != issuedAtPause {
+		t.Errorf("일시 중지 이후 발급됨: 중지 시점 %d, 현재 %d", issuedAtPause, current.IssuedQuantity)
+	}
+
+	resumed, _ := campaignRepo.Modify(ctx, "t6", func(c *model.Campaign) error { return c.Resume(time.Now()) })
+	if resumed == nil || resumed.Status != coupon.CampaignStatus_ACTIVE {
+		t.Fatalf("재개 실패: %v", resumed)
+	}
+
+	issued, _ := couponRepo.IssueCoupon(ctx, "t6", "resume-user", "RESUME1")
+	if issued == nil {
+		t.Fatal("재개 후 발급 실패")
+	}
+	couponRepo.RedeemCoupon(ctx, "RESUME1", "resume-user", "order-1")
+
+	cancelled, _ := campaignRepo.Modify(ctx, "t6", func(c *model.Campaign) error { return c.Cancel() })
+	revokedCount, _ := couponRepo.RevokeByCampaignID(ctx, "t6")
+	if cancelled == nil || cancelled.Status != coupon.CampaignStatus_CANCELLED {
+		t.Fatalf("취소 실패: %v", cancelled)
+	}
+	if revokedCount != cancelled.IssuedQuantity-1 {
+		t.Errorf("사용된 쿠폰을 제외한 쿠폰만 회수되어야 함: 회수 %d, 발급 %d", revokedCount, cancelled.IssuedQuantity)
+	}
+
+	redeemed, _ := couponRepo.GetByCode(ctx, "RESUME1")
+	if redeemed.Status != coupon.CouponStatus_REDEEMED {
+		t.Errorf("사용된 쿠폰 상태가 변경됨: %s", redeemed.Status)
+	}
+
+	if again, _ := campaignRepo.Modify(ctx, "t6", func(c *model.Campaign) error { return c.Resume(time.Now()) }); again != nil {
+		t.Error("취소된 캠페인이 재개됨")
+	}
+}
+
+// 같은 version 으로 동시에 수정하면 하나만 성공, 소진된 캠페인은 수량 추가 시 재개
+func TestUpdateCampaignOptimisticConcurrency(t *testing.T) {
+	campaignRepo := NewMemoryCampaignRepository(clock.System())
+	couponRepo := NewMemoryCouponRepository(campaignRepo)
+	ctx := context.Background()
+
+	campaignRepo.Save(ctx, &coupon.Campaign{
+		CampaignId:    "t7",
+		TotalQuantity: 1,
+		Status:        coupon.CampaignStatus_ACTIVE,
+		StartTime:     time.Now().Unix(),
+		Version:       1,
+	})
+
+	couponRepo.IssueCoupon(ctx, "t7", "user-1", "UPDATE1")
+	if c, _ := campaignRepo.GetByID(ctx, "t7"); c.Status != coupon.CampaignStatus_COMPLETED {
+		t.Fatalf("소진 후 상태가 COMPLETED 가 아님: %s", c.Status)
+	}
+
+	var wg sync.WaitGroup
+	successCount := 0
+	var mu sync.Mutex
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+
+			quantity := int32(10 + index)
+			updated, _ := campaignRepo.Modify(ctx, "t7", func(c *model.Campaign) error {
+				return c.ApplyChanges(1, model.CampaignChanges{TotalQuantity: &quantity}, time.Now())
+			})
+			if updated != nil {
+				mu.Lock()
+				successCount++
+				mu.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if successCount != 1 {
+		t.Errorf("예상: 1개 수정 성공, 실제: %d개", successCount)
+	}
+
+	current, _ := campaignRepo.GetByID(ctx, "t7")
+	if current.Version != 2 || current.Status != coupon.CampaignStatus_ACTIVE {
+		t.Errorf("수정 후 상태가 잘못됨: version %d, status %s", current.Version, current.Status)
+	}
+
+	if issued, _ := couponRepo.IssueCoupon(ctx, "t7", "user-2", "UPDATE2"); issued == nil {
+		t.Error("수량 추가 후 발급 실패")
+	}
+
+	// 발급된 수량보다 적게 줄일 수 없음
+	tooSmall := int32(1)
+	if updated, _ := campaignRepo.Modify(ctx, "t7", func(c *model.Campaign) error {
+		return c.ApplyChanges(2, model.CampaignChanges{TotalQuantity: &tooSmall}, time.Now())
+	}); updated != nil {
+		t.Error("발급된 수량보다 적은 수량으로 수정됨")
+	}
+
+	// 시작 이후에는 이름 변경 불가
+	name := "새 이름"
+	if updated, _ := campaignRepo.Modify(ctx, "t7", func(c *model.Campaign) error {
+		return c.ApplyChanges(2, model.CampaignChanges{Name: &name}, time.Now())
+	}); updated != nil {
+		t.Error("진행 중인 캠페인의 이름이 변경됨")
+	}
+}
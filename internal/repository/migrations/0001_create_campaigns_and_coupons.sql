@@ -0,0 +1,32 @@
+-- 캠페인: issued_quantity 는 발급 트랜잭션에서만 증가
+CREATE TABLE campaigns (
+    campaign_id     TEXT    PRIMARY KEY,
+    name            TEXT    NOT NULL,
+    total_quantity  INTEGER NOT NULL,
+    issued_quantity INTEGER NOT NULL DEFAULT 0,
+    start_time      BIGINT  NOT NULL,
+    end_time        BIGINT  NOT NULL DEFAULT 0,
+    status          INTEGER NOT NULL,
+    created_at      BIGINT  NOT NULL,
+    max_per_user    INTEGER NOT NULL DEFAULT 0,
+    version         BIGINT  NOT NULL DEFAULT 0,
+    CONSTRAINT campaigns_issued_quantity_check CHECK (issued_quantity >= 0 AND issued_quantity <= total_quantity)
+);
+
+CREATE INDEX campaigns_created_at_idx ON campaigns (created_at, campaign_id);
+
+-- 쿠폰: coupon_code 가 기본 키이므로 캠페인과 관계없이 전역에서 유일
+-- 캠페인이 삭제되어도 코드 유일성을 유지하기 위해 외래 키는 두지 않음
+CREATE TABLE coupons (
+    coupon_code TEXT      PRIMARY KEY,
+    seq         BIGSERIAL NOT NULL, -- 캠페인 내 발급 순서
+    campaign_id TEXT      NOT NULL,
+    issued_to   TEXT      NOT NULL,
+    issued_at   BIGINT    NOT NULL,
+    status      INTEGER   NOT NULL,
+    redeemed_at BIGINT    NOT NULL DEFAULT 0,
+    order_id    TEXT      NOT NULL DEFAULT ''
+);
+
+CREATE INDEX coupons_campaign_seq_idx ON coupons (campaign_id, seq);
+CREATE INDEX coupons_campaign_user_idx ON coupons (campaign_id, issued_to);
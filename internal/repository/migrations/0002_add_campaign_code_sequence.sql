@@ -0,0 +1,2 @@
+-- 순번 기반 쿠폰 코드 생성용 다음 코드 순번 (발급 트랜잭션에서만 증가)
+ALTER TABLE campaigns ADD COLUMN code_sequence BIGINT NOT NULL DEFAULT 0;
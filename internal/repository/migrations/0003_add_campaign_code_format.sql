@@ -0,0 +1,6 @@
+-- 캠페인별 쿠폰 코드 형식 (0 이면 기본 HANGUL 형식)
+ALTER TABLE campaigns
+    ADD COLUMN code_format   INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN code_length   INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN code_prefix   TEXT    NOT NULL DEFAULT '',
+    ADD COLUMN code_template TEXT    NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+-- 쿠폰 코드 끝에 오타 검출용 검사 문자를 붙이는지 여부
+ALTER TABLE campaigns ADD COLUMN code_check_char BOOLEAN NOT NULL DEFAULT FALSE;
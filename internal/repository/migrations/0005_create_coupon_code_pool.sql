@@ -0,0 +1,9 @@
+-- POOL 형식 캠페인의 코드 풀: 아직 발급되지 않은 코드만 남고, 발급되면 coupons 로 옮겨짐
+-- code 가 기본 키이므로 모든 캠페인의 풀에서 유일하며, coupons 와의 유일성은 INSERT 시 서로 확인
+CREATE TABLE coupon_code_pool (
+    code        TEXT      PRIMARY KEY,
+    position    BIGSERIAL NOT NULL, -- 등록 순서 (발급 순서)
+    campaign_id TEXT      NOT NULL
+);
+
+CREATE INDEX coupon_code_pool_campaign_position_idx ON coupon_code_pool (campaign_id, position);
@@ -0,0 +1,31 @@
+-- 쿠폰 코드 정규형(model.NormalizeCouponCode): 구분자 '-' 는 코드에 남지 않음
+-- 이전에 TEMPLATE 형식으로 만든 코드의 '-' 를 제거 (같은 템플릿의 코드는 '-' 위치가 같으므로 서로 겹치지 않음)
+-- 다른 캠페인의 코드와는 겹칠 수 있으므로(TEMPLATE 'AB-12' 와 ALPHANUMERIC 'AB12' 등) 먼저 확인하고,
+-- 겹치는 코드가 있으면 아무것도 바꾸지 않고 목록과 함께 중단 (정리한 뒤 서버를 다시 시작하면 이어서 적용됨)
+DO $$
+DECLARE
+    conflicts text;
+BEGIN
+    SELECT string_agg(format('%s → %s', codes, normalized), ', ')
+    INTO conflicts
+    FROM (
+        SELECT normalized, string_agg(format('%s(%s)', code, campaign_id), ' / ' ORDER BY code) AS codes
+        FROM (
+            SELECT coupon_code AS code, campaign_id, replace(coupon_code, '-', '') AS normalized FROM coupons
+            UNION ALL
+            SELECT code, campaign_id, replace(code, '-', '') FROM coupon_code_pool
+        ) all_codes
+        GROUP BY normalized
+        HAVING count(*) > 1
+        ORDER BY normalized
+        LIMIT 100
+    ) collided;
+
+    IF conflicts IS NOT NULL THEN
+        RAISE EXCEPTION '구분자를 제거하면 겹치는 쿠폰 코드가 있습니다 (최대 100건): %', conflicts
+            USING HINT = '겹치는 코드 중 하나를 변경하거나 삭제한 뒤 다시 시작하세요';
+    END IF;
+END $$;
+
+UPDATE coupons SET coupon_code = replace(coupon_code, '-', '') WHERE coupon_code LIKE '%-%';
+UPDATE coupon_code_pool SET code = replace(code, '-', '') WHERE code LIKE '%-%';
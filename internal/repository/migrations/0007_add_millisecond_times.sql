@@ -0,0 +1,12 @@
+-- 밀리초 단위 시각 (초 단위 컬럼은 예전 클라이언트를 위해 함께 유지)
+-- 이전에 저장된 행은 초 단위 값으로 채움
+ALTER TABLE campaigns
+    ADD COLUMN start_time_ms BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN end_time_ms   BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE coupons
+    ADD COLUMN issued_at_ms   BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN redeemed_at_ms BIGINT NOT NULL DEFAULT 0;
+
+UPDATE campaigns SET start_time_ms = start_time * 1000, end_time_ms = end_time * 1000;
+UPDATE coupons SET issued_at_ms = issued_at * 1000, redeemed_at_ms = redeemed_at * 1000;
@@ -0,0 +1,820 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"coupon-issuance-system/gen/coupon"
+	"coupon-issuance-system/internal/clock"
+	"coupon-issuance-system/internal/model"
+	"github.com/lib/pq"
+)
+
+/*
+# PostgreSQL 저장소
+
+여러 서버 인스턴스가 같은 DB 를 공유해도 초과 발급이 일어나지 않도록,
+메모리 저장소의 캠페인별 뮤텍스 역할을 캠페인 행 잠금(SELECT ... FOR UPDATE)이 대신함
+
+발급 트랜잭션
+  1. 캠페인 행 잠금 (같은 캠페인의 발급/상태 전이는 여기서 직렬화)
+  2. 사용자 발급 수 조회 → 도메인 모델로 발급 가능 여부 확인
+  3. issued_quantity 조건부 증가 (읽은 값 그대로일 때만)
+  4. 쿠폰 INSERT (coupon_code 기본 키 제약으로 전역 유일성 보장)
+  5. COMMIT. 3, 4 중 하나라도 실패하면 전체 롤백
+
+코드 풀 (POOL 형식 캠페인)
+  - 등록: 캠페인 행 잠금 → coupons/coupon_code_pool 에 이미 있는 코드 확인 → coupon_code_pool INSERT → total_quantity 증가
+  - 발급: 발급 트랜잭션 4 단계에서 풀의 가장 앞 코드를 DELETE ... RETURNING 으로 꺼내 coupons 로 INSERT
+  - 쿠폰 INSERT 는 풀에 있는 코드를 거절하므로 풀의 코드는 다른 발급 경로가 쓸 수 없음
+    단, 풀 등록과 같은 코드의 무작위 발급이 동시에 커밋되면 둘 다 성공할 수 있으며, 이 코드는 풀에서 꺼낼 때 버리고 다음 코드로 넘어감
+*/
+
+// OpenPostgres DSN 으로 연결하고 스키마 마이그레이션까지 적용
+func OpenPostgres(ctx context.Context, dsn string) (*sql.DB, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("PostgreSQL 연결 실패: %w", err)
+	}
+
+	if err := MigratePostgres(ctx, db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+const campaignColumns = `campaign_id, name, total_quantity, issued_quantity, start_time, end_time, status, created_at, max_per_user, version, code_sequence,
+	code_format, code_length, code_prefix, code_template, code_check_char, start_time_ms, end_time_ms`
+
+const updateCampaignSQL = `
+	UPDATE campaigns SET
+		name = $2, total_quantity = $3, issued_quantity = $4, start_time = $5, end_time = $6,
+		status = $7, created_at = $8, max_per_user = $9, version = $10, code_sequence = $11,
+		code_format = $12, code_length = $13, code_prefix = $14, code_template = $15, code_check_char = $16,
+		start_time_ms = $17, end_time_ms = $18
+	WHERE campaign_id = $1`
+
+const couponColumns = `coupon_code, campaign_id, issued_to, issued_at, status, redeemed_at, order_id, issued_at_ms, redeemed_at_ms`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanCampaign(row rowScanner) (*coupon.Campaign, error) {
+	campaign := &coupon.Campaign{}
+	err := row.Scan(
+		&campaign.CampaignId,
+		&campaign.Name,
+		&campaign.TotalQuantity,
+		&campaign.IssuedQuantity,
+		&campaign.StartTime,
+		&campaign.EndTime,
+		&campaign.Status,
+		&campaign.CreatedAt,
+		&campaign.MaxPerUser,
+		&campaign.Version,
+		&campaign.CodeSequence,
+		&campaign.CodeFormat,
+		&campaign.CodeLength,
+		&campaign.CodePrefix,
+		&campaign.CodeTemplate,
+		&campaign.CodeCheckChar,
+		&campaign.StartTimeMs,
+		&campaign.EndTimeMs,
+	)
+	if err != nil {
+		return nil, err
+	}
+	model.NewCampaign(campaign).FillMillis() // 밀리초 컬럼 없이 저장한 예전 서버의 행
+	return campaign, nil
+}
+
+func scanCoupon(row rowScanner) (*coupon.Coupon, error) {
+	cp := &coupon.Coupon{}
+	err := row.Scan(
+		&cp.CouponCode,
+		&cp.CampaignId,
+		&cp.IssuedTo,
+		&cp.IssuedAt,
+		&cp.Status,
+		&cp.RedeemedAt,
+		&cp.OrderId,
+		&cp.IssuedAtMs,
+		&cp.RedeemedAtMs,
+	)
+	if err != nil {
+		return nil, err
+	}
+	model.NewCoupon(cp).FillMillis()
+	return cp, nil
+}
+
+// isUniqueViolation 기본 키/유니크 제약 위반 여부
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
+// execTx 트랜잭션 안에서 fn 을 실행. fn 이 오류(실패 사유 포함)를 반환하면 롤백
+func execTx(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // 커밋 후에는 아무 일도 하지 않음
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////
+
+type PostgresCampaignRepository struct {
+	db    *sql.DB
+	clock clock.Clock // 조회 시점 상태 계산 기준
+}
+
+func NewPostgresCampaignRepository(db *sql.DB, clk clock.Clock) *PostgresCampaignRepository {
+	return &PostgresCampaignRepository{db: db, clock: clk}
+}
+
+func (r *PostgresCampaignRepository) Save(ctx context.Context, campaign *coupon.Campaign) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO campaigns (`+campaignColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+		ON CONFLICT (campaign_id) DO UPDATE SET
+			name = EXCLUDED.name,
+			total_quantity = EXCLUDED.total_quantity,
+			issued_quantity = EXCLUDED.issued_quantity,
+			start_time = EXCLUDED.start_time,
+			end_time = EXCLUDED.end_time,
+			status = EXCLUDED.status,
+			created_at = EXCLUDED.created_at,
+			max_per_user = EXCLUDED.max_per_user,
+			version = EXCLUDED.version,
+			code_sequence = EXCLUDED.code_sequence,
+			code_format = EXCLUDED.code_format,
+			code_length = EXCLUDED.code_length,
+			code_prefix = EXCLUDED.code_prefix,
+			code_template = EXCLUDED.code_template,
+			code_check_char = EXCLUDED.code_check_char,
+			start_time_ms = EXCLUDED.start_time_ms,
+			end_time_ms = EXCLUDED.end_time_ms`,
+		campaignArgs(campaign)...,
+	)
+	return err
+}
+
+func (r *PostgresCampaignRepository) GetByID(ctx context.Context, id string) (*coupon.Campaign, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+campaignColumns+` FROM campaigns WHERE campaign_id = $1`, id)
+
+	campaign, err := scanCampaign(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w. id: %s", model.ErrCampaignNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	model.NewCampaign(campaign).UpdateStatusIfNeeded(r.clock.Now()) // 상태 업데이트 (lazy evaluation, 저장은 다음 변경 시)
+
+	return campaign, nil
+}
+
+// List 시간에 따라 바뀌는 상태는 조회 시점에 계산되므로, 상태 조건만 행을 읽으면서 확인하고 나머지 조건은 SQL 로 처리
+func (r *PostgresCampaignRepository) List(
+	ctx context.Context,
+	filter CampaignFilter,
+	after *CampaignCursor,
+	limit int,
+) (campaigns []*coupon.Campaign, hasMore bool, err error) {
+
+	var conditions []string
+	var args []any
+	addCondition := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, strings.ReplaceAll(condition, "?", "$"+strconv.Itoa(len(args))))
+	}
+
+	if after != nil {
+		args = append(args, after.CreatedAt, after.CampaignID)
+		conditions = append(conditions, fmt.Sprintf("(created_at, campaign_id) > ($%d, $%d)", len(args)-1, len(args)))
+	}
+	if filter.NameContains != "" {
+		addCondition("strpos(name, ?) > 0", filter.NameContains)
+	}
+	if filter.StartTimeFrom != 0 {
+		addCondition("start_time >= ?", filter.StartTimeFrom)
+	}
+	if filter.StartTimeTo != 0 {
+		addCondition("start_time <= ?", filter.StartTimeTo)
+	}
+
+	query := `SELECT ` + campaignColumns + ` FROM campaigns`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	query += ` ORDER BY created_at, campaign_id`
+	if len(filter.Statuses) == 0 {
+		query += ` LIMIT ` + strconv.Itoa(limit+1)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, false, err
+	}
+	defer rows.Close()
+
+	now := r.clock.Now()
+	for rows.Next() {
+		campaign, err := scanCampaign(rows)
+		if err != nil {
+			return nil, false, err
+		}
+
+		model.NewCampaign(campaign).UpdateStatusIfNeeded(now)
+		if !filter.matches(campaign) {
+			continue
+		}
+
+		if len(campaigns) == limit {
+			return campaigns, true, nil
+		}
+		campaigns = append(campaigns, campaign)
+	}
+
+	return campaigns, false, rows.Err()
+}
+
+func (r *PostgresCampaignRepository) Update(ctx context.Context, campaign *coupon.Campaign) error {
+	result, err := r.db.ExecContext(ctx, updateCampaignSQL, campaignArgs(campaign)...)
+	if err != nil {
+		return err
+	}
+
+	return requireCampaignAffected(result, campaign.CampaignId)
+}
+
+func (r *PostgresCampaignRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM campaigns WHERE campaign_id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	return requireCampaignAffected(result, id)
+}
+
+// Modify 캠페인 행을 잠근 트랜잭션 안에서 modify 를 실행 (발급 트랜잭션과 같은 행 잠금)
+func (r *PostgresCampaignRepository) Modify(
+	ctx context.Context,
+	id string,
+	modify CampaignModifier,
+) (*coupon.Campaign, error) {
+
+	var modified *coupon.Campaign
+	err := execTx(ctx, r.db, func(tx *sql.Tx) error {
+		campaign, err := lockCampaign(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if campaign == nil {
+			return model.ErrCampaignNotFound
+		}
+
+		if err := modify(model.NewCampaign(campaign)); err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, updateCampaignSQL, campaignArgs(campaign)...)
+		if err != nil {
+			return err
+		}
+
+		modified = campaign
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return modified, nil
+}
+
+// lockCampaign 캠페인 행을 잠그고 조회. 캠페인이 없으면 nil
+func lockCampaign(ctx context.Context, tx *sql.Tx, id string) (*coupon.Campaign, error) {
+	row := tx.QueryRowContext(ctx, `SELECT `+campaignColumns+` FROM campaigns WHERE campaign_id = $1 FOR UPDATE`, id)
+
+	campaign, err := scanCampaign(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return campaign, err
+}
+
+func campaignArgs(campaign *coupon.Campaign) []any {
+	return []any{
+		campaign.CampaignId,
+		campaign.Name,
+		campaign.TotalQuantity,
+		campaign.IssuedQuantity,
+		campaign.StartTime,
+		campaign.EndTime,
+		campaign.Status,
+		campaign.CreatedAt,
+		campaign.MaxPerUser,
+		campaign.Version,
+		campaign.CodeSequence,
+		campaign.CodeFormat,
+		campaign.CodeLength,
+		campaign.CodePrefix,
+		campaign.CodeTemplate,
+		campaign.CodeCheckChar,
+		campaign.StartTimeMs,
+		campaign.EndTimeMs,
+	}
+}
+
+// requireCampaignAffected 변경된 행이 없으면 캠페인이 없는 것으로 보고 model.ErrCampaignNotFound 를 감싼 오류
+func requireCampaignAffected(result sql.Result, id string) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return fmt.Errorf("%w. id: %s", model.ErrCampaignNotFound, id)
+	}
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////
+
+type PostgresCouponRepository struct {
+	db    *sql.DB
+	clock clock.Clock // 발급 가능 여부 판정과 발급/사용 시각 기준
+}
+
+func NewPostgresCouponRepository(db *sql.DB, clk clock.Clock) *PostgresCouponRepository {
+	return &PostgresCouponRepository{db: db, clock: clk}
+}
+
+// Save 발급과 같은 캠페인 행 잠금 안에서 INSERT (seq 가 커밋 순서대로 커지도록)
+func (r *PostgresCouponRepository) Save(ctx context.Context, cp *coupon.Coupon) error {
+	return execTx(ctx, r.db, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `SELECT 1 FROM campaigns WHERE campaign_id = $1 FOR UPDATE`, cp.CampaignId); err != nil {
+			return err
+		}
+		return insertCoupon(ctx, tx, cp)
+	})
+}
+
+func (r *PostgresCouponRepository) GetByCampaignID(ctx context.Context, campaignID string) ([]*coupon.Coupon, error) {
+	coupons, _, err := r.queryCoupons(ctx, `
+		SELECT `+couponColumns+` FROM coupons WHERE campaign_id = $1 ORDER BY seq`, -1, campaignID)
+	return coupons, err
+}
+
+// ListByCampaignID 캠페인의 쿠폰을 발급 순서(seq)대로 after 보다 큰 seq 부터 최대 limit 개 반환
+// 쿠폰 INSERT 는 모두 캠페인 행 잠금 안에서 seq 를 할당하므로 같은 캠페인 안에서는 seq 순서가 커밋 순서와 같음
+// (잠금 없이 할당하면 먼저 할당받은 트랜잭션이 늦게 커밋될 때 이미 지나간 페이지에 끼어들어 누락됨)
+func (r *PostgresCouponRepository) ListByCampaignID(
+	ctx context.Context,
+	campaignID string,
+	after CouponCursor,
+	limit int,
+) ([]*coupon.Coupon, CouponCursor, bool, error) {
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT seq, `+couponColumns+` FROM coupons WHERE campaign_id = $1 AND seq > $2 ORDER BY seq LIMIT $3`,
+		campaignID, int64(after), limit+1)
+	if err != nil {
+		return nil, after, false, err
+	}
+	defer rows.Close()
+
+	coupons := []*coupon.Coupon{}
+	next := after
+	for rows.Next() {
+		var seq int64
+		cp, err := scanCoupon(seqScanner{rows: rows, seq: &seq})
+		if err != nil {
+			return nil, after, false, err
+		}
+
+		if len(coupons) == limit {
+			return coupons, next, true, nil
+		}
+		coupons = append(coupons, cp)
+		next = CouponCursor(seq)
+	}
+
+	return coupons, next, false, rows.Err()
+}
+
+// seqScanner 첫 번째 열(seq)을 읽고 나머지 열을 scanCoupon 에 넘김
+type seqScanner struct {
+	rows *sql.Rows
+	seq  *int64
+}
+
+func (s seqScanner) Scan(dest ...any) error {
+	return s.rows.Scan(append([]any{s.seq}, dest...)...)
+}
+
+// queryCoupons limit 보다 하나 더 조회해서 다음 페이지 존재 여부를 판단 (limit < 0 이면 전체)
+func (r *PostgresCouponRepository) queryCoupons(ctx context.Context, query string, limit int, args ...any) ([]*coupon.Coupon, bool, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, false, err
+	}
+	defer rows.Close()
+
+	coupons := []*coupon.Coupon{}
+	for rows.Next() {
+		cp, err := scanCoupon(rows)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if limit >= 0 && len(coupons) == limit {
+			return coupons, true, nil
+		}
+		coupons = append(coupons, cp)
+	}
+
+	return coupons, false, rows.Err()
+}
+
+func (r *PostgresCouponRepository) GetByCode(ctx context.Context, code string) (*coupon.Coupon, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+couponColumns+` FROM coupons WHERE coupon_code = $1`, code)
+
+	cp, err := scanCoupon(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w. code: %s", model.ErrCouponNotFound, code)
+	}
+	return cp, err
+}
+
+// IssueCoupon 발급 조건 확인, issued_quantity 조건부 증가, 쿠폰 INSERT 를 하나의 트랜잭션으로 처리
+func (r *PostgresCouponRepository) IssueCoupon(
+	ctx context.Context,
+	campaignID,
+	userID,
+	couponCode string,
+) (*coupon.Coupon, error) {
+
+	return r.issue(ctx, campaignID, userID, func(tx *sql.Tx, campaign *coupon.Campaign, issued *coupon.Coupon) error {
+		issued.CouponCode = couponCode
+		return insertCoupon(ctx, tx, issued) // ErrDuplicateCouponCode 여도 롤백되므로 발급 수량은 원래대로
+	})
+}
+
+// IssueSequencedCoupon 캠페인 행 잠금 안에서 code_sequence 를 증가시키며 codeFor 로 만든 코드를 INSERT
+// 다른 캠페인의 코드와 겹치면 트랜잭션을 중단시키지 않도록 ON CONFLICT DO NOTHING 으로 확인하고 다음 순번으로 넘어감
+func (r *PostgresCouponRepository) IssueSequencedCoupon(
+	ctx context.Context,
+	campaignID,
+	userID string,
+	codeFor SequencedCode,
+) (*coupon.Coupon, error) {
+
+	return r.issue(ctx, campaignID, userID, func(tx *sql.Tx, campaign *coupon.Campaign, issued *coupon.Coupon) error {
+		sequence := campaign.CodeSequence
+
+		for skip := 0; skip <= maxSequenceSkips; skip++ {
+			couponCode, err := codeFor(sequence)
+			if err != nil {
+				return err
+			}
+			sequence++
+
+			issued.CouponCode = couponCode
+			inserted, err := insertCouponIfAbsent(ctx, tx, issued)
+			if err != nil {
+				return err
+			}
+			if !inserted {
+				continue // 다른 캠페인이 이미 사용 중인 코드
+			}
+
+			_, err = tx.ExecContext(ctx, `UPDATE campaigns SET code_sequence = $2 WHERE campaign_id = $1`, campaignID, sequence)
+			return err
+		}
+
+		return ErrDuplicateCouponCode
+	})
+}
+
+// IssuePooledCoupon 캠페인 행 잠금 안에서 코드 풀의 가장 앞 코드를 꺼내 INSERT (실패하면 롤백되어 코드도 풀로 돌아감)
+func (r *PostgresCouponRepository) IssuePooledCoupon(
+	ctx context.Context,
+	campaignID,
+	userID string,
+) (*coupon.Coupon, error) {
+
+	issued, err := r.issue(ctx, campaignID, userID, func(tx *sql.Tx, campaign *coupon.Campaign, issued *coupon.Coupon) error {
+		for skip := 0; skip <= maxSequenceSkips; skip++ {
+			err := tx.QueryRowContext(ctx, `
+				DELETE FROM coupon_code_pool WHERE code = (
+					SELECT code FROM coupon_code_pool WHERE campaign_id = $1 ORDER BY position LIMIT 1
+				)
+				RETURNING code`,
+				campaignID,
+			).Scan(&issued.CouponCode)
+			if errors.Is(err, sql.ErrNoRows) {
+				return errCodePoolExhausted
+			}
+			if err != nil {
+				return err
+			}
+
+			inserted, err := insertCouponIfAbsent(ctx, tx, issued)
+			if err != nil || inserted {
+				return err
+			}
+			// 풀 등록과 동시에 다른 경로로 발급된 코드. 풀에서 버리고 다음 코드로
+		}
+
+		return ErrDuplicateCouponCode
+	})
+
+	if errors.Is(err, errCodePoolExhausted) {
+		return nil, model.ErrCampaignSoldOut
+	}
+	return issued, err
+}
+
+// issue 발급 공통 트랜잭션. insert 는 issued 에 코드를 채워 INSERT 해야 하며, 오류를 반환하면 전체 롤백
+func (r *PostgresCouponRepository) issue(
+	ctx context.Context,
+	campaignID,
+	userID string,
+	insert func(tx *sql.Tx, campaign *coupon.Campaign, issued *coupon.Coupon) error,
+) (*coupon.Coupon, error) {
+
+	var issued *coupon.Coupon
+	err := execTx(ctx, r.db, func(tx *sql.Tx) error {
+		pbCampaign, err := lockCampaign(ctx, tx, campaignID)
+		if err != nil {
+			return err
+		}
+		if pbCampaign == nil {
+			return model.ErrCampaignNotFound
+		}
+
+		var userIssuedCount int32
+		err = tx.QueryRowContext(ctx, `
+			SELECT count(*) FROM coupons WHERE campaign_id = $1 AND issued_to = $2`,
+			campaignID, userID,
+		).Scan(&userIssuedCount)
+		if err != nil {
+			return err
+		}
+
+		now := r.clock.Now()
+		domainCampaign := model.NewCampaign(pbCampaign)
+
+		if err := domainCampaign.CanIssueCouponTo(userIssuedCount, now); err != nil {
+			return err
+		}
+
+		before := pbCampaign.IssuedQuantity
+		if err := domainCampaign.IssueCoupon(now); err != nil {
+			return err
+		}
+
+		// 읽은 수량 그대로이고 총 수량을 넘지 않을 때만 증가 (행 잠금과 별개로 SQL 조건으로도 초과 발급 방지)
+		result, err := tx.ExecContext(ctx, `
+			UPDATE campaigns SET issued_quantity = issued_quantity + 1, status = $3
+			WHERE campaign_id = $1 AND issued_quantity = $2 AND issued_quantity < total_quantity`,
+			campaignID, before, pbCampaign.Status,
+		)
+		if err != nil {
+			return err
+		}
+		if affected, err := result.RowsAffected(); err != nil {
+			return err
+		} else if affected == 0 {
+			return model.ErrCampaignSoldOut
+		}
+
+		issued = &coupon.Coupon{
+			CampaignId: campaignID,
+			IssuedAt:   now.Unix(),
+			IssuedAtMs: now.UnixMilli(),
+			IssuedTo:   userID,
+			Status:     coupon.CouponStatus_ISSUED,
+		}
+		return insert(tx, pbCampaign, issued)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return issued, nil
+}
+
+// RedeemCoupon 쿠폰 행을 잠그고 사용 처리. 같은 쿠폰의 동시 사용 요청은 행 잠금에서 직렬화됨
+func (r *PostgresCouponRepository) RedeemCoupon(
+	ctx context.Context,
+	couponCode,
+	userID,
+	orderID string,
+) (*coupon.Coupon, error) {
+
+	var redeemed *coupon.Coupon
+	err := execTx(ctx, r.db, func(tx *sql.Tx) error {
+		row := tx.QueryRowContext(ctx, `SELECT `+couponColumns+` FROM coupons WHERE coupon_code = $1 FOR UPDATE`, couponCode)
+
+		cp, err := scanCoupon(row)
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.ErrCouponNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := model.NewCoupon(cp).Redeem(userID, orderID, r.clock.Now()); err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			UPDATE coupons SET status = $2, redeemed_at = $3, order_id = $4, redeemed_at_ms = $5 WHERE coupon_code = $1`,
+			cp.CouponCode, cp.Status, cp.RedeemedAt, cp.OrderId, cp.RedeemedAtMs,
+		)
+		if err != nil {
+			return err
+		}
+
+		redeemed = cp
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return redeemed, nil
+}
+
+// ImportCodePool 캠페인 행 잠금 안에서 코드 충돌을 확인한 뒤 풀에 등록하고 total_quantity 를 늘림
+func (r *PostgresCouponRepository) ImportCodePool(
+	ctx context.Context,
+	campaignID string,
+	codes []string,
+) (*coupon.Campaign, error) {
+
+	var imported *coupon.Campaign
+	err := execTx(ctx, r.db, func(tx *sql.Tx) error {
+		campaign, err := lockCampaign(ctx, tx, campaignID)
+		if err != nil {
+			return err
+		}
+		if campaign == nil {
+			return model.ErrCampaignNotFound
+		}
+
+		if err := model.NewCampaign(campaign).AddPoolCodes(int32(len(codes)), r.clock.Now()); err != nil {
+			return err
+		}
+
+		conflicts, err := queryPoolConflicts(ctx, tx, codes)
+		if err != nil {
+			return err
+		}
+		if len(conflicts) > 0 {
+			return &CodePoolConflictError{Codes: conflicts}
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO coupon_code_pool (code, campaign_id)
+			SELECT code, $2 FROM unnest($1::text[]) WITH ORDINALITY AS c(code, ord) ORDER BY ord`,
+			pq.Array(codes), campaignID,
+		)
+		if isUniqueViolation(err) {
+			return ErrDuplicateCouponCode // 요청 안의 중복 또는 같은 코드를 동시에 등록한 다른 요청
+		}
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, updateCampaignSQL, campaignArgs(campaign)...); err != nil {
+			return err
+		}
+
+		imported = campaign
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return imported, nil
+}
+
+// queryPoolConflicts codes 중 이미 발급되었거나 코드 풀에 있는 코드 (최대 maxReportedPoolConflicts 개)
+func queryPoolConflicts(ctx context.Context, tx *sql.Tx, codes []string) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT c.code FROM unnest($1::text[]) AS c(code)
+		WHERE EXISTS (SELECT 1 FROM coupons WHERE coupon_code = c.code)
+		   OR EXISTS (SELECT 1 FROM coupon_code_pool WHERE code = c.code)
+		LIMIT $2`,
+		pq.Array(codes), maxReportedPoolConflicts,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var conflicts []string
+	for rows.Next() {
+		var code string
+		if err := rows.Scan(&code); err != nil {
+			return nil, err
+		}
+		conflicts = append(conflicts, code)
+	}
+	return conflicts, rows.Err()
+}
+
+// RevokeByCampaignID 캠페인의 사용되지 않은 쿠폰을 모두 회수 (이미 사용된 쿠폰은 유지)
+func (r *PostgresCouponRepository) RevokeByCampaignID(ctx context.Context, campaignID string) (int32, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE coupons SET status = $2
+		WHERE campaign_id = $1 AND status IN ($3, $4)`,
+		campaignID,
+		coupon.CouponStatus_REVOKED,
+		coupon.CouponStatus_COUPON_STATUS_UNSPECIFIED,
+		coupon.CouponStatus_ISSUED,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	revoked, err := result.RowsAffected()
+	return int32(revoked), err
+}
+
+// execer *sql.DB 와 *sql.Tx 공통
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// insertCouponSQL 코드 풀에 있는 코드(아직 발급되지 않은 POOL 형식 캠페인의 코드)는 INSERT 하지 않음
+const insertCouponSQL = `
+	INSERT INTO coupons (` + couponColumns + `)
+	SELECT $1::text, $2::text, $3::text, $4::bigint, $5::integer, $6::bigint, $7::text, $8::bigint, $9::bigint
+	WHERE NOT EXISTS (SELECT 1 FROM coupon_code_pool WHERE code = $1)`
+
+// insertCoupon 코드가 이미 발급되었거나 코드 풀에 있으면 ErrDuplicateCouponCode
+func insertCoupon(ctx context.Context, db execer, cp *coupon.Coupon) error {
+	result, err := db.ExecContext(ctx, insertCouponSQL, couponArgs(cp)...)
+	if isUniqueViolation(err) {
+		return ErrDuplicateCouponCode
+	}
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrDuplicateCouponCode
+	}
+	return nil
+}
+
+// insertCouponIfAbsent 코드가 이미 있거나 코드 풀에 있으면 오류 대신 false 반환 (트랜잭션이 중단되지 않음)
+func insertCouponIfAbsent(ctx context.Context, db execer, cp *coupon.Coupon) (bool, error) {
+	result, err := db.ExecContext(ctx, insertCouponSQL+` ON CONFLICT (coupon_code) DO NOTHING`, couponArgs(cp)...)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+func couponArgs(cp *coupon.Coupon) []any {
+	return []any{cp.CouponCode, cp.CampaignId, cp.IssuedTo, cp.IssuedAt, cp.Status, cp.RedeemedAt, cp.OrderId, cp.IssuedAtMs, cp.RedeemedAtMs}
+}
+
+// 컴파일 타임 인터페이스 검증
+var (
+	_ CampaignRepository = (*PostgresCampaignRepository)(nil)
+	_ CouponRepository   = (*PostgresCouponRepository)(nil)
+)
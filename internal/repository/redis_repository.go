@@ -0,0 +1,789 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"coupon-issuance-system/gen/coupon"
+	"coupon-issuance-system/internal/clock"
+	"coupon-issuance-system/internal/model"
+	"github.com/redis/go-redis/v9"
+)
+
+/*
+# Redis 발급 경로
+
+발급 요청이 몰릴 때 영속 저장소(PostgreSQL 등)의 캠페인 행 잠금 대신 Redis Lua 스크립트 하나로 발급을 확정하고,
+쿠폰 기록은 Redis Stream(outbox)을 거쳐 영속 저장소에 비동기로 저장
+
+발급 흐름
+  1. 영속 저장소에서 캠페인 조회 → 상태/기간 확인 (일시 중지, 취소 등은 영속 저장소 기준)
+  2. Lua 스크립트 (Redis 안에서 원자적으로 실행)
+     - 남은 수량 확인 → 사용자당 발급 수 확인 → 코드 중복 확인
+     - 발급 수량 증가, 사용자 발급 수 증가, 코드 등록, outbox 에 쿠폰 기록 추가
+  3. outbox 워커가 쿠폰을 영속 저장소에 저장하고 캠페인 발급 수량을 따라잡게 갱신
+
+Redis 키 (prefix 기본값 "coupon")
+  - {prefix}:{<campaignID>}:issued : 발급 수량
+  - {prefix}:{<campaignID>}:users  : userID → 발급 수 (hash)
+  - {prefix}:{<campaignID>}:total  : 총 수량 (캠페인 수정으로 총 수량이 바뀔 때 기록. 발급은 요청에 실린 총 수량과 둘 중 작은 값 기준)
+  - {prefix}:{<campaignID>}:seq    : 다음 코드 순번 (IssueSequencedCoupon, 첫 사용 시 영속 저장소의 code_sequence 로 초기화)
+  - {prefix}:codes                  : 발급된 코드와 코드 풀에 등록된 코드 (set, 전체 캠페인 공통)
+  - {prefix}:outbox                 : 영속 저장 대기 중인 쿠폰 (stream)
+
+코드 풀 (POOL 형식 캠페인)
+  - 코드 풀과 발급은 영속 저장소가 그대로 처리 (등록 순서대로 꺼내야 하므로 Redis 수량 키를 쓰지 않음)
+  - 등록한 코드를 {prefix}:codes 에도 추가하여 Redis 발급 경로가 같은 코드를 쓰지 않게 함
+
+주의
+  - 캠페인의 발급 수량 키는 첫 발급 시 영속 저장소의 값으로 초기화됨. Redis 는 AOF 등으로 영속화해야 하며,
+    outbox 가 처리되기 전에 Redis 데이터가 사라지면 그 사이의 발급 기록도 사라짐
+  - 조회(GetByCampaignID 등)는 영속 저장소를 그대로 읽으므로 outbox 처리 지연만큼 늦게 반영될 수 있음
+  - 캠페인 수정은 CampaignRepository() 가 반환하는 저장소로 해야 Redis 발급 수량 기준으로 총 수량을 검증함
+  - 키가 여러 슬롯에 걸치므로 Redis Cluster 가 아닌 단일 인스턴스(또는 복제 구성)를 전제로 함
+*/
+
+// issueScript 수량/사용자 한도/코드 중복 확인과 발급 기록을 한 번에 처리
+// KEYS: issued, users, codes, outbox, total
+// ARGV: totalQuantity, maxPerUser, userID, couponCode, issuedAt(초), campaignID, sequence (순번을 쓰지 않으면 -1), issuedAt(밀리초)
+// 총 수량은 요청에 실린 값(발급 전에 읽은 영속 저장소 값)과 total 키 중 작은 값 (발급 도중 총 수량이 줄어도 넘지 않도록)
+var issueScript = redis.NewScript(`
+local issued = redis.call('GET', KEYS[1])
+if not issued then
+	return {'uninitialized'}
+end
+local total = tonumber(ARGV[1])
+local storedTotal = redis.call('GET', KEYS[5])
+if storedTotal then
+	total = math.min(total, tonumber(storedTotal))
+end
+if tonumber(issued) >= total then
+	return {'sold_out'}
+end
+
+local userCount = tonumber(redis.call('HGET', KEYS[2], ARGV[3]) or '0')
+if userCount >= tonumber(ARGV[2]) then
+	return {'user_limit'}
+end
+
+if redis.call('SISMEMBER', KEYS[3], ARGV[4]) == 1 then
+	return {'duplicate'}
+end
+
+issued = redis.call('INCR', KEYS[1])
+redis.call('HINCRBY', KEYS[2], ARGV[3], 1)
+redis.call('SADD', KEYS[3], ARGV[4])
+redis.call('XADD', KEYS[4], '*',
+	'campaign_id', ARGV[6], 'user_id', ARGV[3], 'code', ARGV[4], 'issued_at', ARGV[5], 'issued', issued,
+	'sequence', ARGV[7], 'issued_at_ms', ARGV[8])
+
+return {'ok', issued}
+`)
+
+// seedScript 캠페인의 Redis 상태가 없을 때만 영속 저장소 기준으로 초기화
+// KEYS: issued, users, codes
+// ARGV: issuedQuantity, userCount, (userID, count) * userCount, code...
+var seedScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 1 then
+	return 0
+end
+
+local codesFrom = 3 + tonumber(ARGV[2]) * 2
+for i = 3, codesFrom - 1, 2 do
+	redis.call('HSET', KEYS[2], ARGV[i], ARGV[i + 1])
+end
+for i = codesFrom, #ARGV do
+	redis.call('SADD', KEYS[3], ARGV[i])
+end
+
+redis.call('SET', KEYS[1], ARGV[1])
+return 1
+`)
+
+// setTotalScript 발급 수량이 새 총 수량을 넘지 않을 때만 total 키를 갱신. 넘으면 현재 발급 수량, 갱신하면 -1
+// KEYS: issued, total
+// ARGV: totalQuantity
+var setTotalScript = redis.NewScript(`
+local issued = tonumber(redis.call('GET', KEYS[1]) or '0')
+if issued > tonumber(ARGV[1]) then
+	return issued
+end
+redis.call('SET', KEYS[2], ARGV[1])
+return -1
+`)
+
+// nextSequenceScript 캠페인의 코드 순번을 하나 가져옴. 순번 키가 없으면 영속 저장소의 다음 순번으로 초기화
+// KEYS: seq
+// ARGV: codeSequence
+var nextSequenceScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	redis.call('SET', KEYS[1], ARGV[1])
+end
+return redis.call('INCR', KEYS[1]) - 1
+`)
+
+const (
+	codeSetBatchSize    = 1000 // 코드 집합을 한 번에 조회/추가하는 코드 수
+	outboxGroup         = "coupon-writer"
+	defaultPollInterval = 50 * time.Millisecond
+	outboxBatchSize     = 100
+	outboxClaimIdle     = 30 * time.Second // 이 시간 이상 처리되지 않은 다른 워커의 기록은 가져와서 처리
+)
+
+// RedisIssuerOptions Redis 발급 경로 설정
+type RedisIssuerOptions struct {
+	KeyPrefix    string        // Redis 키 prefix (기본값 "coupon")
+	Consumer     string        // outbox 컨슈머 이름. 서버마다 달라야 함 (기본값 hostname-pid)
+	PollInterval time.Duration // outbox 가 비어 있을 때 다시 확인하는 주기 (기본값 50ms)
+	Clock        clock.Clock   // 발급 가능 여부 판정과 발급 시각 기준 (nil 이면 시스템 시계)
+}
+
+// RedisCouponRepository 발급은 Redis Lua 스크립트로, 나머지는 영속 쿠폰 저장소로 처리하는 쿠폰 저장소
+type RedisCouponRepository struct {
+	client       *redis.Client
+	campaignRepo CampaignRepository
+	durable      CouponRepository
+	opts         RedisIssuerOptions
+
+	outboxMutex sync.Mutex // outbox 읽기/처리 직렬화 (워커와 Flush)
+	stop        chan struct{}
+	done        chan struct{}
+
+	onIssuedChange atomic.Pointer[func(campaignID string)] // OnIssuedChange 로 등록 (워커가 이미 돌고 있으므로 원자적으로 교체)
+}
+
+// OnIssuedChange 캠페인의 발급 수량이 영속 저장소에 반영되었거나 소진을 확인했을 때 호출할 함수 등록
+// 영속 저장소의 상태 전이(COMPLETED)가 outbox 처리 시점에 따라 늦어지지 않도록 스케줄러의 Notify 를 연결하는 용도
+func (r *RedisCouponRepository) OnIssuedChange(notify func(campaignID string)) {
+	r.onIssuedChange.Store(&notify)
+}
+
+func (r *RedisCouponRepository) notifyIssuedChange(campaignID string) {
+	if notify := r.onIssuedChange.Load(); notify != nil {
+		(*notify)(campaignID)
+	}
+}
+
+// NewRedisCouponRepository outbox 컨슈머 그룹을 준비하고 워커를 시작
+func NewRedisCouponRepository(
+	ctx context.Context,
+	client *redis.Client,
+	campaignRepo CampaignRepository,
+	durable CouponRepository,
+	opts RedisIssuerOptions,
+) (*RedisCouponRepository, error) {
+
+	if opts.KeyPrefix == "" {
+		opts.KeyPrefix = "coupon"
+	}
+	if opts.Consumer == "" {
+		hostname, _ := os.Hostname()
+		opts.Consumer = fmt.Sprintf("%s-%d", hostname, os.Getpid())
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = defaultPollInterval
+	}
+	opts.Clock = clock.OrSystem(opts.Clock)
+
+	r := &RedisCouponRepository{
+		client:       client,
+		campaignRepo: campaignRepo,
+		durable:      durable,
+		opts:         opts,
+		stop:         make(chan struct{}),
+		done:         make(chan struct{}),
+	}
+
+	// 다른 서버가 이미 만든 그룹이면 그대로 사용
+	err := client.XGroupCreateMkStream(ctx, r.outboxKey(), outboxGroup, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return nil, fmt.Errorf("outbox 컨슈머 그룹 생성 실패: %w", err)
+	}
+
+	go r.runOutboxWorker()
+	return r, nil
+}
+
+// Close 워커를 멈추고 남은 outbox 를 영속 저장소에 저장
+func (r *RedisCouponRepository) Close(ctx context.Context) error {
+	close(r.stop)
+	<-r.done
+	return r.Flush(ctx)
+}
+
+func (r *RedisCouponRepository) issuedKey(campaignID string) string {
+	return fmt.Sprintf("%s:{%s}:issued", r.opts.KeyPrefix, campaignID)
+}
+
+func (r *RedisCouponRepository) usersKey(campaignID string) string {
+	return fmt.Sprintf("%s:{%s}:users", r.opts.KeyPrefix, campaignID)
+}
+
+func (r *RedisCouponRepository) totalKey(campaignID string) string {
+	return fmt.Sprintf("%s:{%s}:total", r.opts.KeyPrefix, campaignID)
+}
+
+func (r *RedisCouponRepository) sequenceKey(campaignID string) string {
+	return fmt.Sprintf("%s:{%s}:seq", r.opts.KeyPrefix, campaignID)
+}
+
+func (r *RedisCouponRepository) codesKey() string {
+	return r.opts.KeyPrefix + ":codes"
+}
+
+func (r *RedisCouponRepository) outboxKey() string {
+	return r.opts.KeyPrefix + ":outbox"
+}
+
+// IssueCoupon 캠페인 상태 확인 후 Lua 스크립트로 원자적 발급
+func (r *RedisCouponRepository) IssueCoupon(
+	ctx context.Context,
+	campaignID,
+	userID,
+	couponCode string,
+) (*coupon.Coupon, error) {
+
+	pbCampaign, err := r.loadIssuableCampaign(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	issued, err := r.issue(ctx, pbCampaign, userID, couponCode, -1)
+	if errors.Is(err, errCodeInUse) {
+		return nil, ErrDuplicateCouponCode
+	}
+	return issued, err
+}
+
+// IssueSequencedCoupon Redis 순번 키에서 순번을 가져와 codeFor 로 만든 코드로 발급
+// 순번은 발급 스크립트 전에 가져오므로 수량 소진 등으로 실패한 요청의 순번은 비어 있게 됨 (유일성에는 영향 없음)
+func (r *RedisCouponRepository) IssueSequencedCoupon(
+	ctx context.Context,
+	campaignID,
+	userID string,
+	codeFor SequencedCode,
+) (*coupon.Coupon, error) {
+
+	pbCampaign, err := r.loadIssuableCampaign(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	for skip := 0; skip <= maxSequenceSkips; skip++ {
+		sequence, err := nextSequenceScript.Run(ctx, r.client, []string{r.sequenceKey(campaignID)}, pbCampaign.CodeSequence).Int64()
+		if err != nil {
+			return nil, fmt.Errorf("Redis 코드 순번 조회 실패: %w", err)
+		}
+
+		couponCode, err := codeFor(sequence)
+		if err != nil {
+			return nil, err
+		}
+
+		issued, err := r.issue(ctx, pbCampaign, userID, couponCode, sequence)
+		if !errors.Is(err, errCodeInUse) {
+			return issued, err
+		}
+		// 다른 캠페인이 이미 사용 중인 코드. 다음 순번으로 다시 시도
+	}
+
+	return nil, ErrDuplicateCouponCode
+}
+
+// errCodeInUse issue 에서 코드가 이미 사용 중일 때 반환 (호출자가 ErrDuplicateCouponCode 로 바꾸거나 다음 순번으로 재시도)
+var errCodeInUse = errors.New("이미 사용 중인 쿠폰 코드")
+
+// loadIssuableCampaign 영속 저장소의 캠페인 상태/기간 확인. 발급할 수 없으면 실패 사유 (*model.DomainError)
+// 영속 저장소의 발급 수량은 Redis 보다 늦게 따라오므로 정확한 수량 확인은 Lua 스크립트가 담당
+func (r *RedisCouponRepository) loadIssuableCampaign(ctx context.Context, campaignID string) (*coupon.Campaign, error) {
+	pbCampaign, err := r.campaignRepo.GetByID(ctx, campaignID)
+	if errors.Is(err, model.ErrCampaignNotFound) {
+		return nil, model.ErrCampaignNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("캠페인 조회 실패: %w", err)
+	}
+
+	if err := model.NewCampaign(pbCampaign).CanIssueCoupon(r.opts.Clock.Now()); err != nil {
+		return nil, err
+	}
+	return pbCampaign, nil
+}
+
+// issue Lua 스크립트로 발급. sequence 는 outbox 를 거쳐 영속 저장소의 code_sequence 에 반영됨 (-1 이면 반영하지 않음)
+func (r *RedisCouponRepository) issue(
+	ctx context.Context,
+	pbCampaign *coupon.Campaign,
+	userID,
+	couponCode string,
+	sequence int64,
+) (*coupon.Coupon, error) {
+
+	// Redis 를 쓰기 전에 발급된 코드는 Redis 코드 집합에 없으므로 영속 저장소에서도 확인
+	if _, err := r.durable.GetByCode(ctx, couponCode); err == nil {
+		return nil, errCodeInUse
+	}
+
+	campaignID := pbCampaign.CampaignId
+	issuedAt := r.opts.Clock.Now()
+	keys := []string{r.issuedKey(campaignID), r.usersKey(campaignID), r.codesKey(), r.outboxKey(), r.totalKey(campaignID)}
+	args := []any{
+		pbCampaign.TotalQuantity, model.NewCampaign(pbCampaign).EffectiveMaxPerUser(),
+		userID, couponCode, issuedAt.Unix(), campaignID, sequence, issuedAt.UnixMilli(),
+	}
+
+	for attempt := 0; attempt < 2; attempt++ {
+		result, err := issueScript.Run(ctx, r.client, keys, args...).Slice()
+		if err != nil {
+			return nil, fmt.Errorf("Redis 발급 실패: %w", err)
+		}
+
+		switch result[0] {
+		case "ok":
+			return &coupon.Coupon{
+				CouponCode: couponCode,
+				CampaignId: campaignID,
+				IssuedAt:   issuedAt.Unix(),
+				IssuedAtMs: issuedAt.UnixMilli(),
+				IssuedTo:   userID,
+				Status:     coupon.CouponStatus_ISSUED,
+			}, nil
+
+		case "sold_out":
+			r.notifyIssuedChange(campaignID) // 영속 저장소는 아직 ACTIVE 일 수 있음
+			return nil, model.ErrCampaignSoldOut
+
+		case "user_limit":
+			return nil, model.ErrUserLimitExceeded
+
+		case "duplicate":
+			return nil, errCodeInUse
+
+		case "uninitialized":
+			if err := r.seedCampaign(ctx, campaignID, pbCampaign.IssuedQuantity); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return nil, errors.New("Redis 발급 상태 초기화 실패")
+}
+
+// setTotal 총 수량을 total 키에 기록. Redis 로 이미 발급된 수량보다 적으면 기록하지 않고 *model.DomainError
+func (r *RedisCouponRepository) setTotal(ctx context.Context, campaignID string, totalQuantity int32) error {
+	keys := []string{r.issuedKey(campaignID), r.totalKey(campaignID)}
+	issued, err := setTotalScript.Run(ctx, r.client, keys, totalQuantity).Int()
+	if err != nil {
+		return fmt.Errorf("Redis 총 수량 갱신 실패: %w", err)
+	}
+	if issued >= 0 {
+		return model.NewDomainError(coupon.ErrorReason_ERROR_REASON_INVALID_CAMPAIGN_STATE,
+			fmt.Sprintf("발급 수량은 이미 발급된 수량(%d개)보다 적을 수 없습니다", issued))
+	}
+	return nil
+}
+
+// Warm 캠페인의 Redis 발급 상태를 미리 초기화 (시작 직전/직후 호출해 첫 발급 요청들이 초기화를 기다리지 않도록)
+// 이미 초기화된 캠페인은 아무것도 바꾸지 않음
+func (r *RedisCouponRepository) Warm(ctx context.Context, campaign *coupon.Campaign) error {
+	return r.seedCampaign(ctx, campaign.CampaignId, campaign.IssuedQuantity)
+}
+
+// seedCampaign 캠페인의 첫 Redis 발급 전에 영속 저장소의 발급 수량/사용자별 발급 수/코드를 옮김
+func (r *RedisCouponRepository) seedCampaign(ctx context.Context, campaignID string, issuedQuantity int32) error {
+	coupons, err := r.durable.GetByCampaignID(ctx, campaignID)
+	if err != nil {
+		return err
+	}
+
+	userCounts := make(map[string]int32)
+	for _, cp := range coupons {
+		userCounts[cp.IssuedTo]++
+	}
+
+	args := []any{issuedQuantity, len(userCounts)}
+	for userID, count := range userCounts {
+		args = append(args, userID, count)
+	}
+	for _, cp := range coupons {
+		args = append(args, cp.CouponCode)
+	}
+
+	keys := []string{r.issuedKey(campaignID), r.usersKey(campaignID), r.codesKey()}
+	if err := seedScript.Run(ctx, r.client, keys, args...).Err(); err != nil {
+		return fmt.Errorf("Redis 발급 상태 초기화 실패: %w", err)
+	}
+	return nil
+}
+
+// ImportCodePool Redis 로 발급되어 아직 영속 저장되지 않은 코드와 겹치지 않는지 확인한 뒤 영속 저장소의 코드 풀에 등록
+func (r *RedisCouponRepository) ImportCodePool(
+	ctx context.Context,
+	campaignID string,
+	codes []string,
+) (*coupon.Campaign, error) {
+
+	var conflicts []string
+	for start := 0; start < len(codes); start += codeSetBatchSize {
+		batch := codes[start:min(start+codeSetBatchSize, len(codes))]
+
+		members, err := r.client.SMIsMember(ctx, r.codesKey(), toArgs(batch)...).Result()
+		if err != nil {
+			return nil, err
+		}
+		for i, isMember := range members {
+			if isMember && len(conflicts) < maxReportedPoolConflicts {
+				conflicts = append(conflicts, batch[i])
+			}
+		}
+	}
+	if len(conflicts) > 0 {
+		return nil, &CodePoolConflictError{Codes: conflicts}
+	}
+
+	campaign, err := r.durable.ImportCodePool(ctx, campaignID, codes)
+	if err != nil {
+		return nil, err
+	}
+
+	for start := 0; start < len(codes); start += codeSetBatchSize {
+		batch := codes[start:min(start+codeSetBatchSize, len(codes))]
+		if err := r.client.SAdd(ctx, r.codesKey(), toArgs(batch)...).Err(); err != nil {
+			// 코드 풀은 이미 등록됨. 겹치는 Redis 발급은 outbox 저장 시 충돌로 기록됨
+			log.Printf("Redis 코드 집합 갱신 실패. campaign: %s, err: %v", campaignID, err)
+			break
+		}
+	}
+	return campaign, nil
+}
+
+// IssuePooledCoupon 코드 풀이 있는 영속 저장소에서 발급
+func (r *RedisCouponRepository) IssuePooledCoupon(ctx context.Context, campaignID, userID string) (*coupon.Coupon, error) {
+	return r.durable.IssuePooledCoupon(ctx, campaignID, userID)
+}
+
+func toArgs(values []string) []any {
+	args := make([]any, len(values))
+	for i, value := range values {
+		args[i] = value
+	}
+	return args
+}
+
+func (r *RedisCouponRepository) Save(ctx context.Context, cp *coupon.Coupon) error {
+	added, err := r.client.SAdd(ctx, r.codesKey(), cp.CouponCode).Result()
+	if err != nil {
+		return err
+	}
+	if added == 0 {
+		return ErrDuplicateCouponCode
+	}
+	return r.durable.Save(ctx, cp)
+}
+
+func (r *RedisCouponRepository) GetByCampaignID(ctx context.Context, campaignID string) ([]*coupon.Coupon, error) {
+	return r.durable.GetByCampaignID(ctx, campaignID)
+}
+
+func (r *RedisCouponRepository) ListByCampaignID(ctx context.Context, campaignID string, after CouponCursor, limit int) ([]*coupon.Coupon, CouponCursor, bool, error) {
+	return r.durable.ListByCampaignID(ctx, campaignID, after, limit)
+}
+
+func (r *RedisCouponRepository) GetByCode(ctx context.Context, code string) (*coupon.Coupon, error) {
+	return r.durable.GetByCode(ctx, code)
+}
+
+// RedeemCoupon 방금 발급되어 아직 영속 저장되지 않은 쿠폰이면 outbox 를 처리한 뒤 다시 시도
+func (r *RedisCouponRepository) RedeemCoupon(ctx context.Context, couponCode, userID, orderID string) (*coupon.Coupon, error) {
+	if _, err := r.durable.GetByCode(ctx, couponCode); err != nil {
+		if !errors.Is(err, model.ErrCouponNotFound) {
+			return nil, err
+		}
+		isIssued, err := r.client.SIsMember(ctx, r.codesKey(), couponCode).Result()
+		if err != nil {
+			return nil, err
+		}
+		if isIssued {
+			if err := r.Flush(ctx); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return r.durable.RedeemCoupon(ctx, couponCode, userID, orderID)
+}
+
+// RevokeByCampaignID 발급된 쿠폰이 모두 영속 저장된 뒤에 회수
+func (r *RedisCouponRepository) RevokeByCampaignID(ctx context.Context, campaignID string) (int32, error) {
+	if err := r.Flush(ctx); err != nil {
+		return 0, err
+	}
+	return r.durable.RevokeByCampaignID(ctx, campaignID)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////
+// outbox
+
+func (r *RedisCouponRepository) runOutboxWorker() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+			if err := r.Flush(context.Background()); err != nil {
+				log.Printf("outbox 처리 실패 (다음 주기에 재시도): %v", err)
+			}
+		}
+	}
+}
+
+// Flush 호출한 시점까지 outbox 에 추가된 기록을 이 서버가 받았지만 처리하지 못한 기록 → 오래 방치된 다른 서버의 기록 → 새 기록 순서로 처리
+// 처리하는 동안 계속 추가되는 기록은 기다리지 않음 (발급이 몰려도 사용 요청이나 워커가 끝없이 묶이지 않도록)
+func (r *RedisCouponRepository) Flush(ctx context.Context) error {
+	r.outboxMutex.Lock()
+	defer r.outboxMutex.Unlock()
+
+	latest, err := r.client.XRevRangeN(ctx, r.outboxKey(), "+", "-", 1).Result()
+	if err != nil {
+		return err
+	}
+	if len(latest) == 0 {
+		return nil
+	}
+	until := latest[0].ID
+
+	for _, start := range []string{"0", "claim", ">"} {
+		for {
+			messages, err := r.readOutbox(ctx, start)
+			if err != nil {
+				return err
+			}
+
+			// 이후에 추가된 기록은 이 서버의 미처리 기록으로 남아 다음 Flush 에서 처리
+			bounded := messages
+			for i, message := range messages {
+				if compareStreamID(message.ID, until) > 0 {
+					bounded = messages[:i]
+					break
+				}
+			}
+			if len(bounded) > 0 {
+				if err := r.processOutbox(ctx, bounded); err != nil {
+					return err
+				}
+			}
+			if len(messages) == 0 || len(bounded) < len(messages) {
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// compareStreamID Redis stream ID("<밀리초>-<순번>") 비교. a 가 앞이면 음수, 같으면 0, 뒤면 양수
+func compareStreamID(a, b string) int {
+	parse := func(id string) (uint64, uint64) {
+		ms, seq, _ := strings.Cut(id, "-")
+		msValue, _ := strconv.ParseUint(ms, 10, 64)
+		seqValue, _ := strconv.ParseUint(seq, 10, 64)
+		return msValue, seqValue
+	}
+
+	aMs, aSeq := parse(a)
+	bMs, bSeq := parse(b)
+	if c := cmp.Compare(aMs, bMs); c != 0 {
+		return c
+	}
+	return cmp.Compare(aSeq, bSeq)
+}
+
+func (r *RedisCouponRepository) readOutbox(ctx context.Context, start string) ([]redis.XMessage, error) {
+	if start == "claim" {
+		messages, _, err := r.client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+			Stream:   r.outboxKey(),
+			Group:    outboxGroup,
+			MinIdle:  outboxClaimIdle,
+			Start:    "0",
+			Count:    outboxBatchSize,
+			Consumer: r.opts.Consumer,
+		}).Result()
+		return messages, err
+	}
+
+	streams, err := r.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    outboxGroup,
+		Consumer: r.opts.Consumer,
+		Streams:  []string{r.outboxKey(), start},
+		Count:    outboxBatchSize,
+		Block:    -1, // 기다리지 않음
+	}).Result()
+	if errors.Is(err, redis.Nil) || len(streams) == 0 {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return streams[0].Messages, nil
+}
+
+// processOutbox 쿠폰을 저장하고 캠페인별 최대 발급 수량으로 영속 저장소의 캠페인을 갱신한 뒤 ACK
+// 저장에 실패하면 그 이후 기록은 ACK 하지 않고 남겨 두어 다음 처리에서 다시 시도
+func (r *RedisCouponRepository) processOutbox(ctx context.Context, messages []redis.XMessage) error {
+	issuedByCampaign := make(map[string]int32)
+	nextSequenceByCampaign := make(map[string]int64)
+	var acked []string
+	var saveErr error
+
+	for _, message := range messages {
+		cp, issued, sequence, err := parseOutboxMessage(message)
+		if err != nil {
+			log.Printf("잘못된 outbox 기록을 건너뜀. id: %s, err: %v", message.ID, err)
+			acked = append(acked, message.ID)
+			continue
+		}
+
+		if err := r.durable.Save(ctx, cp); errors.Is(err, ErrDuplicateCouponCode) {
+			// 이전 처리에서 저장한 뒤 ACK 전에 중단된 경우는 정상. 다른 쿠폰과 코드가 겹친 경우만 기록
+			if existing, _ := r.durable.GetByCode(ctx, cp.CouponCode); existing == nil || existing.CampaignId != cp.CampaignId || existing.IssuedTo != cp.IssuedTo {
+				log.Printf("outbox 쿠폰 코드가 기존 쿠폰과 충돌합니다. code: %s, campaign: %s", cp.CouponCode, cp.CampaignId)
+			}
+		} else if err != nil {
+			saveErr = err
+			break
+		}
+
+		issuedByCampaign[cp.CampaignId] = max(issuedByCampaign[cp.CampaignId], issued)
+		if sequence >= 0 {
+			nextSequenceByCampaign[cp.CampaignId] = max(nextSequenceByCampaign[cp.CampaignId], sequence+1)
+		}
+		acked = append(acked, message.ID)
+	}
+
+	for campaignID, issued := range issuedByCampaign {
+		nextSequence := nextSequenceByCampaign[campaignID]
+		_, err := r.campaignRepo.Modify(ctx, campaignID, func(c *model.Campaign) error {
+			if c.IssuedQuantity < issued {
+				c.IssuedQuantity = issued
+				c.UpdateStatusIfNeeded(r.opts.Clock.Now())
+			}
+			c.CodeSequence = max(c.CodeSequence, nextSequence) // Redis 순번 키가 사라져도 쓴 순번을 다시 쓰지 않도록
+			return nil
+		})
+		if err != nil {
+			log.Printf("캠페인 발급 수량 갱신 실패. campaign: %s, err: %v", campaignID, err)
+			continue
+		}
+		r.notifyIssuedChange(campaignID)
+	}
+
+	if len(acked) > 0 {
+		if err := r.client.XAck(ctx, r.outboxKey(), outboxGroup, acked...).Err(); err != nil {
+			return err
+		}
+	}
+
+	return saveErr
+}
+
+// parseOutboxMessage 쿠폰, 발급 후 발급 수량, 코드 순번 (순번을 쓰지 않은 발급이면 -1)
+func parseOutboxMessage(message redis.XMessage) (*coupon.Coupon, int32, int64, error) {
+	field := func(name string) string {
+		value, _ := message.Values[name].(string)
+		return value
+	}
+
+	issuedAt, err := strconv.ParseInt(field("issued_at"), 10, 64)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	issued, err := strconv.ParseInt(field("issued"), 10, 32)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	sequence := int64(-1) // 순번 필드가 추가되기 전의 기록
+	if value := field("sequence"); value != "" {
+		if sequence, err = strconv.ParseInt(value, 10, 64); err != nil {
+			return nil, 0, 0, err
+		}
+	}
+	var issuedAtMs int64 // 밀리초 필드가 추가되기 전의 기록이면 초 단위로 계산
+	if value := field("issued_at_ms"); value != "" {
+		if issuedAtMs, err = strconv.ParseInt(value, 10, 64); err != nil {
+			return nil, 0, 0, err
+		}
+	}
+
+	return &coupon.Coupon{
+		CouponCode: field("code"),
+		CampaignId: field("campaign_id"),
+		IssuedAt:   issuedAt,
+		IssuedAtMs: model.Millis(issuedAt, issuedAtMs),
+		IssuedTo:   field("user_id"),
+		Status:     coupon.CouponStatus_ISSUED,
+	}, int32(issued), sequence, nil
+}
+
+// CampaignRepository 캠페인 수정(Modify)이 Redis 발급 수량을 기준으로 동작하는 캠페인 저장소
+// 영속 저장소의 발급 수량은 outbox 가 처리될 때까지 Redis 보다 적으므로, 서비스와 스케줄러에는 이 저장소를 주입해야 함
+func (r *RedisCouponRepository) CampaignRepository() CampaignRepository {
+	return &redisCampaignRepository{CampaignRepository: r.campaignRepo, issuer: r}
+}
+
+type redisCampaignRepository struct {
+	CampaignRepository
+	issuer *RedisCouponRepository
+}
+
+// Modify outbox 처리를 기다리지 않고 Redis 발급 수량을 캠페인에 반영한 뒤 modify 실행
+// 총 수량이 바뀌면 total 키도 함께 갱신하여, 이미 총 수량을 읽고 진행 중인 Redis 발급도 새 총 수량을 넘지 않게 함
+func (r *redisCampaignRepository) Modify(ctx context.Context, id string, modify CampaignModifier) (*coupon.Campaign, error) {
+	totalChanged := false
+	campaign, err := r.CampaignRepository.Modify(ctx, id, func(c *model.Campaign) error {
+		issued, err := r.issuer.client.Get(ctx, r.issuer.issuedKey(id)).Int()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			return fmt.Errorf("Redis 발급 수량 조회 실패: %w", err)
+		}
+		c.IssuedQuantity = max(c.IssuedQuantity, int32(issued)) // outbox 워커와 같은 방식으로 큰 값만 반영
+
+		before := c.TotalQuantity
+		if err := modify(c); err != nil {
+			return err
+		}
+		if c.TotalQuantity == before {
+			return nil
+		}
+
+		totalChanged = true
+		return r.issuer.setTotal(ctx, id, c.TotalQuantity)
+	})
+
+	if err != nil && totalChanged {
+		// 영속 저장소에 반영되지 않은 총 수량은 지우고 요청에 실린 총 수량(영속 저장소 값)을 사용
+		if delErr := r.issuer.client.Del(ctx, r.issuer.totalKey(id)).Err(); delErr != nil {
+			log.Printf("Redis 총 수량 키 삭제 실패. campaign: %s, err: %v", id, delErr)
+		}
+	}
+	return campaign, err
+}
+
+// 컴파일 타임 인터페이스 검증
+var (
+	_ CouponRepository   = (*RedisCouponRepository)(nil)
+	_ CampaignRepository = (*redisCampaignRepository)(nil)
+)
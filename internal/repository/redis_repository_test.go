@@ -0,0 +1,287 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"coupon-issuance-system/gen/coupon"
+	"coupon-issuance-system/internal/clock"
+	"coupon-issuance-system/internal/model"
+	"coupon-issuance-system/internal/repository"
+	"coupon-issuance-system/internal/repository/repositorytest"
+	"github.com/redis/go-redis/v9"
+)
+
+// 통합 테스트는 로컬 redis-server 가 있을 때만 실행
+//
+//	redis-server --port 6379 &
+//	COUPON_TEST_REDIS_ADDR=localhost:6379 go test ./internal/repository/...
+const redisAddrEnv = "COUPON_TEST_REDIS_ADDR"
+
+// newTestRedisRepository 메모리 저장소를 영속 저장소로 사용하는 Redis 발급 저장소 (테스트마다 별도 키 prefix)
+func newTestRedisRepository(t *testing.T) (*repository.MemoryCampaignRepository, *repository.MemoryCouponRepository, *repository.RedisCouponRepository) {
+	t.Helper()
+
+	addr := os.Getenv(redisAddrEnv)
+	if addr == "" {
+		t.Skipf("%s 가 설정되지 않아 Redis 통합 테스트를 건너뜀", redisAddrEnv)
+	}
+
+	client := redis.NewClient(&redis.Options{Addr: addr})
+	t.Cleanup(func() { client.Close() })
+
+	campaignRepo := repository.NewMemoryCampaignRepository(clock.System())
+	durable := repository.NewMemoryCouponRepository(campaignRepo)
+
+	redisRepo, err := repository.NewRedisCouponRepository(context.Background(), client, campaignRepo, durable, repository.RedisIssuerOptions{
+		KeyPrefix: fmt.Sprintf("coupon_test_%d", time.Now().UnixNano()),
+	})
+	if err != nil {
+		t.Fatalf("Redis 발급 저장소 생성 실패: %v", err)
+	}
+	t.Cleanup(func() { redisRepo.Close(context.Background()) })
+
+	return campaignRepo, durable, redisRepo
+}
+
+// flushingCampaignRepository 적합성 테스트는 발급 직후 결과를 확인하므로 조회 전에 outbox 를 모두 반영
+type flushingCampaignRepository struct {
+	repository.CampaignRepository
+	redisRepo *repository.RedisCouponRepository
+}
+
+func (r flushingCampaignRepository) GetByID(ctx context.Context, id string) (*coupon.Campaign, error) {
+	if err := r.redisRepo.Flush(ctx); err != nil {
+		return nil, err
+	}
+	return r.CampaignRepository.GetByID(ctx, id)
+}
+
+type flushingCouponRepository struct {
+	*repository.RedisCouponRepository
+}
+
+func (r flushingCouponRepository) GetByCampaignID(ctx context.Context, campaignID string) ([]*coupon.Coupon, error) {
+	if err := r.Flush(ctx); err != nil {
+		return nil, err
+	}
+	return r.RedisCouponRepository.GetByCampaignID(ctx, campaignID)
+}
+
+func (r flushingCouponRepository) ListByCampaignID(ctx context.Context, campaignID string, after repository.CouponCursor, limit int) ([]*coupon.Coupon, repository.CouponCursor, bool, error) {
+	if err := r.Flush(ctx); err != nil {
+		return nil, after, false, err
+	}
+	return r.RedisCouponRepository.ListByCampaignID(ctx, campaignID, after, limit)
+}
+
+func (r flushingCouponRepository) GetByCode(ctx context.Context, code string) (*coupon.Coupon, error) {
+	if err := r.Flush(ctx); err != nil {
+		return nil, err
+	}
+	return r.RedisCouponRepository.GetByCode(ctx, code)
+}
+
+func TestRedisRepositoryConformance(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) (repository.CampaignRepository, repository.CouponRepository) {
+		campaignRepo, _, redisRepo := newTestRedisRepository(t)
+		return flushingCampaignRepository{campaignRepo, redisRepo}, flushingCouponRepository{redisRepo}
+	})
+}
+
+// Redis 발급 전에 영속 저장소에 있던 발급 기록도 사용자당 한도와 수량에 반영되어야 함
+func TestRedisSeedsFromDurableState(t *testing.T) {
+	campaignRepo, durable, redisRepo := newTestRedisRepository(t)
+	ctx := context.Background()
+
+	campaignRepo.Save(ctx, &coupon.Campaign{
+		CampaignId:    "redis-seed",
+		TotalQuantity: 3,
+		Status:        coupon.CampaignStatus_ACTIVE,
+		StartTime:     time.Now().Unix() - 1,
+	})
+	for i := 0; i < 2; i++ {
+		if _, err := durable.IssueCoupon(ctx, "redis-seed", fmt.Sprintf("user-%d", i), fmt.Sprintf("SEED%d", i)); err != nil {
+			t.Fatalf("영속 저장소 발급 실패: %v", err)
+		}
+	}
+
+	if issued, _ := redisRepo.IssueCoupon(ctx, "redis-seed", "user-0", "SEEDX"); issued != nil {
+		t.Fatal("영속 저장소에서 이미 발급받은 사용자에게 다시 발급됨")
+	}
+	if issued, err := redisRepo.IssueCoupon(ctx, "redis-seed", "user-2", "SEED2"); issued == nil {
+		t.Fatalf("남은 1개 발급 실패: %v", err)
+	}
+	if issued, _ := redisRepo.IssueCoupon(ctx, "redis-seed", "user-3", "SEED3"); issued != nil {
+		t.Fatal("초과 발급됨")
+	}
+
+	// 발급 직후 사용 요청도 outbox 를 먼저 반영해서 처리
+	if _, err := redisRepo.RedeemCoupon(ctx, "SEED2", "user-2", "order-1"); err != nil {
+		t.Fatalf("발급 직후 사용 실패: %v", err)
+	}
+
+	campaign, _ := campaignRepo.GetByID(ctx, "redis-seed")
+	if campaign.IssuedQuantity != 3 || campaign.Status != coupon.CampaignStatus_COMPLETED {
+		t.Fatalf("영속 저장소 캠페인이 따라잡지 못함: %v", campaign)
+	}
+}
+
+// outbox 가 처리되기 전이라도 총 수량은 Redis 로 이미 발급된 수량보다 줄일 수 없음
+func TestRedisCampaignQuantityFollowsRedisIssued(t *testing.T) {
+	campaignRepo, _, redisRepo := newTestRedisRepository(t)
+	managedRepo := redisRepo.CampaignRepository()
+	ctx := context.Background()
+
+	campaignRepo.Save(ctx, &coupon.Campaign{
+		CampaignId:    "redis-quantity",
+		TotalQuantity: 5,
+		Status:        coupon.CampaignStatus_ACTIVE,
+		StartTime:     time.Now().Unix() - 1,
+		Version:       1,
+	})
+	for i := 0; i < 3; i++ {
+		if _, err := redisRepo.IssueCoupon(ctx, "redis-quantity", fmt.Sprintf("user-%d", i), fmt.Sprintf("QTY%d", i)); err != nil {
+			t.Fatalf("발급 실패: %v", err)
+		}
+	}
+
+	changeQuantity := func(quantity int32) (*coupon.Campaign, error) {
+		return managedRepo.Modify(ctx, "redis-quantity", func(c *model.Campaign) error {
+			return c.ApplyChanges(c.Version, model.CampaignChanges{TotalQuantity: &quantity}, time.Now())
+		})
+	}
+
+	if _, err := changeQuantity(2); !errors.Is(err, model.NewDomainError(coupon.ErrorReason_ERROR_REASON_INVALID_CAMPAIGN_STATE, "")) {
+		t.Fatalf("발급된 수량보다 적은 총 수량으로 변경됨: %v", err)
+	}
+
+	campaign, err := changeQuantity(3)
+	if err != nil || campaign.IssuedQuantity != 3 || campaign.Status != coupon.CampaignStatus_COMPLETED {
+		t.Fatalf("총 수량 변경 결과가 다름: %v, %v", campaign, err)
+	}
+
+	if _, err := redisRepo.IssueCoupon(ctx, "redis-quantity", "user-9", "QTY9"); !errors.Is(err, model.ErrCampaignSoldOut) {
+		t.Errorf("줄인 총 수량을 넘어 발급됨: %v", err)
+	}
+}
+
+// 소진을 확인하거나 outbox 로 발급 수량을 반영하면 등록한 함수로 캠페인을 알림
+func TestRedisNotifiesIssuedChange(t *testing.T) {
+	campaignRepo, _, redisRepo := newTestRedisRepository(t)
+	ctx := context.Background()
+
+	notified := make(chan string, 10)
+	redisRepo.OnIssuedChange(func(campaignID string) {
+		select {
+		case notified <- campaignID:
+		default:
+		}
+	})
+
+	campaignRepo.Save(ctx, &coupon.Campaign{
+		CampaignId:    "redis-notify",
+		TotalQuantity: 1,
+		Status:        coupon.CampaignStatus_ACTIVE,
+		StartTime:     time.Now().Unix() - 1,
+	})
+	if _, err := redisRepo.IssueCoupon(ctx, "redis-notify", "user-1", "NOTIFY1"); err != nil {
+		t.Fatalf("발급 실패: %v", err)
+	}
+
+	select {
+	case campaignID := <-notified:
+		if campaignID != "redis-notify" {
+			t.Errorf("다른 캠페인이 알려짐: %s", campaignID)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("outbox 반영 후 알림이 오지 않음")
+	}
+
+	// 영속 저장소가 아직 따라잡지 못했어도 Redis 에서 소진을 확인하면 알림
+	redisRepo.Flush(ctx)
+	for len(notified) > 0 {
+		<-notified
+	}
+	campaignRepo.Modify(ctx, "redis-notify", func(c *model.Campaign) error {
+		c.IssuedQuantity, c.Status = 0, coupon.CampaignStatus_ACTIVE
+		return nil
+	})
+	if _, err := redisRepo.IssueCoupon(ctx, "redis-notify", "user-2", "NOTIFY2"); !errors.Is(err, model.ErrCampaignSoldOut) {
+		t.Fatalf("소진된 캠페인에 발급됨: %v", err)
+	}
+	select {
+	case <-notified:
+	default:
+		t.Error("Redis 에서 소진을 확인했지만 알림이 오지 않음")
+	}
+}
+
+// issuingCouponRepository outbox 를 처리하는 동안 발급이 계속 들어오는 상황: 쿠폰을 저장할 때마다 새 쿠폰을 발급
+type issuingCouponRepository struct {
+	repository.CouponRepository
+	redisRepo *repository.RedisCouponRepository
+	issued    int
+}
+
+func (r *issuingCouponRepository) Save(ctx context.Context, cp *coupon.Coupon) error {
+	if err := r.CouponRepository.Save(ctx, cp); err != nil {
+		return err
+	}
+	r.issued++
+	_, err := r.redisRepo.IssueCoupon(ctx, cp.CampaignId, fmt.Sprintf("user-%d", r.issued), fmt.Sprintf("BUSY%d", r.issued))
+	return err
+}
+
+// 발급 직후 사용 요청은 요청 시점까지 쌓인 outbox 만 처리하고, 처리 중에 추가된 기록을 기다리지 않음
+func TestRedisRedeemFlushIsBounded(t *testing.T) {
+	addr := os.Getenv(redisAddrEnv)
+	if addr == "" {
+		t.Skipf("%s 가 설정되지 않아 Redis 통합 테스트를 건너뜀", redisAddrEnv)
+	}
+
+	client := redis.NewClient(&redis.Options{Addr: addr})
+	t.Cleanup(func() { client.Close() })
+
+	campaignRepo := repository.NewMemoryCampaignRepository(clock.System())
+	durable := &issuingCouponRepository{CouponRepository: repository.NewMemoryCouponRepository(campaignRepo)}
+	redisRepo, err := repository.NewRedisCouponRepository(context.Background(), client, campaignRepo, durable, repository.RedisIssuerOptions{
+		KeyPrefix:    fmt.Sprintf("coupon_test_%d", time.Now().UnixNano()),
+		PollInterval: time.Hour, // 워커가 끼어들지 않도록
+	})
+	if err != nil {
+		t.Fatalf("Redis 발급 저장소 생성 실패: %v", err)
+	}
+	durable.redisRepo = redisRepo
+	t.Cleanup(func() { redisRepo.Close(context.Background()) })
+	ctx := context.Background()
+
+	campaignRepo.Save(ctx, &coupon.Campaign{
+		CampaignId:    "redis-busy",
+		TotalQuantity: 50,
+		Status:        coupon.CampaignStatus_ACTIVE,
+		StartTime:     time.Now().Unix() - 1,
+	})
+	if _, err := redisRepo.IssueCoupon(ctx, "redis-busy", "user-0", "BUSY0"); err != nil {
+		t.Fatalf("발급 실패: %v", err)
+	}
+
+	if _, err := redisRepo.RedeemCoupon(ctx, "BUSY0", "user-0", "order-1"); err != nil {
+		t.Fatalf("발급 직후 사용 실패: %v", err)
+	}
+	if coupons, _ := durable.GetByCampaignID(ctx, "redis-busy"); len(coupons) != 1 {
+		t.Errorf("사용 요청 이후에 추가된 outbox 까지 처리함: 쿠폰 %d개", len(coupons))
+	}
+
+	// 남은 기록은 다음 처리에서 이어서 저장
+	if err := redisRepo.Flush(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if coupons, _ := durable.GetByCampaignID(ctx, "redis-busy"); len(coupons) != 2 {
+		t.Errorf("다음 처리에서 남은 기록을 저장하지 않음: 쿠폰 %d개", len(coupons))
+	}
+}
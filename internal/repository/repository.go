@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"coupon-issuance-system/gen/coupon"
+	"coupon-issuance-system/internal/model"
+)
+
+// ErrDuplicateCouponCode 이미 다른 쿠폰이 사용 중인 코드로 발급을 시도함
+var ErrDuplicateCouponCode = errors.New("이미 사용 중인 쿠폰 코드입니다")
+
+// errCodePoolExhausted 코드 풀에 발급할 코드가 남아 있지 않음 (IssuePooledCoupon 에서 model.ErrCampaignSoldOut 으로 바뀜)
+var errCodePoolExhausted = errors.New("코드 풀에 남은 코드가 없습니다")
+
+// maxReportedPoolConflicts CodePoolConflictError 에 담는 최대 코드 수
+const maxReportedPoolConflicts = 100
+
+// CodePoolConflictError 코드 풀에 등록하려는 코드 중 이미 발급되었거나 다른 코드 풀에 있는 코드 (최대 100개)
+// errors.Is(err, ErrDuplicateCouponCode) 로도 확인할 수 있음
+type CodePoolConflictError struct {
+	Codes []string
+}
+
+func (e *CodePoolConflictError) Error() string {
+	return fmt.Sprintf("이미 사용 중인 쿠폰 코드가 있습니다: %s", strings.Join(e.Codes, ", "))
+}
+
+func (e *CodePoolConflictError) Is(target error) bool {
+	return target == ErrDuplicateCouponCode
+}
+
+// CampaignModifier 캠페인 락을 잡은 상태에서 실행되는 상태 전이/수정 함수
+// 실패 시에는 캠페인을 변경하지 않고 오류(보통 *model.DomainError)를 반환해야 하며, 오류는 그대로 호출자에게 전달됨
+type CampaignModifier func(campaign *model.Campaign) error
+
+// SequencedCode 캠페인 코드 순번으로 쿠폰 코드를 만드는 함수. 같은 순번에는 항상 같은 코드를 반환해야 함
+type SequencedCode func(sequence int64) (string, error)
+
+// maxSequenceSkips 순번으로 만든 코드가 다른 캠페인의 코드와 겹칠 때 다음 순번으로 넘어가는 최대 횟수
+const maxSequenceSkips = 8
+
+// CampaignRepository 캠페인 저장소
+type CampaignRepository interface {
+	Save(ctx context.Context, campaign *coupon.Campaign) error
+	// GetByID 캠페인이 없으면 model.ErrCampaignNotFound 를 감싼 오류
+	GetByID(ctx context.Context, id string) (*coupon.Campaign, error)
+	List(ctx context.Context, filter CampaignFilter, after *CampaignCursor, limit int) ([]*coupon.Campaign, bool, error)
+	// Update, Delete 캠페인이 없으면 model.ErrCampaignNotFound 를 감싼 오류
+	Update(ctx context.Context, campaign *coupon.Campaign) error
+	Delete(ctx context.Context, id string) error
+
+	// Modify 캠페인 단위 락(쿠폰 발급과 같은 락) 안에서 modify 를 실행하고 변경된 캠페인을 반환
+	// 캠페인이 없으면 model.ErrCampaignNotFound, modify 가 실패하면 modify 의 오류를 반환
+	Modify(ctx context.Context, id string, modify CampaignModifier) (*coupon.Campaign, error)
+}
+
+// CouponRepository 쿠폰 저장소
+// 발급/사용 조건을 만족하지 않는 요청(캠페인 없음, 소진, 이미 사용된 쿠폰 등)은 *model.DomainError 로,
+// 그 밖의 오류는 저장소 오류로 반환함 (model.IsDomainError 로 구분)
+type CouponRepository interface {
+	Save(ctx context.Context, coupon *coupon.Coupon) error
+	GetByCampaignID(ctx context.Context, campaignID string) ([]*coupon.Coupon, error)
+	// ListByCampaignID 캠페인의 쿠폰을 발급 순서대로 after 다음 위치부터 최대 limit 개 반환
+	// next 는 마지막으로 반환한 쿠폰의 위치(없으면 after)로, 다음 페이지를 조회할 때 after 로 넘김
+	ListByCampaignID(ctx context.Context, campaignID string, after CouponCursor, limit int) (coupons []*coupon.Coupon, next CouponCursor, hasMore bool, err error)
+	// GetByCode 쿠폰이 없으면 model.ErrCouponNotFound 를 감싼 오류
+	GetByCode(ctx context.Context, code string) (*coupon.Coupon, error)
+
+	// IssueCoupon 원자적 쿠폰 발급
+	// 수량/상태/사용자당 한도 확인, 발급 수량 증가, 쿠폰 저장이 하나의 원자적 단위로 처리되어야 함
+	//   - 발급 조건 불충족: nil, *model.DomainError
+	//   - 코드 중복: nil, ErrDuplicateCouponCode (발급 수량은 변하지 않음)
+	IssueCoupon(ctx context.Context, campaignID, userID, couponCode string) (*coupon.Coupon, error)
+
+	// IssueSequencedCoupon IssueCoupon 과 같지만 코드를 캠페인의 코드 순번(Campaign.code_sequence)으로 만듦
+	// 순번은 발급과 같은 원자적 단위에서 증가하므로 codeFor 가 순번마다 다른 코드를 만들면 캠페인 안에서는 중복 확인이 필요 없음
+	// 다른 캠페인의 코드와 겹치면 그 순번은 건너뛰고 다음 순번으로 다시 시도 (maxSequenceSkips 회를 넘으면 ErrDuplicateCouponCode)
+	IssueSequencedCoupon(ctx context.Context, campaignID, userID string, codeFor SequencedCode) (*coupon.Coupon, error)
+
+	// ImportCodePool 캠페인 코드 풀에 codes 를 등록 순서대로 추가하고, 추가한 수만큼 total_quantity 를 늘린 캠페인을 반환
+	// 이미 발급되었거나 다른 캠페인의 코드 풀에 있는 코드가 하나라도 있으면 아무것도 추가하지 않고 *CodePoolConflictError
+	// 풀에 등록된 코드는 발급 전에도 전체 캠페인 공통 유일성 검사에 포함됨 (다른 발급 경로가 같은 코드를 쓸 수 없음)
+	ImportCodePool(ctx context.Context, campaignID string, codes []string) (*coupon.Campaign, error)
+
+	// IssuePooledCoupon IssueCoupon 과 같지만 코드를 캠페인 코드 풀에서 등록 순서대로 꺼냄
+	// 코드를 꺼내는 것과 발급이 하나의 원자적 단위이므로 같은 코드가 두 번 발급되지 않음
+	IssuePooledCoupon(ctx context.Context, campaignID, userID string) (*coupon.Coupon, error)
+
+	// RedeemCoupon 쿠폰 사용 처리. 같은 쿠폰의 동시 사용 요청 중 하나만 성공해야 함
+	RedeemCoupon(ctx context.Context, couponCode, userID, orderID string) (*coupon.Coupon, error)
+
+	// RevokeByCampaignID 캠페인의 사용되지 않은 쿠폰을 모두 회수하고 회수된 수를 반환
+	RevokeByCampaignID(ctx context.Context, campaignID string) (int32, error)
+}
@@ -0,0 +1,606 @@
+// Package repositorytest 저장소 구현체가 공통으로 통과해야 하는 적합성(conformance) 테스트
+//
+// 새 저장소 구현체는 자신의 _test.go 에서 Run 을 호출하기만 하면 됨
+//
+//	func TestConformance(t *testing.T) {
+//		repositorytest.Run(t, func(t *testing.T) (repository.CampaignRepository, repository.CouponRepository) {
+//			return newMyRepositories(t)
+//		})
+//	}
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"coupon-issuance-system/gen/coupon"
+	"coupon-issuance-system/internal/model"
+	"coupon-issuance-system/internal/repository"
+)
+
+// Factory 서브테스트마다 호출되어 저장소 쌍을 생성
+// 저장소가 비어있을 필요는 없지만, 테스트가 만든 캠페인/쿠폰 외의 데이터와 코드가 겹치지 않아야 함
+type Factory func(t *testing.T) (repository.CampaignRepository, repository.CouponRepository)
+
+// Run 모든 적합성 테스트 실행
+func Run(t *testing.T, newRepos Factory) {
+	t.Run("SaveAndGetCampaign", func(t *testing.T) { testSaveAndGetCampaign(t, newRepos) })
+	t.Run("NoOversell", func(t *testing.T) { testNoOversell(t, newRepos) })
+	t.Run("MaxPerUser", func(t *testing.T) { testMaxPerUser(t, newRepos) })
+	t.Run("UniqueCodeAcrossCampaigns", func(t *testing.T) { testUniqueCodeAcrossCampaigns(t, newRepos) })
+	t.Run("ConcurrentSameCode", func(t *testing.T) { testConcurrentSameCode(t, newRepos) })
+	t.Run("RedeemOnce", func(t *testing.T) { testRedeemOnce(t, newRepos) })
+	t.Run("ModifyBlocksIssue", func(t *testing.T) { testModifyBlocksIssue(t, newRepos) })
+	t.Run("ParallelCampaigns", func(t *testing.T) { testParallelCampaigns(t, newRepos) })
+	t.Run("SequencedCodes", func(t *testing.T) { testSequencedCodes(t, newRepos) })
+	t.Run("SequencedCodeSkipsTakenCode", func(t *testing.T) { testSequencedCodeSkipsTakenCode(t, newRepos) })
+	t.Run("CodePool", func(t *testing.T) { testCodePool(t, newRepos) })
+	t.Run("ListCouponsWhileIssuing", func(t *testing.T) { testListCouponsWhileIssuing(t, newRepos) })
+}
+
+var sequence atomic.Int64
+
+// uniqueID 여러 테스트/실행 간에 겹치지 않는 ID 생성 (영속 저장소에서도 재실행 가능하도록)
+func uniqueID(prefix string) string {
+	return fmt.Sprintf("%s_%d_%d", prefix, time.Now().UnixNano(), sequence.Add(1))
+}
+
+func saveActiveCampaign(t *testing.T, repo repository.CampaignRepository, quantity, maxPerUser int32) string {
+	t.Helper()
+
+	campaignID := uniqueID("conf")
+	err := repo.Save(context.Background(), &coupon.Campaign{
+		CampaignId:    campaignID,
+		Name:          "적합성",
+		StartTime:     time.Now().Unix() - 1,
+		TotalQuantity: quantity,
+		Status:        coupon.CampaignStatus_ACTIVE,
+		CreatedAt:     time.Now().Unix(),
+		MaxPerUser:    maxPerUser,
+		Version:       1,
+	})
+	if err != nil {
+		t.Fatalf("캠페인 저장 실패: %v", err)
+	}
+
+	return campaignID
+}
+
+func testSaveAndGetCampaign(t *testing.T, newRepos Factory) {
+	campaignRepo, _ := newRepos(t)
+	ctx := context.Background()
+
+	campaignID := saveActiveCampaign(t, campaignRepo, 3, 1)
+
+	saved, err := campaignRepo.GetByID(ctx, campaignID)
+	if err != nil || saved.Name != "적합성" || saved.TotalQuantity != 3 {
+		t.Fatalf("캠페인 저장/조회 실패: %v, %v", saved, err)
+	}
+
+	if _, err := campaignRepo.GetByID(ctx, uniqueID("missing")); !errors.Is(err, model.ErrCampaignNotFound) {
+		t.Errorf("존재하지 않는 캠페인 조회 결과가 다름: %v", err)
+	}
+	if err := campaignRepo.Update(ctx, &coupon.Campaign{CampaignId: uniqueID("missing")}); !errors.Is(err, model.ErrCampaignNotFound) {
+		t.Errorf("존재하지 않는 캠페인 수정 결과가 다름: %v", err)
+	}
+	if err := campaignRepo.Delete(ctx, uniqueID("missing")); !errors.Is(err, model.ErrCampaignNotFound) {
+		t.Errorf("존재하지 않는 캠페인 삭제 결과가 다름: %v", err)
+	}
+}
+
+// 수량보다 많은 동시 요청에서도 정확히 수량만큼만 발급되고 카운터와 쿠폰 수가 일치
+func testNoOversell(t *testing.T, newRepos Factory) {
+	campaignRepo, couponRepo := newRepos(t)
+	ctx := context.Background()
+
+	const quantity = 10
+	const numRequests = 100
+	campaignID := saveActiveCampaign(t, campaignRepo, quantity, 1)
+
+	var wg sync.WaitGroup
+	var successCount atomic.Int32
+	codePrefix := uniqueID("C")
+
+	for i := 0; i < numRequests; i++ {
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+
+			issued, err := couponRepo.IssueCoupon(ctx, campaignID, fmt.Sprintf("user-%d", index), fmt.Sprintf("%s-%d", codePrefix, index))
+			if err != nil && !errors.Is(err, model.ErrCampaignSoldOut) {
+				t.Errorf("발급 중 오류: %v", err)
+			}
+			if issued != nil {
+				successCount.Add(1)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if successCount.Load() != quantity {
+		t.Errorf("예상: %d개 성공, 실제: %d개", quantity, successCount.Load())
+	}
+
+	campaign, _ := campaignRepo.GetByID(ctx, campaignID)
+	if campaign.IssuedQuantity != quantity {
+		t.Errorf("캠페인 발급 수량이 잘못됨: %d", campaign.IssuedQuantity)
+	}
+	if campaign.Status != coupon.CampaignStatus_COMPLETED {
+		t.Errorf("소진된 캠페인 상태가 COMPLETED 가 아님: %s", campaign.Status)
+	}
+
+	coupons, _ := couponRepo.GetByCampaignID(ctx, campaignID)
+	if len(coupons) != quantity {
+		t.Errorf("저장된 쿠폰 수 불일치: %d", len(coupons))
+	}
+
+	seen := make(map[string]bool)
+	for _, cp := range coupons {
+		if seen[cp.CouponCode] {
+			t.Errorf("중복 쿠폰 코드: %s", cp.CouponCode)
+		}
+		seen[cp.CouponCode] = true
+	}
+}
+
+// 발급이 진행되는 동안 커서로 페이지를 넘겨도 모든 쿠폰을 한 번씩 조회
+func testListCouponsWhileIssuing(t *testing.T, newRepos Factory) {
+	campaignRepo, couponRepo := newRepos(t)
+	ctx := context.Background()
+
+	const quantity = 60
+	campaignID := saveActiveCampaign(t, campaignRepo, quantity, 1)
+	codePrefix := uniqueID("L")
+
+	var wg sync.WaitGroup
+	for i := 0; i < quantity; i++ {
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+			if _, err := couponRepo.IssueCoupon(ctx, campaignID, fmt.Sprintf("user-%d", index), fmt.Sprintf("%s-%d", codePrefix, index)); err != nil {
+				t.Errorf("발급 중 오류: %v", err)
+			}
+		}(i)
+	}
+	issuing := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(issuing)
+	}()
+
+	seen := make(map[string]int)
+	var after repository.CouponCursor
+	deadline := time.Now().Add(10 * time.Second)
+	for len(seen) < quantity && time.Now().Before(deadline) {
+		coupons, next, _, err := couponRepo.ListByCampaignID(ctx, campaignID, after, 4)
+		if err != nil {
+			t.Fatalf("쿠폰 목록 조회 실패: %v", err)
+		}
+		for _, cp := range coupons {
+			seen[cp.CouponCode]++
+		}
+		after = next
+	}
+	<-issuing
+
+	if coupons, _, hasMore, _ := couponRepo.ListByCampaignID(ctx, campaignID, after, 4); len(coupons) > 0 || hasMore {
+		t.Errorf("모두 조회한 뒤에도 쿠폰이 남아 있음: %d개", len(coupons))
+	}
+	if len(seen) != quantity {
+		t.Errorf("조회된 쿠폰 수 불일치: %d", len(seen))
+	}
+	for code, count := range seen {
+		if count != 1 {
+			t.Errorf("같은 쿠폰이 %d번 조회됨: %s", count, code)
+		}
+	}
+}
+
+// 같은 사용자의 동시 요청은 사용자당 한도만큼만 발급
+func testMaxPerUser(t *testing.T, newRepos Factory) {
+	campaignRepo, couponRepo := newRepos(t)
+	ctx := context.Background()
+
+	campaignID := saveActiveCampaign(t, campaignRepo, 100, 2)
+
+	var wg sync.WaitGroup
+	var successCount atomic.Int32
+	codePrefix := uniqueID("U")
+
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+
+			issued, _ := couponRepo.IssueCoupon(ctx, campaignID, "same-user", fmt.Sprintf("%s-%d", codePrefix, index))
+			if issued != nil {
+				successCount.Add(1)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if successCount.Load() != 2 {
+		t.Errorf("예상: 2개 성공, 실제: %d개", successCount.Load())
+	}
+}
+
+// 이미 발급된 코드는 다른 캠페인에서도 발급되지 않고, 실패한 발급은 수량을 소모하지 않음
+func testUniqueCodeAcrossCampaigns(t *testing.T, newRepos Factory) {
+	campaignRepo, couponRepo := newRepos(t)
+	ctx := context.Background()
+
+	first := saveActiveCampaign(t, campaignRepo, 5, 1)
+	second := saveActiveCampaign(t, campaignRepo, 5, 1)
+	code := uniqueID("DUP")
+
+	if issued, err := couponRepo.IssueCoupon(ctx, first, "user-1", code); issued == nil {
+		t.Fatalf("첫 발급 실패: %v", err)
+	}
+
+	issued, err := couponRepo.IssueCoupon(ctx, second, "user-2", code)
+	if issued != nil || !errors.Is(err, repository.ErrDuplicateCouponCode) {
+		t.Fatalf("중복 코드 발급이 거절되지 않음: %v, %v", issued, err)
+	}
+
+	campaign, _ := campaignRepo.GetByID(ctx, second)
+	if campaign.IssuedQuantity != 0 {
+		t.Errorf("중복 코드로 실패한 발급이 수량을 소모함: %d", campaign.IssuedQuantity)
+	}
+
+	saved, err := couponRepo.GetByCode(ctx, code)
+	if err != nil || saved.CampaignId != first || saved.IssuedTo != "user-1" {
+		t.Errorf("기존 쿠폰이 덮어써짐: %v, %v", saved, err)
+	}
+}
+
+// 서로 다른 캠페인에서 같은 코드로 동시에 발급해도 하나만 성공
+func testConcurrentSameCode(t *testing.T, newRepos Factory) {
+	campaignRepo, couponRepo := newRepos(t)
+	ctx := context.Background()
+
+	const numCampaigns = 10
+	campaignIDs := make([]string, numCampaigns)
+	for i := range campaignIDs {
+		campaignIDs[i] = saveActiveCampaign(t, campaignRepo, 1, 1)
+	}
+	code := uniqueID("RACE")
+
+	var wg sync.WaitGroup
+	var successCount atomic.Int32
+
+	for i, campaignID := range campaignIDs {
+		wg.Add(1)
+		go func(index int, campaignID string) {
+			defer wg.Done()
+
+			issued, _ := couponRepo.IssueCoupon(ctx, campaignID, fmt.Sprintf("user-%d", index), code)
+			if issued != nil {
+				successCount.Add(1)
+			}
+		}(i, campaignID)
+	}
+	wg.Wait()
+
+	if successCount.Load() != 1 {
+		t.Errorf("예상: 1개 성공, 실제: %d개", successCount.Load())
+	}
+
+	var totalIssued int32
+	for _, campaignID := range campaignIDs {
+		campaign, _ := campaignRepo.GetByID(ctx, campaignID)
+		totalIssued += campaign.IssuedQuantity
+	}
+	if totalIssued != 1 {
+		t.Errorf("캠페인 발급 수량 합계가 1 이 아님: %d", totalIssued)
+	}
+}
+
+// 같은 쿠폰의 동시 사용 요청 중 하나만 성공
+func testRedeemOnce(t *testing.T, newRepos Factory) {
+	campaignRepo, couponRepo := newRepos(t)
+	ctx := context.Background()
+
+	campaignID := saveActiveCampaign(t, campaignRepo, 1, 1)
+	code := uniqueID("R")
+	if issued, err := couponRepo.IssueCoupon(ctx, campaignID, "user-1", code); issued == nil {
+		t.Fatalf("발급 실패: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	var successCount atomic.Int32
+
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+
+			redeemed, _ := couponRepo.RedeemCoupon(ctx, code, "user-1", fmt.Sprintf("order-%d", index))
+			if redeemed != nil {
+				successCount.Add(1)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if successCount.Load() != 1 {
+		t.Errorf("예상: 1번 사용 성공, 실제: %d번", successCount.Load())
+	}
+}
+
+// Modify 로 일시 중지한 이후에는 발급이 거절되고 수량이 변하지 않음
+func testModifyBlocksIssue(t *testing.T, newRepos Factory) {
+	campaignRepo, couponRepo := newRepos(t)
+	ctx := context.Background()
+
+	campaignID := saveActiveCampaign(t, campaignRepo, 10, 1)
+
+	paused, err := campaignRepo.Modify(ctx, campaignID, func(c *model.Campaign) error {
+		return c.Pause(time.Now())
+	})
+	if paused == nil || err != nil {
+		t.Fatalf("일시 중지 실패: %v", err)
+	}
+
+	issued, err := couponRepo.IssueCoupon(ctx, campaignID, "user-1", uniqueID("P"))
+	if issued != nil || !errors.Is(err, model.ErrCampaignPaused) {
+		t.Errorf("일시 중지된 캠페인에서 발급됨: %v, %v", issued, err)
+	}
+
+	if missing, err := campaignRepo.Modify(ctx, uniqueID("missing"), func(c *model.Campaign) error {
+		return c.Pause(time.Now())
+	}); missing != nil || !errors.Is(err, model.ErrCampaignNotFound) {
+		t.Errorf("존재하지 않는 캠페인 수정 결과가 다름: %v, %v", missing, err)
+	}
+	if _, err := campaignRepo.GetByID(ctx, uniqueID("missing")); !errors.Is(err, model.ErrCampaignNotFound) {
+		t.Errorf("존재하지 않는 캠페인 조회 오류가 다름: %v", err)
+	}
+}
+
+// 여러 캠페인에 동시에 발급/조회/사용/상태 전이를 섞어서 요청해도 캠페인마다 수량과 쿠폰 수가 정확해야 함
+// -race 로 실행하면 저장소 내부와 반환값의 데이터 경쟁까지 검증
+func testParallelCampaigns(t *testing.T, newRepos Factory) {
+	campaignRepo, couponRepo := newRepos(t)
+	ctx := context.Background()
+
+	const numCampaigns = 16
+	const quantity = 20
+	const requestsPerCampaign = 40
+
+	campaignIDs := make([]string, numCampaigns)
+	for i := range campaignIDs {
+		campaignIDs[i] = saveActiveCampaign(t, campaignRepo, quantity, 1)
+	}
+	codePrefix := uniqueID("PAR")
+
+	var wg sync.WaitGroup
+	successCounts := make([]atomic.Int32, numCampaigns)
+
+	for c, campaignID := range campaignIDs {
+		for i := 0; i < requestsPerCampaign; i++ {
+			wg.Add(1)
+			go func(c, index int, campaignID string) {
+				defer wg.Done()
+
+				userID := fmt.Sprintf("user-%d", index)
+				code := fmt.Sprintf("%s-%d-%d", codePrefix, c, index)
+
+				issued, err := couponRepo.IssueCoupon(ctx, campaignID, userID, code)
+				if err != nil {
+					if !errors.Is(err, model.ErrCampaignSoldOut) {
+						t.Errorf("발급 중 오류: %v", err)
+					}
+					return
+				}
+				successCounts[c].Add(1)
+
+				// 반환된 값과 저장소 내부 상태를 동시에 읽고 쓰기
+				_ = issued.CouponCode + issued.Status.String()
+				if index%3 == 0 {
+					couponRepo.RedeemCoupon(ctx, code, userID, "order-"+code)
+				}
+				if found, err := couponRepo.GetByCode(ctx, code); err == nil {
+					_ = found.Status.String()
+				}
+			}(c, i, campaignID)
+		}
+
+		// 발급과 동시에 조회/상태 전이
+		wg.Add(1)
+		go func(campaignID string) {
+			defer wg.Done()
+
+			for i := 0; i < 10; i++ {
+				if campaign, err := campaignRepo.GetByID(ctx, campaignID); err == nil {
+					_ = campaign.IssuedQuantity
+				}
+				couponRepo.ListByCampaignID(ctx, campaignID, 0, 5)
+				campaignRepo.List(ctx, repository.CampaignFilter{NameContains: "적합성"}, nil, 5)
+				campaignRepo.Modify(ctx, campaignID, func(c *model.Campaign) error {
+					c.UpdateStatusIfNeeded(time.Now())
+					return nil
+				})
+			}
+		}(campaignID)
+	}
+	wg.Wait()
+
+	seen := make(map[string]bool)
+	for c, campaignID := range campaignIDs {
+		if successCounts[c].Load() != quantity {
+			t.Errorf("캠페인 %d: 예상 %d개 발급, 실제 %d개", c, quantity, successCounts[c].Load())
+		}
+
+		campaign, err := campaignRepo.GetByID(ctx, campaignID)
+		if err != nil || campaign.IssuedQuantity != quantity {
+			t.Errorf("캠페인 %d 발급 수량 불일치: %v, %v", c, campaign, err)
+		}
+
+		coupons, _ := couponRepo.GetByCampaignID(ctx, campaignID)
+		if len(coupons) != quantity {
+			t.Errorf("캠페인 %d 저장된 쿠폰 수 불일치: %d", c, len(coupons))
+		}
+		for _, cp := range coupons {
+			if seen[cp.CouponCode] || cp.CampaignId != campaignID {
+				t.Errorf("쿠폰 코드 중복 또는 캠페인 불일치: %s", cp.CouponCode)
+			}
+			seen[cp.CouponCode] = true
+		}
+	}
+}
+
+// 동시에 순번 기반으로 발급해도 순번(= 코드)이 겹치지 않고 수량만큼만 발급
+func testSequencedCodes(t *testing.T, newRepos Factory) {
+	campaignRepo, couponRepo := newRepos(t)
+	ctx := context.Background()
+
+	const quantity = 30
+	campaignID := saveActiveCampaign(t, campaignRepo, quantity, 1)
+	prefix := uniqueID("SEQ")
+	codeFor := func(sequence int64) (string, error) {
+		return fmt.Sprintf("%s-%d", prefix, sequence), nil
+	}
+
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	codes := make(map[string]bool)
+
+	for i := 0; i < quantity+10; i++ {
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+
+			issued, err := couponRepo.IssueSequencedCoupon(ctx, campaignID, fmt.Sprintf("user-%d", index), codeFor)
+			if err != nil {
+				if !errors.Is(err, model.ErrCampaignSoldOut) {
+					t.Errorf("순번 발급 오류: %v", err)
+				}
+				return
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			if codes[issued.CouponCode] {
+				t.Errorf("같은 코드가 두 번 발급됨: %s", issued.CouponCode)
+			}
+			codes[issued.CouponCode] = true
+		}(i)
+	}
+	wg.Wait()
+
+	if len(codes) != quantity {
+		t.Errorf("예상: %d개 발급, 실제: %d개", quantity, len(codes))
+	}
+
+	campaign, err := campaignRepo.GetByID(ctx, campaignID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if campaign.IssuedQuantity != quantity || campaign.CodeSequence < quantity {
+		t.Errorf("발급 수량 %d, 코드 순번 %d (예상: %d, %d 이상)", campaign.IssuedQuantity, campaign.CodeSequence, quantity, quantity)
+	}
+}
+
+// 순번으로 만든 코드가 다른 캠페인의 코드와 겹치면 다음 순번의 코드로 발급
+func testSequencedCodeSkipsTakenCode(t *testing.T, newRepos Factory) {
+	campaignRepo, couponRepo := newRepos(t)
+	ctx := context.Background()
+
+	first := saveActiveCampaign(t, campaignRepo, 5, 1)
+	second := saveActiveCampaign(t, campaignRepo, 5, 1)
+	prefix := uniqueID("SKIP")
+	codeFor := func(sequence int64) (string, error) {
+		return fmt.Sprintf("%s-%d", prefix, sequence), nil
+	}
+
+	if issued, err := couponRepo.IssueCoupon(ctx, first, "user-1", prefix+"-0"); issued == nil {
+		t.Fatalf("첫 발급 실패: %v", err)
+	}
+
+	issued, err := couponRepo.IssueSequencedCoupon(ctx, second, "user-2", codeFor)
+	if issued == nil {
+		t.Fatalf("순번 발급 실패: %v", err)
+	}
+	if issued.CouponCode != prefix+"-1" {
+		t.Errorf("사용 중인 순번을 건너뛰지 않음: %s", issued.CouponCode)
+	}
+
+	campaign, _ := campaignRepo.GetByID(ctx, second)
+	if campaign.IssuedQuantity != 1 || campaign.CodeSequence != 2 {
+		t.Errorf("발급 수량 %d, 코드 순번 %d (예상: 1, 2)", campaign.IssuedQuantity, campaign.CodeSequence)
+	}
+}
+
+// 코드 풀: 이미 사용 중인 코드가 있으면 전체 거절, 등록된 코드는 다른 경로로 발급 불가, 등록 순서대로 수량만큼 발급
+func testCodePool(t *testing.T, newRepos Factory) {
+	campaignRepo, couponRepo := newRepos(t)
+	ctx := context.Background()
+
+	savePoolCampaign := func() string {
+		campaignID := uniqueID("pool")
+		err := campaignRepo.Save(ctx, &coupon.Campaign{
+			CampaignId: campaignID,
+			Name:       "코드 풀",
+			StartTime:  time.Now().Unix() - 1,
+			Status:     coupon.CampaignStatus_ACTIVE,
+			CreatedAt:  time.Now().Unix(),
+			MaxPerUser: 1,
+			Version:    1,
+			CodeFormat: coupon.CodeFormat_CODE_FORMAT_POOL,
+		})
+		if err != nil {
+			t.Fatalf("캠페인 저장 실패: %v", err)
+		}
+		return campaignID
+	}
+
+	pool := savePoolCampaign()
+	other := saveActiveCampaign(t, campaignRepo, 5, 5)
+	prefix := uniqueID("P")
+	codes := []string{prefix + "-A", prefix + "-B", prefix + "-C"}
+
+	if issued, err := couponRepo.IssueCoupon(ctx, other, "user-1", prefix+"-X"); issued == nil {
+		t.Fatalf("다른 캠페인 발급 실패: %v", err)
+	}
+
+	var conflict *repository.CodePoolConflictError
+	_, err := couponRepo.ImportCodePool(ctx, pool, append(slices.Clone(codes), prefix+"-X"))
+	if !errors.As(err, &conflict) || !slices.Equal(conflict.Codes, []string{prefix + "-X"}) {
+		t.Fatalf("발급된 코드가 포함된 등록이 거절되지 않음: %v", err)
+	}
+
+	imported, err := couponRepo.ImportCodePool(ctx, pool, codes)
+	if imported == nil || imported.TotalQuantity != 3 || imported.Status != coupon.CampaignStatus_ACTIVE {
+		t.Fatalf("코드 풀 등록 실패: %v, %v", imported, err)
+	}
+
+	// 등록된 코드는 발급 전이어도 다른 캠페인과 다른 코드 풀에서 쓸 수 없음
+	if _, err := couponRepo.IssueCoupon(ctx, other, "user-2", prefix+"-B"); !errors.Is(err, repository.ErrDuplicateCouponCode) {
+		t.Errorf("코드 풀의 코드가 다른 캠페인에서 발급됨: %v", err)
+	}
+	if _, err := couponRepo.ImportCodePool(ctx, savePoolCampaign(), []string{prefix + "-C"}); !errors.Is(err, repository.ErrDuplicateCouponCode) {
+		t.Errorf("다른 코드 풀의 코드가 등록됨: %v", err)
+	}
+
+	for i, code := range codes {
+		issued, err := couponRepo.IssuePooledCoupon(ctx, pool, fmt.Sprintf("user-%d", i))
+		if issued == nil || issued.CouponCode != code {
+			t.Fatalf("%d번째 발급: %v, %v (예상 코드: %s)", i, issued, err, code)
+		}
+	}
+
+	if issued, err := couponRepo.IssuePooledCoupon(ctx, pool, "user-9"); issued != nil || !errors.Is(err, model.ErrCampaignSoldOut) {
+		t.Errorf("코드 풀 소진 후 발급 결과가 다름: %v, %v", issued, err)
+	}
+
+	found, err := couponRepo.GetByCode(ctx, codes[0])
+	if err != nil || found.CampaignId != pool || found.IssuedTo != "user-0" {
+		t.Errorf("코드 풀에서 발급된 쿠폰 조회 실패: %v, %v", found, err)
+	}
+}
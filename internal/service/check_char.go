@@ -0,0 +1,119 @@
+package service
+
+import (
+	"slices"
+	"unicode"
+	"unicode/utf8"
+)
+
+/*
+# 쿠폰 코드 검사 문자
+
+HANGUL 형식 캠페인에서 code_check_char 를 켜면 코드 끝에 검사 문자(한글 14자 중 하나)를 붙임
+  코드 = prefix + 한글 2자 + 숫자 + 검사 문자   (예: 여름세가나1234 + 다)
+
+검사 문자가 있는 코드는 "숫자 다음에 한글로 끝나는" 모양으로 구분됨
+  - 검사 문자가 없는 HANGUL 코드는 숫자로, 다른 내장 형식은 영문/숫자로 끝남
+  - TEMPLATE 형식은 한글 자리로 끝날 수 없음 (parseCodeTemplate 에서 거절)
+따라서 저장소를 조회하지 않고도 잘못 입력한 코드(오타)와 발급되지 않은 코드를 구분할 수 있음
+
+계산: 오른쪽(검사 문자)부터 자리마다 가중치 1, 3, 1, 3, ... 을 곱한 합이 14 의 배수가 되도록 검사 문자를 정함
+  - 문자 값: 한글 14자는 0~13, 숫자는 0~9, 그 밖의 문자(prefix 한글 등)는 코드 포인트 % 14
+  - 가중치 1, 3 은 14 와 서로소이므로 같은 종류(한글↔한글, 숫자↔숫자) 한 글자 오타는 항상 검출
+  - 인접한 두 글자가 바뀐 경우는 두 값의 차이가 7 의 배수일 때(예: 0↔7)를 빼고 검출
+*/
+
+const checkCharModulus = 14
+
+// codeCheck 검사 문자 확인 결과
+type codeCheck int
+
+const (
+	codeCheckAbsent  codeCheck = iota // 검사 문자가 없는 모양의 코드
+	codeCheckValid                    // 검사 문자가 맞음
+	codeCheckInvalid                  // 검사 문자가 틀림 (오타)
+)
+
+// checkedCodeGenerator 다른 생성 전략의 코드 끝에 검사 문자를 붙임 (코드 수는 그대로)
+type checkedCodeGenerator struct {
+	CodeGenerator
+}
+
+func (g checkedCodeGenerator) Generate() (string, error) {
+	code, err := g.CodeGenerator.Generate()
+	if err != nil {
+		return "", err
+	}
+	return appendCheckChar(code), nil
+}
+
+func (g checkedCodeGenerator) Encode(value int64) string {
+	return appendCheckChar(g.CodeGenerator.Encode(value))
+}
+
+func (g checkedCodeGenerator) spaceKey() string {
+	return codeSpaceKey(g.CodeGenerator) + "+check"
+}
+
+// appendCheckChar 코드 끝에 검사 문자를 붙임
+func appendCheckChar(code string) string {
+	sum := weightedCheckSum(code, 1)
+	return code + string(hangulAlphabet[(checkCharModulus-sum)%checkCharModulus])
+}
+
+// verifyCheckChar 검사 문자가 있는 모양이면 검사 문자가 맞는지 확인
+func verifyCheckChar(code string) codeCheck {
+	checkChar, size := utf8.DecodeLastRuneInString(code)
+	body := code[:len(code)-size]
+	last, _ := utf8.DecodeLastRuneInString(body)
+
+	if !slices.Contains(hangulAlphabet, checkChar) || last < '0' || last > '9' {
+		return codeCheckAbsent
+	}
+
+	if (weightedCheckSum(body, 1)+checkCharValue(checkChar))%checkCharModulus != 0 {
+		return codeCheckInvalid
+	}
+	return codeCheckValid
+}
+
+// weightedCheckSum 오른쪽 끝 글자가 position 번째 자리일 때의 가중치 합 (mod 14)
+func weightedCheckSum(code string, position int) int {
+	sum := 0
+	for len(code) > 0 {
+		r, size := utf8.DecodeLastRuneInString(code)
+		code = code[:len(code)-size]
+
+		weight := 1
+		if position%2 == 1 {
+			weight = 3
+		}
+		sum = (sum + weight*checkCharValue(r)) % checkCharModulus
+		position++
+	}
+	return sum
+}
+
+func checkCharValue(r rune) int {
+	if index := slices.Index(hangulAlphabet, r); index >= 0 {
+		return index
+	}
+	if r >= '0' && r <= '9' {
+		return int(r - '0')
+	}
+	return int(r) % checkCharModulus
+}
+
+// isWellFormedCode 저장소를 조회하기 전에 확인할 수 있는 정규형 코드의 형식 (길이, 문자 종류)
+func isWellFormedCode(code string) bool {
+	if code == "" || utf8.RuneCountInString(code) > maxCouponCodeLength {
+		return false
+	}
+
+	for _, r := range code {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,322 @@
+package service
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"coupon-issuance-system/gen/coupon"
+	"coupon-issuance-system/internal/model"
+)
+
+// maxCouponCodeLength 쿠폰 코드 최대 길이 (prefix 포함, 정규형 기준)
+const maxCouponCodeLength = 10
+
+// 코드 문자 집합
+var (
+	hangulAlphabet       = []rune("가나다라마바사아자차카타파하")
+	digitAlphabet        = []rune("0123456789")
+	letterAlphabet       = []rune("ABCDEFGHIJKLMNOPQRSTUVWXYZ")
+	alphanumericAlphabet = []rune("0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ")
+	unambiguousAlphabet  = []rune("23456789ABCDEFGHJKMNPQRSTUVWXYZ") // 0/O, 1/I/L 제외
+)
+
+// CodeGenerator 쿠폰 코드 생성 전략. 캠페인의 코드 형식(문자 집합, 길이, prefix)이 정해진 인스턴스
+type CodeGenerator interface {
+	// Generate 무작위 코드 생성
+	Generate() (string, error)
+
+	// Keyspace 만들 수 있는 서로 다른 코드 수
+	Keyspace() int64
+
+	// Encode [0, Keyspace) 의 값을 코드로 변환. 값이 다르면 코드도 다름 (키 기반 순번 코드 생성에서 사용)
+	Encode(value int64) string
+}
+
+// codeSlot 코드의 한 자리. alphabet 이 nil 이면 literal 을 그대로 출력 (literal 은 정규형)
+type codeSlot struct {
+	literal  string
+	alphabet []rune
+}
+
+// patternCodeGenerator 고정 문자열(prefix 등)과 자리별 문자 집합으로 정의되는 코드 (내장 전략 공통 구현)
+type patternCodeGenerator struct {
+	slots    []codeSlot
+	keyspace int64
+}
+
+func newPatternCodeGenerator(slots []codeSlot) (*patternCodeGenerator, error) {
+	length := 0
+	keyspace := int64(1)
+
+	for _, slot := range slots {
+		if slot.alphabet == nil {
+			length += utf8.RuneCountInString(slot.literal)
+			continue
+		}
+		length++
+		keyspace *= int64(len(slot.alphabet)) // 최대 36^10 이므로 넘치지 않음
+	}
+
+	if length > maxCouponCodeLength {
+		return nil, fmt.Errorf("쿠폰 코드는 prefix 를 포함해 %d자 이하여야 합니다 (현재 %d자)", maxCouponCodeLength, length)
+	}
+	if keyspace == 1 {
+		return nil, errors.New("쿠폰 코드에 무작위로 정해지는 자리가 없습니다")
+	}
+
+	return &patternCodeGenerator{slots: slots, keyspace: keyspace}, nil
+}
+
+func (g *patternCodeGenerator) Generate() (string, error) {
+	var code strings.Builder
+
+	for _, slot := range g.slots {
+		if slot.alphabet == nil {
+			code.WriteString(slot.literal)
+			continue
+		}
+
+		idx, err := rand.Int(rand.Reader, big.NewInt(int64(len(slot.alphabet))))
+		if err != nil {
+			return "", err
+		}
+		code.WriteRune(slot.alphabet[idx.Int64()])
+	}
+
+	return code.String(), nil
+}
+
+func (g *patternCodeGenerator) Keyspace() int64 {
+	return g.keyspace
+}
+
+// Encode 값을 자리별 문자 집합의 혼합 진법으로 표현 (뒤 자리가 작은 단위)
+func (g *patternCodeGenerator) Encode(value int64) string {
+	parts := make([]string, len(g.slots))
+
+	for i := len(g.slots) - 1; i >= 0; i-- {
+		slot := g.slots[i]
+		if slot.alphabet == nil {
+			parts[i] = slot.literal
+			continue
+		}
+
+		base := int64(len(slot.alphabet))
+		parts[i] = string(slot.alphabet[value%base])
+		value /= base
+	}
+
+	return strings.Join(parts, "")
+}
+
+// spaceKey 코드 공간 식별자. 같으면 두 생성기가 만들 수 있는 코드 집합이 같음
+func (g *patternCodeGenerator) spaceKey() string {
+	parts := make([]string, len(g.slots))
+	for i, slot := range g.slots {
+		if slot.alphabet == nil {
+			parts[i] = "=" + slot.literal
+		} else {
+			parts[i] = "[" + string(slot.alphabet) + "]"
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// ForCampaign 캠페인의 코드 형식에 맞는 생성 전략. 형식이 잘못되었거나 10자를 넘으면 오류 (메시지는 그대로 응답에 사용)
+func (g *CouponCodeGenerator) ForCampaign(campaign *coupon.Campaign) (CodeGenerator, error) {
+	if err := validateCodePrefix(campaign.CodePrefix); err != nil {
+		return nil, err
+	}
+	prefix := model.NormalizeCouponCode(campaign.CodePrefix) // 정규형으로 저장되기 전에 만들어진 캠페인
+
+	length := int(campaign.CodeLength)
+	if length == 0 {
+		length = maxCouponCodeLength
+	}
+	if length < 0 || length > maxCouponCodeLength {
+		return nil, fmt.Errorf("쿠폰 코드 길이는 1~%d자여야 합니다", maxCouponCodeLength)
+	}
+
+	isHangul := campaign.CodeFormat == coupon.CodeFormat_CODE_FORMAT_UNSPECIFIED || campaign.CodeFormat == coupon.CodeFormat_CODE_FORMAT_HANGUL
+	if campaign.CodeCheckChar && !isHangul {
+		return nil, errors.New("검사 문자는 HANGUL 형식에서만 사용할 수 있습니다")
+	}
+
+	switch campaign.CodeFormat {
+	case coupon.CodeFormat_CODE_FORMAT_UNSPECIFIED, coupon.CodeFormat_CODE_FORMAT_HANGUL:
+		if prefix == "" {
+			prefix = g.extractPrefix(campaign.Name) // prefix 가 저장되기 전에 만들어진 캠페인
+		}
+
+		// 한글 2자 + 숫자 (+ 검사 문자)
+		bodyLength := length - len([]rune(prefix))
+		if campaign.CodeCheckChar {
+			bodyLength--
+		}
+		if bodyLength < 3 {
+			return nil, errors.New("HANGUL 형식은 prefix 뒤에 한글 2자 + 숫자 1자 이상(검사 문자 사용 시 + 1자)이 필요합니다")
+		}
+
+		generator, err := newPatternCodeGenerator(append(
+			[]codeSlot{{literal: prefix}, {alphabet: g.koreanChars}, {alphabet: g.koreanChars}},
+			repeatSlot(g.numberChars, bodyLength-2)...,
+		))
+		if err != nil || !campaign.CodeCheckChar {
+			return generator, err
+		}
+		return checkedCodeGenerator{generator}, nil
+
+	case coupon.CodeFormat_CODE_FORMAT_ALPHANUMERIC:
+		return fixedAlphabetCodeGenerator(prefix, alphanumericAlphabet, length)
+
+	case coupon.CodeFormat_CODE_FORMAT_UNAMBIGUOUS:
+		return fixedAlphabetCodeGenerator(prefix, unambiguousAlphabet, length)
+
+	case coupon.CodeFormat_CODE_FORMAT_NUMERIC:
+		return fixedAlphabetCodeGenerator(prefix, g.numberChars, length)
+
+	case coupon.CodeFormat_CODE_FORMAT_TEMPLATE:
+		slots, err := g.parseCodeTemplate(campaign.CodeTemplate, prefix)
+		if err != nil {
+			return nil, err
+		}
+		return newPatternCodeGenerator(slots)
+
+	case coupon.CodeFormat_CODE_FORMAT_POOL:
+		return nil, errors.New("POOL 형식 캠페인은 코드를 생성하지 않고 등록한 코드 목록에서 발급합니다")
+
+	default:
+		return nil, fmt.Errorf("지원하지 않는 쿠폰 코드 형식입니다: %s", campaign.CodeFormat)
+	}
+}
+
+// fixedAlphabetCodeGenerator prefix 뒤를 한 가지 문자 집합으로 채우는 코드
+func fixedAlphabetCodeGenerator(prefix string, alphabet []rune, length int) (CodeGenerator, error) {
+	bodyLength := length - len([]rune(prefix))
+	if bodyLength < 1 {
+		return nil, errors.New("쿠폰 코드 길이가 prefix 보다 길어야 합니다")
+	}
+	return newPatternCodeGenerator(append([]codeSlot{{literal: prefix}}, repeatSlot(alphabet, bodyLength)...))
+}
+
+func repeatSlot(alphabet []rune, count int) []codeSlot {
+	slots := make([]codeSlot, count)
+	for i := range slots {
+		slots[i] = codeSlot{alphabet: alphabet}
+	}
+	return slots
+}
+
+// parseCodeTemplate 템플릿 해석
+//   - {PREFIX}: code_prefix
+//   - {AAAA}: 영문 대문자, {9999}: 숫자, {XXXX}: 영문 대문자/숫자, {HHHH}: 한글 (글자 수만큼 자리)
+//   - 그 밖의 영문/숫자/한글은 그대로 출력. '-' 는 템플릿을 읽기 쉽게 나누는 용도로만 쓰이고 코드에는 남지 않음 (정규형)
+func (g *CouponCodeGenerator) parseCodeTemplate(template, prefix string) ([]codeSlot, error) {
+	if template == "" {
+		return nil, errors.New("TEMPLATE 형식은 code_template 이 필요합니다")
+	}
+
+	placeholders := map[rune][]rune{
+		'A': letterAlphabet,
+		'9': g.numberChars,
+		'X': alphanumericAlphabet,
+		'H': g.koreanChars,
+	}
+
+	var slots []codeSlot
+	rest := template
+	for rest != "" {
+		open := strings.IndexByte(rest, '{')
+		if open < 0 {
+			open = len(rest)
+		}
+
+		if literal := rest[:open]; literal != "" {
+			if err := validateTemplateLiteral(literal); err != nil {
+				return nil, err
+			}
+			if literal := model.NormalizeCouponCode(literal); literal != "" {
+				slots = append(slots, codeSlot{literal: literal})
+			}
+		}
+		if open == len(rest) {
+			break
+		}
+
+		closeAt := strings.IndexByte(rest[open:], '}')
+		if closeAt < 0 {
+			return nil, errors.New("쿠폰 코드 템플릿의 { 가 닫히지 않았습니다")
+		}
+		placeholder := rest[open+1 : open+closeAt]
+		rest = rest[open+closeAt+1:]
+
+		if placeholder == "PREFIX" {
+			if prefix == "" {
+				return nil, errors.New("쿠폰 코드 템플릿에 {PREFIX} 가 있으면 code_prefix 가 필요합니다")
+			}
+			slots = append(slots, codeSlot{literal: prefix})
+			continue
+		}
+
+		runes := []rune(placeholder)
+		alphabet, exists := placeholders[firstRune(runes)]
+		if !exists || strings.Count(placeholder, string(runes[0])) != len(runes) {
+			return nil, fmt.Errorf("알 수 없는 쿠폰 코드 템플릿 자리 표시자입니다: {%s}", placeholder)
+		}
+		slots = append(slots, repeatSlot(alphabet, len(runes))...)
+	}
+
+	if len(slots) == 0 {
+		return nil, errors.New("쿠폰 코드 템플릿에 코드 자리가 없습니다")
+	}
+
+	// 한글로 끝나면 검사 문자가 붙은 코드와 모양이 같아짐 (check_char.go)
+	if last := slots[len(slots)-1]; last.alphabet != nil && slices.Contains(last.alphabet, g.koreanChars[0]) ||
+		last.alphabet == nil && endsWithHangulLetter(last.literal) {
+		return nil, errors.New("쿠폰 코드 템플릿은 한글로 끝날 수 없습니다")
+	}
+
+	return slots, nil
+}
+
+func endsWithHangulLetter(literal string) bool {
+	r, _ := utf8.DecodeLastRuneInString(literal)
+	return r >= '가' && r <= '힣'
+}
+
+func firstRune(runes []rune) rune {
+	if len(runes) == 0 {
+		return 0
+	}
+	return runes[0]
+}
+
+func validateTemplateLiteral(literal string) error {
+	for _, r := range literal {
+		if r != '-' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return fmt.Errorf("쿠폰 코드 템플릿에 사용할 수 없는 문자입니다: %q", r)
+		}
+	}
+	return nil
+}
+
+// isValidPoolCode 코드 풀에 등록할 수 있는 코드 (길이, 문자 종류)
+// 숫자 다음 한글로 끝나는 코드는 검사 문자가 붙은 코드와 모양이 같아 사용 시 오타로 판단되므로 거절 (check_char.go)
+func isValidPoolCode(code string) bool {
+	return isWellFormedCode(code) && verifyCheckChar(code) == codeCheckAbsent
+}
+
+func validateCodePrefix(prefix string) error {
+	for _, r := range prefix {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return fmt.Errorf("쿠폰 코드 prefix 에 사용할 수 없는 문자입니다: %q", r)
+		}
+	}
+	return nil
+}
@@ -2,29 +2,67 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"time"
 
 	"coupon-issuance-system/gen/coupon"
+	"coupon-issuance-system/internal/clock"
+	"coupon-issuance-system/internal/model"
 	"coupon-issuance-system/internal/repository"
+	"google.golang.org/protobuf/proto"
 )
 
+var (
+	// ErrInvalidRequest 요청 값 검증 실패. 검증 메시지가 담긴 오류도 errors.Is 로 같은 오류로 확인됨
+	ErrInvalidRequest = model.ErrInvalidArgument
+	// ErrCampaignNotFound 캠페인이 존재하지 않음
+	ErrCampaignNotFound = model.NewDomainError(coupon.ErrorReason_ERROR_REASON_CAMPAIGN_NOT_FOUND, "캠페인을 찾을 수 없습니다")
+)
+
+// maxCodeAttempts 코드 충돌 시 재생성 최대 횟수
+const maxCodeAttempts = 10
+
+// maxImportCodes ImportCouponCodes 한 번에 등록할 수 있는 최대 코드 수 (모두 메모리에 모아 한 번에 등록)
+const maxImportCodes = 1_000_000
+
+// maxRejectedCodes ImportCouponCodes 응답에 담는 거절된 코드 최대 수
+const maxRejectedCodes = 100
+
+// mistypedCodeMessage 형식이나 검사 문자가 맞지 않는 코드 (발급되지 않은 코드와 구분)
+const mistypedCodeMessage = "쿠폰 코드를 잘못 입력했습니다. 코드를 다시 확인해 주세요"
+
+var errMistypedCode = model.NewDomainError(coupon.ErrorReason_ERROR_REASON_MALFORMED_CODE, mistypedCodeMessage)
+
 type CouponService struct {
-	campaignRepo *repository.MemoryCampaignRepository
-	couponRepo   *repository.MemoryCouponRepository
+	campaignRepo repository.CampaignRepository
+	couponRepo   repository.CouponRepository
 	codeGen      *CouponCodeGenerator
+	keyedCodeGen *KeyedCodeGenerator // nil 이 아니면 무작위 코드 대신 캠페인 코드 순번 기반 코드 사용
+	idempotency  *IdempotencyStore
+	clock        clock.Clock        // 캠페인 시작 판정, 시작 시간 검증 등 현재 시각 기준 (저장소와 같은 시계를 주입)
+	scheduler    *CampaignScheduler // 상태가 바뀌었을 수 있는 캠페인을 알림 (nil 이면 lazy evaluation 만 사용)
 }
 
 func NewCouponService(
-	campaignRepo *repository.MemoryCampaignRepository,
-	couponRepo *repository.MemoryCouponRepository,
+	campaignRepo repository.CampaignRepository,
+	couponRepo repository.CouponRepository,
 	codeGenerator *CouponCodeGenerator,
+	keyedCodeGenerator *KeyedCodeGenerator,
+	idempotencyStore *IdempotencyStore,
+	clk clock.Clock,
+	scheduler *CampaignScheduler,
 ) *CouponService {
 	return &CouponService{
 		campaignRepo: campaignRepo,
 		couponRepo:   couponRepo,
 		codeGen:      codeGenerator,
+		keyedCodeGen: keyedCodeGenerator,
+		idempotency:  idempotencyStore,
+		clock:        clk,
+		scheduler:    scheduler,
 	}
 }
 
@@ -33,33 +71,119 @@ func (s *CouponService) CreateCampaign(
 	req *coupon.CreateCampaignRequest,
 ) (*coupon.CreateCampaignResponse, error) {
 	// 입력 검증
-	validation := validateCreateCampaignRequest(req)
+	currentTime := s.clock.Now()
+	validation := validateCreateCampaignRequest(req, currentTime)
 	if !validation.IsValid {
 		return &coupon.CreateCampaignResponse{
 			Message: validation.Message,
-		}, nil
+		}, validation.Err()
 	}
 
-	now := time.Now().Unix()
+	now := currentTime.Unix()
 
+	// ID 는 유일성만 필요하므로 주입된 시계(테스트에서는 멈춰 있을 수 있음) 대신 실제 시간 사용
 	campaignID := fmt.Sprintf("campaign_%d", time.Now().UnixNano()) // 나노초 단위
 
+	// 시각은 밀리초 필드 기준 (초 단위로만 보낸 클라이언트는 초 단위 값으로 계산)
+	startTime := model.Millis(req.StartTime, req.StartTimeMs)
+	endTime := model.Millis(req.EndTime, req.EndTimeMs)
+
 	// 캠페인 상태 결정하기
 	status := coupon.CampaignStatus_WAITING
-	if req.StartTime <= now {
+	if startTime <= currentTime.UnixMilli() {
 		status = coupon.CampaignStatus_ACTIVE
 	}
 
+	maxPerUser := req.MaxPerUser
+	if maxPerUser == 0 {
+		maxPerUser = model.DefaultMaxPerUser
+	}
+
+	// 코드 형식. HANGUL prefix 는 생성 시점의 캠페인명으로 고정 (이후 이름이 바뀌어도 코드 형식은 그대로)
+	codeFormat := req.CodeFormat
+	codePrefix := model.NormalizeCouponCode(req.CodePrefix)
+	if codeFormat == coupon.CodeFormat_CODE_FORMAT_UNSPECIFIED {
+		codeFormat = coupon.CodeFormat_CODE_FORMAT_HANGUL
+	}
+	if codeFormat == coupon.CodeFormat_CODE_FORMAT_HANGUL && codePrefix == "" {
+		codePrefix = s.codeGen.extractPrefix(req.Name)
+	}
+
 	campaign := &coupon.Campaign{
 		CampaignId:     campaignID,
 		Name:           req.Name,
-		StartTime:      req.StartTime,
+		StartTime:      model.Seconds(startTime),
+		StartTimeMs:    startTime,
+		EndTime:        model.Seconds(endTime),
+		EndTimeMs:      endTime,
 		TotalQuantity:  req.TotalQuantity,
 		IssuedQuantity: 0,
 		Status:         status,
 		CreatedAt:      now,
+		MaxPerUser:     maxPerUser,
+		Version:        1,
+		CodeFormat:     codeFormat,
+		CodeLength:     req.CodeLength,
+		CodePrefix:     codePrefix,
+		CodeTemplate:   req.CodeTemplate,
+		CodeCheckChar:  req.CodeCheckChar,
+	}
+
+	// 코드 목록으로 발급하는 캠페인은 코드 공간을 확인하지 않음 (수량은 ImportCouponCodes 에서 정해짐)
+	if codeFormat == coupon.CodeFormat_CODE_FORMAT_POOL {
+		return s.saveCampaign(ctx, campaign, &coupon.CreateCampaignResponse{})
 	}
 
+	codeGenerator, err := s.codeGen.ForCampaign(campaign)
+	if err != nil {
+		return &coupon.CreateCampaignResponse{
+			Message: err.Error(),
+		}, model.InvalidArgument(err.Error())
+	}
+
+	validation = validateCodeKeyspace(codeGenerator, req.TotalQuantity)
+	if !validation.IsValid {
+		return &coupon.CreateCampaignResponse{
+			Message: validation.Message,
+		}, validation.Err()
+	}
+
+	// 같은 코드 공간을 쓰는 캠페인까지 포함한 사용률. 거절할 때도 추정치는 응답에 담음
+	estimate, err := s.estimateKeyspace(ctx, codeGenerator, req.TotalQuantity)
+	if err != nil {
+		log.Printf("코드 공간 사용률 계산 실패: %v", err)
+		return &coupon.CreateCampaignResponse{
+			Message: "캠페인 생성에 실패했습니다",
+		}, err
+	}
+
+	response := &coupon.CreateCampaignResponse{
+		CodeKeyspace:         estimate.Keyspace,
+		CodeSpaceReserved:    estimate.Reserved,
+		CodeSpaceUsage:       estimate.Usage,
+		CollisionProbability: estimate.CollisionProbability,
+	}
+
+	validation, warning := validateKeyspaceUsage(estimate)
+	if !validation.IsValid {
+		response.Message = validation.Message
+		return response, validation.Err()
+	}
+
+	if warning != "" {
+		log.Printf("캠페인 코드 공간 경고. ID: %s, 사용률: %.3f", campaignID, estimate.Usage)
+		response.Warning = warning
+	}
+	return s.saveCampaign(ctx, campaign, response)
+}
+
+// saveCampaign 검증이 끝난 캠페인을 저장하고 response 에 캠페인과 결과 메시지를 채움
+func (s *CouponService) saveCampaign(
+	ctx context.Context,
+	campaign *coupon.Campaign,
+	response *coupon.CreateCampaignResponse,
+) (*coupon.CreateCampaignResponse, error) {
+
 	err := s.campaignRepo.Save(ctx, campaign)
 	if err != nil {
 		log.Printf("캠페인 저장 실패: %v", err)
@@ -68,12 +192,12 @@ func (s *CouponService) CreateCampaign(
 		}, err
 	}
 
-	log.Printf("캠페인이 생성되었습니다. ID: %s, 이름: %s", campaignID, req.Name)
+	log.Printf("캠페인이 생성되었습니다. ID: %s, 이름: %s", campaign.CampaignId, campaign.Name)
+	s.scheduler.Notify(campaign.CampaignId)
 
-	return &coupon.CreateCampaignResponse{
-		Campaign: campaign,
-		Message:  "캠페인이 성공적으로 생성되었습니다",
-	}, nil
+	response.Campaign = campaign
+	response.Message = "캠페인이 성공적으로 생성되었습니다"
+	return response, nil
 }
 
 func (s *CouponService) GetCampaign(
@@ -85,15 +209,24 @@ func (s *CouponService) GetCampaign(
 	if !validation.IsValid {
 		return &coupon.GetCampaignResponse{
 			Message: validation.Message,
-		}, nil
+		}, validation.Err()
 	}
 
 	// 캠페인 조회
 	campaign, err := s.campaignRepo.GetByID(ctx, req.CampaignId)
 	if err != nil {
 		log.Printf("캠페인 조회 실패: %v", err)
+		message, err := campaignLookupError(err)
+		return &coupon.GetCampaignResponse{
+			Message: message,
+		}, err
+	}
+
+	// 카운터만 요청한 경우 쿠폰 목록 복사를 생략
+	if req.ExcludeCoupons {
 		return &coupon.GetCampaignResponse{
-			Message: "캠페인을 찾을 수 없습니다",
+			Campaign: campaign,
+			Message:  "조회 성공",
 		}, nil
 	}
 
@@ -125,21 +258,70 @@ func (s *CouponService) IssueCoupon(
 		return &coupon.IssueCouponResponse{
 			Success: false,
 			Message: validation.Message,
-		}, nil
+		}, validation.Err()
 	}
 
-	// 쿠폰 코드 생성
-	couponCode, err := s.generateUniqueCouponCode(ctx, req.CampaignId)
-	if err != nil {
-		log.Printf("쿠폰 코드 생성 실패: %v", err)
+	if req.IdempotencyKey == "" {
+		return s.issueCoupon(ctx, req)
+	}
+
+	return s.issueCouponIdempotent(ctx, req)
+}
+
+// issueCouponIdempotent 같은 멱등성 키의 재요청에는 최초 응답(발급 불가 같은 도메인 오류 포함)을 그대로 반환
+// 최초 요청이 아직 처리 중이면 끝날 때까지 기다린 뒤 그 결과를 반환
+func (s *CouponService) issueCouponIdempotent(
+	ctx context.Context,
+	req *coupon.IssueCouponRequest,
+) (*coupon.IssueCouponResponse, error) {
+
+	key := idempotencyScope(req)
+
+	for {
+		entry, owner := s.idempotency.begin(key)
+		if owner {
+			response, err := s.issueCoupon(ctx, req)
+			if err != nil && !model.IsDomainError(err) {
+				s.idempotency.abort(key, entry)
+				return response, err
+			}
+
+			s.idempotency.complete(entry, response, err)
+			return response, err
+		}
+
+		select {
+		case <-entry.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		if entry.response != nil {
+			log.Printf("멱등성 키 재요청. 최초 응답 반환. 사용자: %s, 캠페인: %s, 키: %s",
+				req.UserId, req.CampaignId, req.IdempotencyKey)
+			return proto.Clone(entry.response).(*coupon.IssueCouponResponse), entry.err
+		}
+		// 최초 요청이 저장소 오류로 끝났으면 다시 발급을 시도
+	}
+}
+
+// issueCoupon 실제 쿠폰 발급 처리
+func (s *CouponService) issueCoupon(
+	ctx context.Context,
+	req *coupon.IssueCouponRequest,
+) (*coupon.IssueCouponResponse, error) {
+
+	// 쿠폰 발급 (코드 유일성은 저장소가 발급과 같은 원자적 단위에서 보장)
+	issuedCoupon, err := s.issueWithUniqueCode(ctx, req.CampaignId, req.UserId)
+	if errors.Is(err, model.ErrCampaignSoldOut) {
+		s.scheduler.Notify(req.CampaignId) // 소진 상태(COMPLETED) 전이
+	}
+	if model.IsDomainError(err) {
 		return &coupon.IssueCouponResponse{
 			Success: false,
-			Message: "쿠폰 코드 생성에 실패했습니다",
+			Message: err.Error(),
 		}, err
 	}
-
-	// 쿠폰 발급
-	issuedCoupon, failMsg, err := s.couponRepo.IssueCoupon(ctx, req.CampaignId, req.UserId, couponCode)
 	if err != nil {
 		log.Printf("쿠폰 발급 처리 실패: %v", err)
 		return &coupon.IssueCouponResponse{
@@ -148,16 +330,10 @@ func (s *CouponService) IssueCoupon(
 		}, err
 	}
 
-	if issuedCoupon == nil {
-		return &coupon.IssueCouponResponse{
-			Success: false,
-			Message: failMsg,
-		}, err
-	}
-
 	// 성공
 	log.Printf("쿠폰 발급 성공. 사용자: %s, 캠페인: %s, 쿠폰코드: %s",
-		req.UserId, req.CampaignId, couponCode)
+		req.UserId, req.CampaignId, issuedCoupon.CouponCode)
+	s.scheduler.Notify(req.CampaignId) // 마지막 수량이었으면 소진 상태(COMPLETED) 전이
 
 	return &coupon.IssueCouponResponse{
 		Success: true,
@@ -166,27 +342,622 @@ func (s *CouponService) IssueCoupon(
 	}, nil
 }
 
-func (s *CouponService) generateUniqueCouponCode(
+func (s *CouponService) RedeemCoupon(
 	ctx context.Context,
-	campaignID string,
-) (string, error) {
+	req *coupon.RedeemCouponRequest,
+) (*coupon.RedeemCouponResponse, error) {
 
-	// 캠페인 조회
+	// 입력 검증
+	validation := validateRedeemCouponRequest(req)
+	if !validation.IsValid {
+		return &coupon.RedeemCouponResponse{
+			Success: false,
+			Message: validation.Message,
+		}, validation.Err()
+	}
+
+	// 검사 문자가 틀린 코드는 저장소를 조회하지 않고 오타로 안내
+	code := model.NormalizeCouponCode(req.CouponCode)
+	if verifyCheckChar(code) == codeCheckInvalid {
+		return &coupon.RedeemCouponResponse{
+			Success: false,
+			Message: errMistypedCode.Message,
+		}, errMistypedCode
+	}
+
+	redeemedCoupon, err := s.couponRepo.RedeemCoupon(ctx, code, req.UserId, req.OrderId)
+	if model.IsDomainError(err) {
+		return &coupon.RedeemCouponResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+	if err != nil {
+		log.Printf("쿠폰 사용 처리 실패: %v", err)
+		return &coupon.RedeemCouponResponse{
+			Success: false,
+			Message: "쿠폰 사용 처리 중 오류가 발생했습니다",
+		}, err
+	}
+
+	log.Printf("쿠폰 사용 성공. 사용자: %s, 쿠폰코드: %s, 주문: %s",
+		req.UserId, code, req.OrderId)
+
+	return &coupon.RedeemCouponResponse{
+		Success: true,
+		Coupon:  redeemedCoupon,
+		Message: "쿠폰이 성공적으로 사용되었습니다",
+	}, nil
+}
+
+// ValidateCoupon 쿠폰 코드 확인. 형식/검사 문자 오류는 저장소를 조회하지 않고 MALFORMED 로 응답
+// 확인 결과(MALFORMED, NOT_FOUND)는 요청 실패가 아니므로 오류 없이 result 로 응답
+func (s *CouponService) ValidateCoupon(
+	ctx context.Context,
+	req *coupon.ValidateCouponRequest,
+) (*coupon.ValidateCouponResponse, error) {
+
+	// 입력 검증
+	validation := validateValidateCouponRequest(req)
+	if !validation.IsValid {
+		return &coupon.ValidateCouponResponse{
+			Message: validation.Message,
+		}, validation.Err()
+	}
+
+	code := model.NormalizeCouponCode(req.CouponCode)
+	if !isWellFormedCode(code) || verifyCheckChar(code) == codeCheckInvalid {
+		return &coupon.ValidateCouponResponse{
+			Result:  coupon.CodeCheckResult_CODE_CHECK_RESULT_MALFORMED,
+			Message: mistypedCodeMessage,
+		}, nil
+	}
+
+	found, err := s.couponRepo.GetByCode(ctx, code)
+	if errors.Is(err, model.ErrCouponNotFound) {
+		return &coupon.ValidateCouponResponse{
+			Result:  coupon.CodeCheckResult_CODE_CHECK_RESULT_NOT_FOUND,
+			Message: model.ErrCouponNotFound.Message,
+		}, nil
+	}
+	if err != nil {
+		log.Printf("쿠폰 조회 실패: %v", err)
+		return &coupon.ValidateCouponResponse{
+			Message: "쿠폰 조회 중 오류가 발생했습니다",
+		}, err
+	}
+
+	return &coupon.ValidateCouponResponse{
+		Result:  coupon.CodeCheckResult_CODE_CHECK_RESULT_VALID,
+		Coupon:  found,
+		Message: "발급된 쿠폰 코드입니다",
+	}, nil
+}
+
+func (s *CouponService) ListCampaigns(
+	ctx context.Context,
+	req *coupon.ListCampaignsRequest,
+) (*coupon.ListCampaignsResponse, error) {
+
+	// 입력 검증
+	validation := validateListCampaignsRequest(req)
+	if !validation.IsValid {
+		return &coupon.ListCampaignsResponse{
+			Message: validation.Message,
+		}, validation.Err()
+	}
+
+	filter := repository.CampaignFilter{
+		Statuses:      req.Statuses,
+		NameContains:  req.NameContains,
+		StartTimeFrom: req.StartTimeFrom,
+		StartTimeTo:   req.StartTimeTo,
+	}
+
+	after, err := decodeCampaignPageToken(req.PageToken, filter)
+	if err != nil {
+		return &coupon.ListCampaignsResponse{
+			Message: err.Error(),
+		}, model.InvalidArgument(err.Error())
+	}
+
+	campaigns, hasMore, err := s.campaignRepo.List(ctx, filter, after, effectivePageSize(req.PageSize))
+	if err != nil {
+		log.Printf("캠페인 목록 조회 실패: %v", err)
+		return &coupon.ListCampaignsResponse{
+			Message: "캠페인 목록 조회에 실패했습니다",
+		}, err
+	}
+
+	nextPageToken := ""
+	if hasMore {
+		last := campaigns[len(campaigns)-1]
+		nextPageToken = encodeCampaignPageToken(
+			repository.CampaignCursor{CreatedAt: last.CreatedAt, CampaignID: last.CampaignId}, filter)
+	}
+
+	return &coupon.ListCampaignsResponse{
+		Campaigns:     campaigns,
+		NextPageToken: nextPageToken,
+		Message:       "조회 성공",
+	}, nil
+}
+
+func (s *CouponService) ListIssuedCoupons(
+	ctx context.Context,
+	req *coupon.ListIssuedCouponsRequest,
+) (*coupon.ListIssuedCouponsResponse, error) {
+
+	// 입력 검증
+	validation := validateListIssuedCouponsRequest(req)
+	if !validation.IsValid {
+		return &coupon.ListIssuedCouponsResponse{
+			Message: validation.Message,
+		}, validation.Err()
+	}
+
+	after, err := decodeCouponPageToken(req.PageToken, req.CampaignId)
+	if err != nil {
+		return &coupon.ListIssuedCouponsResponse{
+			Message: err.Error(),
+		}, model.InvalidArgument(err.Error())
+	}
+
+	if _, err := s.campaignRepo.GetByID(ctx, req.CampaignId); err != nil {
+		log.Printf("캠페인 조회 실패: %v", err)
+		message, err := campaignLookupError(err)
+		return &coupon.ListIssuedCouponsResponse{
+			Message: message,
+		}, err
+	}
+
+	coupons, next, hasMore, err := s.couponRepo.ListByCampaignID(ctx, req.CampaignId, after, effectivePageSize(req.PageSize))
+	if err != nil {
+		log.Printf("쿠폰 조회 실패: %v", err)
+		return &coupon.ListIssuedCouponsResponse{
+			Message: "쿠폰 정보 조회에 실패했습니다",
+		}, err
+	}
+
+	nextPageToken := ""
+	if hasMore {
+		nextPageToken = encodeCouponPageToken(req.CampaignId, next)
+	}
+
+	return &coupon.ListIssuedCouponsResponse{
+		Coupons:       coupons,
+		NextPageToken: nextPageToken,
+		Message:       "조회 성공",
+	}, nil
+}
+
+// StreamIssuedCoupons 발급된 쿠폰을 발급 순서대로 batch_size 개씩 묶어 send 로 전달
+// 스트리밍 도중 새로 발급된 쿠폰도 따라잡을 때까지 계속 전송함
+func (s *CouponService) StreamIssuedCoupons(
+	ctx context.Context,
+	req *coupon.StreamIssuedCouponsRequest,
+	send func(*coupon.StreamIssuedCouponsResponse) error,
+) error {
+
+	// 입력 검증
+	validation := validateStreamIssuedCouponsRequest(req)
+	if !validation.IsValid {
+		return validation.Err()
+	}
+
+	if _, err := s.campaignRepo.GetByID(ctx, req.CampaignId); err != nil {
+		_, err := campaignLookupError(err)
+		return err
+	}
+
+	batchSize := effectiveStreamBatchSize(req.BatchSize)
+	var after repository.CouponCursor
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		coupons, next, hasMore, err := s.couponRepo.ListByCampaignID(ctx, req.CampaignId, after, batchSize)
+		if err != nil {
+			return fmt.Errorf("쿠폰 조회 실패: %w", err)
+		}
+
+		if len(coupons) > 0 {
+			if err := send(&coupon.StreamIssuedCouponsResponse{Coupons: coupons}); err != nil {
+				return err
+			}
+			after = next
+		}
+
+		if !hasMore {
+			return nil
+		}
+	}
+}
+
+func (s *CouponService) PauseCampaign(
+	ctx context.Context,
+	req *coupon.PauseCampaignRequest,
+) (*coupon.PauseCampaignResponse, error) {
+
+	// 입력 검증
+	validation := validateCampaignID(req.CampaignId)
+	if !validation.IsValid {
+		return &coupon.PauseCampaignResponse{
+			Success: false,
+			Message: validation.Message,
+		}, validation.Err()
+	}
+
+	campaign, err := s.campaignRepo.Modify(ctx, req.CampaignId, func(c *model.Campaign) error {
+		return c.Pause(s.clock.Now())
+	})
+	if model.IsDomainError(err) {
+		return &coupon.PauseCampaignResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+	if err != nil {
+		log.Printf("캠페인 일시 중지 실패: %v", err)
+		return &coupon.PauseCampaignResponse{
+			Success: false,
+			Message: "캠페인 일시 중지 처리 중 오류가 발생했습니다",
+		}, err
+	}
+
+	log.Printf("캠페인이 일시 중지되었습니다. ID: %s", req.CampaignId)
+	s.scheduler.Notify(req.CampaignId)
+
+	return &coupon.PauseCampaignResponse{
+		Success:  true,
+		Campaign: campaign,
+		Message:  "캠페인이 일시 중지되었습니다",
+	}, nil
+}
+
+func (s *CouponService) ResumeCampaign(
+	ctx context.Context,
+	req *coupon.ResumeCampaignRequest,
+) (*coupon.ResumeCampaignResponse, error) {
+
+	// 입력 검증
+	validation := validateCampaignID(req.CampaignId)
+	if !validation.IsValid {
+		return &coupon.ResumeCampaignResponse{
+			Success: false,
+			Message: validation.Message,
+		}, validation.Err()
+	}
+
+	campaign, err := s.campaignRepo.Modify(ctx, req.CampaignId, func(c *model.Campaign) error {
+		return c.Resume(s.clock.Now())
+	})
+	if model.IsDomainError(err) {
+		return &coupon.ResumeCampaignResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+	if err != nil {
+		log.Printf("캠페인 재개 실패: %v", err)
+		return &coupon.ResumeCampaignResponse{
+			Success: false,
+			Message: "캠페인 재개 처리 중 오류가 발생했습니다",
+		}, err
+	}
+
+	log.Printf("캠페인이 재개되었습니다. ID: %s, 상태: %s", req.CampaignId, campaign.Status)
+	s.scheduler.Notify(req.CampaignId)
+
+	return &coupon.ResumeCampaignResponse{
+		Success:  true,
+		Campaign: campaign,
+		Message:  "캠페인이 재개되었습니다",
+	}, nil
+}
+
+func (s *CouponService) CancelCampaign(
+	ctx context.Context,
+	req *coupon.CancelCampaignRequest,
+) (*coupon.CancelCampaignResponse, error) {
+
+	// 입력 검증
+	validation := validateCampaignID(req.CampaignId)
+	if !validation.IsValid {
+		return &coupon.CancelCampaignResponse{
+			Success: false,
+			Message: validation.Message,
+		}, validation.Err()
+	}
+
+	// 취소가 반영된 이후에는 새 발급이 불가능하므로, 취소 후 회수해도 회수 대상이 늘어나지 않음
+	campaign, err := s.campaignRepo.Modify(ctx, req.CampaignId, func(c *model.Campaign) error {
+		return c.Cancel()
+	})
+	if model.IsDomainError(err) {
+		return &coupon.CancelCampaignResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+	if err != nil {
+		log.Printf("캠페인 취소 실패: %v", err)
+		return &coupon.CancelCampaignResponse{
+			Success: false,
+			Message: "캠페인 취소 처리 중 오류가 발생했습니다",
+		}, err
+	}
+
+	var revokedCount int32
+	if req.RevokeIssuedCoupons {
+		revokedCount, err = s.couponRepo.RevokeByCampaignID(ctx, req.CampaignId)
+		if err != nil {
+			log.Printf("쿠폰 회수 실패: %v", err)
+			return &coupon.CancelCampaignResponse{
+				Success:  false,
+				Campaign: campaign,
+				Message:  "캠페인은 취소되었으나 쿠폰 회수 중 오류가 발생했습니다",
+			}, err
+		}
+	}
+
+	log.Printf("캠페인이 취소되었습니다. ID: %s, 회수된 쿠폰: %d개", req.CampaignId, revokedCount)
+	s.scheduler.Notify(req.CampaignId)
+
+	return &coupon.CancelCampaignResponse{
+		Success:      true,
+		Campaign:     campaign,
+		RevokedCount: revokedCount,
+		Message:      "캠페인이 취소되었습니다",
+	}, nil
+}
+
+func (s *CouponService) UpdateCampaign(
+	ctx context.Context,
+	req *coupon.UpdateCampaignRequest,
+) (*coupon.UpdateCampaignResponse, error) {
+
+	// 입력 검증
+	validation := validateUpdateCampaignRequest(req, s.clock.Now())
+	if !validation.IsValid {
+		return &coupon.UpdateCampaignResponse{
+			Success: false,
+			Message: validation.Message,
+		}, validation.Err()
+	}
+
+	changes := model.CampaignChanges{
+		Name:          req.Name,
+		TotalQuantity: req.TotalQuantity,
+	}
+	if req.StartTime != nil || req.StartTimeMs != nil {
+		startTime := model.Millis(req.GetStartTime(), req.GetStartTimeMs())
+		changes.StartTime = &startTime
+	}
+
+	campaign, err := s.campaignRepo.Modify(ctx, req.CampaignId, func(c *model.Campaign) error {
+		if err := c.ApplyChanges(req.ExpectedVersion, changes, s.clock.Now()); err != nil {
+			return err
+		}
+
+		// 늘어난 수량도 코드 형식이 만들 수 있는 코드 수 안이어야 함 (실패하면 변경 내용은 저장되지 않음)
+		// POOL 형식은 ApplyChanges 가 수량 변경을 거절하므로 확인할 코드 공간이 없음
+		if c.CodeFormat == coupon.CodeFormat_CODE_FORMAT_POOL {
+			return nil
+		}
+		codeGenerator, err := s.codeGen.ForCampaign(c.Campaign)
+		if err != nil {
+			return model.InvalidArgument(err.Error())
+		}
+		return validateCodeKeyspace(codeGenerator, c.TotalQuantity).Err()
+	})
+	if model.IsDomainError(err) {
+		return &coupon.UpdateCampaignResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+	if err != nil {
+		log.Printf("캠페인 수정 실패: %v", err)
+		return &coupon.UpdateCampaignResponse{
+			Success: false,
+			Message: "캠페인 수정 처리 중 오류가 발생했습니다",
+		}, err
+	}
+
+	log.Printf("캠페인이 수정되었습니다. ID: %s, version: %d", req.CampaignId, campaign.Version)
+	s.scheduler.Notify(req.CampaignId)
+
+	return &coupon.UpdateCampaignResponse{
+		Success:  true,
+		Campaign: campaign,
+		Message:  "캠페인이 성공적으로 수정되었습니다",
+	}, nil
+}
+
+// ImportCouponCodes 스트림으로 받은 코드를 모두 검증한 뒤 한 번에 캠페인 코드 풀에 등록
+// 하나라도 등록할 수 없는 코드가 있으면 아무 코드도 등록하지 않고 거절된 코드를 응답 (수정 후 전체를 다시 보내면 됨)
+// receive 는 다음 메시지를 반환하며, 클라이언트가 전송을 마치면 io.EOF
+func (s *CouponService) ImportCouponCodes(
+	ctx context.Context,
+	receive func() (*coupon.ImportCouponCodesRequest, error),
+) (*coupon.ImportCouponCodesResponse, error) {
+
+	var campaignID string
+	var codes []string
+	seen := make(map[string]struct{})
+	var rejected []string
+	rejectedCount := 0
+
+	for {
+		req, err := receive()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// 입력 검증
+		validation := validateImportCouponCodesRequest(req, campaignID, len(codes)+rejectedCount)
+		if !validation.IsValid {
+			return &coupon.ImportCouponCodesResponse{
+				Success: false,
+				Message: validation.Message,
+			}, validation.Err()
+		}
+		if campaignID == "" {
+			campaignID = req.CampaignId
+		}
+
+		for _, input := range req.Codes {
+			// 정규형이 같은 코드는 같은 코드로 보고 중복 처리. 거절 목록에는 입력한 그대로 돌려줌
+			code := model.NormalizeCouponCode(input)
+			_, duplicated := seen[code]
+			if duplicated || !isValidPoolCode(code) {
+				rejectedCount++
+				if len(rejected) < maxRejectedCodes {
+					rejected = append(rejected, input)
+				}
+				continue
+			}
+
+			seen[code] = struct{}{}
+			codes = append(codes, code)
+		}
+	}
+
+	validation := validateImportedCodes(campaignID, rejectedCount, len(codes))
+	if !validation.IsValid {
+		return &coupon.ImportCouponCodesResponse{
+			Success:       false,
+			RejectedCodes: rejected,
+			Message:       validation.Message,
+		}, validation.Err()
+	}
+
+	campaign, err := s.couponRepo.ImportCodePool(ctx, campaignID, codes)
+
+	var conflict *repository.CodePoolConflictError
+	switch {
+	case errors.As(err, &conflict):
+		err = model.NewDomainError(coupon.ErrorReason_ERROR_REASON_CODE_CONFLICT, "이미 발급되었거나 다른 캠페인에 등록된 코드가 있습니다")
+		return &coupon.ImportCouponCodesResponse{
+			Success:       false,
+			RejectedCodes: conflict.Codes,
+			Message:       err.Error(),
+		}, err
+
+	case errors.Is(err, repository.ErrDuplicateCouponCode):
+		err = model.NewDomainError(coupon.ErrorReason_ERROR_REASON_CODE_CONFLICT, "다른 요청이 같은 코드를 먼저 등록했습니다. 다시 시도해 주세요")
+		return &coupon.ImportCouponCodesResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+
+	case model.IsDomainError(err):
+		return &coupon.ImportCouponCodesResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+
+	case err != nil:
+		log.Printf("쿠폰 코드 등록 실패: %v", err)
+		return &coupon.ImportCouponCodesResponse{
+			Success: false,
+			Message: "쿠폰 코드 등록 중 오류가 발생했습니다",
+		}, err
+	}
+
+	log.Printf("쿠폰 코드가 등록되었습니다. 캠페인: %s, 등록: %d개, 총 수량: %d", campaignID, len(codes), campaign.TotalQuantity)
+	s.scheduler.Notify(campaignID)
+
+	return &coupon.ImportCouponCodesResponse{
+		Success:       true,
+		Campaign:      campaign,
+		ImportedCount: int32(len(codes)),
+		Message:       fmt.Sprintf("쿠폰 코드 %d개가 등록되었습니다", len(codes)),
+	}, nil
+}
+
+// issueWithUniqueCode 코드를 생성해서 발급을 시도하고, 저장소가 코드 중복으로 거절하면 새 코드로 다시 시도
+// 저장소가 코드 예약과 발급을 원자적으로 처리하므로 미리 GetByCode 로 확인하지 않음 (확인과 저장 사이의 경쟁 방지)
+func (s *CouponService) issueWithUniqueCode(
+	ctx context.Context,
+	campaignID,
+	userID string,
+) (*coupon.Coupon, error) {
+
+	// 캠페인 조회 (코드 형식 용)
 	campaign, err := s.campaignRepo.GetByID(ctx, campaignID)
+	if errors.Is(err, model.ErrCampaignNotFound) {
+		return nil, model.ErrCampaignNotFound
+	}
 	if err != nil {
-		return "", fmt.Errorf("캠페인 조회 실패: %w", err)
+		return nil, err
 	}
 
-	// 중복 검사 함수 정의
-	checkDuplicate := func(code string) bool {
-		_, err := s.couponRepo.GetByCode(ctx, code)
-		return err == nil // 에러가 없으면 중복 (이미 존재하기 때문)
+	// 등록한 코드 목록에서 꺼내므로 재생성이 필요 없음
+	if campaign.CodeFormat == coupon.CodeFormat_CODE_FORMAT_POOL {
+		return s.couponRepo.IssuePooledCoupon(ctx, campaignID, userID)
 	}
 
-	couponCode, err := s.codeGen.GenerateUniqueCode(campaign.Name, checkDuplicate)
+	codeGenerator, err := s.codeGen.ForCampaign(campaign)
 	if err != nil {
-		return "", fmt.Errorf("쿠폰 코드 생성 실패: %w", err)
+		return nil, fmt.Errorf("캠페인 코드 형식 오류: %w", err)
+	}
+
+	if s.keyedCodeGen != nil {
+		return s.issueWithSequencedCode(ctx, campaign.CampaignId, codeGenerator, userID)
+	}
+
+	for attempt := 1; attempt <= maxCodeAttempts; attempt++ {
+		couponCode, err := codeGenerator.Generate()
+		if err != nil {
+			return nil, fmt.Errorf("쿠폰 코드 생성 실패: %w", err)
+		}
+
+		codeMetrics.Add(metricCodeAttempts, 1)
+
+		issuedCoupon, err := s.couponRepo.IssueCoupon(ctx, campaignID, userID, couponCode)
+		if !errors.Is(err, repository.ErrDuplicateCouponCode) {
+			return issuedCoupon, err
+		}
+
+		codeMetrics.Add(metricCodeCollisions, 1)
+		log.Printf("쿠폰 코드 충돌로 재생성. 캠페인: %s, 코드: %s, 시도: %d/%d", campaignID, couponCode, attempt, maxCodeAttempts)
+	}
+
+	codeMetrics.Add(metricCodeExhausted, 1)
+	return nil, fmt.Errorf("쿠폰 코드 중복 방지를 위한 최대 시도 횟수(%d) 초과", maxCodeAttempts)
+}
+
+// issueWithSequencedCode 저장소가 발급과 함께 증가시키는 캠페인 코드 순번으로 코드를 만들어 발급 (코드 재생성 없음)
+func (s *CouponService) issueWithSequencedCode(
+	ctx context.Context,
+	campaignID string,
+	codeGenerator CodeGenerator,
+	userID string,
+) (*coupon.Coupon, error) {
+
+	codeMetrics.Add(metricCodeAttempts, 1)
+
+	issuedCoupon, err := s.couponRepo.IssueSequencedCoupon(ctx, campaignID, userID, func(sequence int64) (string, error) {
+		return s.keyedCodeGen.CodeAt(campaignID, codeGenerator, sequence)
+	})
+	if errors.Is(err, errCodeSpaceExhausted) || errors.Is(err, repository.ErrDuplicateCouponCode) {
+		codeMetrics.Add(metricCodeExhausted, 1)
 	}
 
-	return couponCode, nil
+	return issuedCoupon, err
+}
+
+// campaignLookupError 캠페인 조회 실패를 응답 메시지와 오류로 변환 (캠페인이 없으면 ErrCampaignNotFound, 그 밖에는 저장소 오류 그대로)
+func campaignLookupError(err error) (string, error) {
+	if errors.Is(err, model.ErrCampaignNotFound) {
+		return ErrCampaignNotFound.Message, ErrCampaignNotFound
+	}
+	return "캠페인 조회 중 오류가 발생했습니다", err
 }
@@ -0,0 +1,982 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"coupon-issuance-system/gen/coupon"
+	"coupon-issuance-system/internal/clock"
+	"coupon-issuance-system/internal/model"
+	"coupon-issuance-system/internal/repository"
+	"golang.org/x/text/unicode/norm"
+	"google.golang.org/protobuf/proto"
+)
+
+func newTestService() (*CouponService, *repository.MemoryCampaignRepository) {
+	return newTestServiceWithClock(clock.System())
+}
+
+// newTestServiceWithClock 서비스와 저장소가 모두 clk 를 기준으로 동작
+func newTestServiceWithClock(clk clock.Clock) (*CouponService, *repository.MemoryCampaignRepository) {
+	campaignRepo := repository.NewMemoryCampaignRepository(clk)
+	couponRepo := repository.NewMemoryCouponRepository(campaignRepo)
+	svc := NewCouponService(campaignRepo, couponRepo, NewCouponCodeGenerator(), nil, NewIdempotencyStore(time.Minute, clk), clk, nil)
+	return svc, campaignRepo
+}
+
+// 같은 멱등성 키로 동시에 재시도해도 쿠폰은 한 번만 발급되고 모두 같은 응답을 받음
+func TestIssueCouponIdempotencyKey(t *testing.T) {
+	svc, campaignRepo := newTestService()
+	ctx := context.Background()
+
+	campaignRepo.Save(ctx, &coupon.Campaign{
+		CampaignId:    "s1",
+		Name:          "멱등성",
+		TotalQuantity: 10,
+		MaxPerUser:    10,
+		Status:        coupon.CampaignStatus_ACTIVE,
+		StartTime:     time.Now().Unix(),
+	})
+
+	req := func() *coupon.IssueCouponRequest {
+		return &coupon.IssueCouponRequest{CampaignId: "s1", UserId: "retry-user", IdempotencyKey: "key-1"}
+	}
+
+	numRequests := 20
+	var wg sync.WaitGroup
+	codes := make([]string, numRequests)
+
+	for i := 0; i < numRequests; i++ {
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+
+			resp, err := svc.IssueCoupon(ctx, req())
+			if err != nil || !resp.Success {
+				t.Errorf("재시도 요청 실패: %v, %v", err, resp)
+				return
+			}
+			codes[index] = resp.Coupon.CouponCode
+		}(i)
+	}
+
+	wg.Wait()
+
+	for _, code := range codes {
+		if code != codes[0] {
+			t.Fatalf("재시도마다 다른 쿠폰이 반환됨: %s vs %s", codes[0], code)
+		}
+	}
+
+	campaign, _ := campaignRepo.GetByID(ctx, "s1")
+	if campaign.IssuedQuantity != 1 {
+		t.Errorf("예상 발급 수량: 1, 실제: %d", campaign.IssuedQuantity)
+	}
+
+	// 다른 키는 새로 발급
+	resp, _ := svc.IssueCoupon(ctx, &coupon.IssueCouponRequest{CampaignId: "s1", UserId: "retry-user", IdempotencyKey: "key-2"})
+	if !resp.Success || resp.Coupon.CouponCode == codes[0] {
+		t.Errorf("다른 멱등성 키의 요청이 새로 발급되지 않음: %v", resp)
+	}
+}
+
+// 발급/사용 실패는 기존 응답 메시지와 함께 실패 종류를 구분할 수 있는 도메인 오류로 반환
+// 멱등성 키 재요청은 최초 요청과 같은 오류를 돌려받음
+func TestIssueAndRedeemDomainErrors(t *testing.T) {
+	svc, campaignRepo := newTestService()
+	ctx := context.Background()
+	now := time.Now().Unix()
+
+	campaignRepo.Save(ctx, &coupon.Campaign{CampaignId: "e1", Name: "대기", TotalQuantity: 1, Status: coupon.CampaignStatus_WAITING, StartTime: now + 3600})
+	campaignRepo.Save(ctx, &coupon.Campaign{CampaignId: "e2", Name: "한정", TotalQuantity: 2, MaxPerUser: 1, Status: coupon.CampaignStatus_ACTIVE, StartTime: now})
+
+	issue := func(campaignID, userID, key string, expected *model.DomainError) *coupon.IssueCouponResponse {
+		t.Helper()
+		resp, err := svc.IssueCoupon(ctx, &coupon.IssueCouponRequest{CampaignId: campaignID, UserId: userID, IdempotencyKey: key})
+		if expected == nil {
+			if err != nil || !resp.Success {
+				t.Fatalf("발급 실패: %v, %v", resp, err)
+			}
+			return resp
+		}
+		if !errors.Is(err, expected) || resp.Success || resp.Message != err.Error() {
+			t.Errorf("예상 오류 %s, 실제: %v, %v", expected.Reason, resp, err)
+		}
+		return resp
+	}
+
+	issue("", "user-1", "", model.ErrInvalidArgument)
+	issue("missing", "user-1", "", model.ErrCampaignNotFound)
+	issue("e1", "user-1", "", model.ErrCampaignNotStarted)
+
+	issued := issue("e2", "user-1", "", nil)
+	issue("e2", "user-1", "", model.ErrUserLimitExceeded)
+	issue("e2", "user-2", "", nil)
+
+	first := issue("e2", "user-3", "sold-out", model.ErrCampaignSoldOut)
+	if replay := issue("e2", "user-3", "sold-out", model.ErrCampaignSoldOut); replay.Message != first.Message {
+		t.Errorf("멱등성 키 재요청 응답이 다름: %v vs %v", first, replay)
+	}
+
+	redeem := func(code, userID string, expected *model.DomainError) {
+		t.Helper()
+		resp, err := svc.RedeemCoupon(ctx, &coupon.RedeemCouponRequest{CouponCode: code, UserId: userID, OrderId: "order-1"})
+		if expected == nil {
+			if err != nil || !resp.Success {
+				t.Fatalf("사용 실패: %v, %v", resp, err)
+			}
+			return
+		}
+		if !errors.Is(err, expected) || resp.Success || resp.Message != err.Error() {
+			t.Errorf("예상 오류 %s, 실제: %v, %v", expected.Reason, resp, err)
+		}
+	}
+
+	code := issued.Coupon.CouponCode
+	redeem("없는코드0000", "user-1", model.ErrCouponNotFound)
+	redeem(code, "user-2", model.ErrCouponNotOwned)
+	redeem(code, "user-1", nil)
+	redeem(code, "user-1", model.ErrCouponAlreadyRedeemed)
+}
+
+// 시작/종료 판정, 시작 시간 검증, 발급/사용 시각, 멱등성 키 보관 기간이 모두 주입된 시계를 따름 (기다리지 않고 시간을 옮겨 확인)
+func TestCampaignLifecycleWithFakeClock(t *testing.T) {
+	fake := clock.NewFake(time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC))
+	svc, _ := newTestServiceWithClock(fake)
+	ctx := context.Background()
+
+	// 실제 시간으로는 이미 지난 시각이지만 시계 기준으로는 1시간 뒤
+	created, err := svc.CreateCampaign(ctx, &coupon.CreateCampaignRequest{
+		Name:          "오픈 리허설",
+		TotalQuantity: 10,
+		MaxPerUser:    2,
+		StartTime:     fake.Now().Add(time.Hour).Unix(),
+		EndTime:       fake.Now().Add(2 * time.Hour).Unix(),
+	})
+	if err != nil || created.Campaign.Status != coupon.CampaignStatus_WAITING {
+		t.Fatalf("캠페인 생성 결과가 다름: %v, %v", created, err)
+	}
+	campaignID := created.Campaign.CampaignId
+
+	issue := func(key string) (*coupon.IssueCouponResponse, error) {
+		return svc.IssueCoupon(ctx, &coupon.IssueCouponRequest{CampaignId: campaignID, UserId: "user-1", IdempotencyKey: key})
+	}
+
+	if _, err := issue(""); !errors.Is(err, model.ErrCampaignNotStarted) {
+		t.Errorf("시작 전 발급 실패 사유가 다름: %v", err)
+	}
+
+	fake.Advance(time.Hour)
+
+	first, err := issue("key-1")
+	if err != nil || first.Coupon.IssuedAt != fake.Now().Unix() {
+		t.Fatalf("시작 시간이 지난 뒤 발급 실패 또는 발급 시각이 다름: %v, %v", first, err)
+	}
+
+	redeemed, err := svc.RedeemCoupon(ctx, &coupon.RedeemCouponRequest{CouponCode: first.Coupon.CouponCode, UserId: "user-1", OrderId: "order-1"})
+	if err != nil || redeemed.Coupon.RedeemedAt != fake.Now().Unix() {
+		t.Errorf("사용 시각이 다름: %v, %v", redeemed, err)
+	}
+
+	// 보관 기간 안에는 같은 응답, 지나면 새로 발급
+	if replay, _ := issue("key-1"); replay.Coupon.CouponCode != first.Coupon.CouponCode {
+		t.Errorf("보관 기간 안의 재요청이 다시 발급됨: %v", replay)
+	}
+	fake.Advance(2 * time.Minute)
+	if again, err := issue("key-1"); err != nil || again.Coupon.CouponCode == first.Coupon.CouponCode {
+		t.Errorf("보관 기간이 지난 멱등성 키로 새로 발급되지 않음: %v, %v", again, err)
+	}
+
+	fake.Advance(time.Hour)
+
+	if _, err := issue(""); !errors.Is(err, model.ErrCampaignEnded) {
+		t.Errorf("종료 후 발급 실패 사유가 다름: %v", err)
+	}
+}
+
+// 시작 시간은 밀리초 단위로 판정하고, 초 단위 필드만 보내는 클라이언트도 그대로 동작
+func TestMillisecondStartTime(t *testing.T) {
+	fake := clock.NewFake(time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC))
+	svc, _ := newTestServiceWithClock(fake)
+	ctx := context.Background()
+
+	created, err := svc.CreateCampaign(ctx, &coupon.CreateCampaignRequest{
+		Name:          "정각 오픈",
+		TotalQuantity: 10,
+		StartTimeMs:   fake.Now().Add(1500 * time.Millisecond).UnixMilli(),
+	})
+	if err != nil || created.Campaign.StartTime != fake.Now().Add(time.Second).Unix() {
+		t.Fatalf("캠페인 생성 결과가 다름: %v, %v", created, err)
+	}
+
+	issue := func() (*coupon.IssueCouponResponse, error) {
+		return svc.IssueCoupon(ctx, &coupon.IssueCouponRequest{CampaignId: created.Campaign.CampaignId, UserId: fmt.Sprintf("user-%d", fake.Now().UnixMilli())})
+	}
+
+	// 초 단위로는 시작 시간과 같은 초지만 아직 500ms 전
+	fake.Advance(time.Second)
+	if _, err := issue(); !errors.Is(err, model.ErrCampaignNotStarted) {
+		t.Errorf("시작 시간 전 발급 실패 사유가 다름: %v", err)
+	}
+
+	fake.Advance(500 * time.Millisecond)
+	issued, err := issue()
+	if err != nil || issued.Coupon.IssuedAtMs != fake.Now().UnixMilli() || issued.Coupon.IssuedAt != fake.Now().Unix() {
+		t.Fatalf("시작 시각 발급 실패 또는 발급 시각이 다름: %v, %v", issued, err)
+	}
+
+	// 초 단위 필드만 보낸 요청은 현재 초도 허용하고 밀리초 필드를 채워 응답
+	legacy, err := svc.CreateCampaign(ctx, &coupon.CreateCampaignRequest{Name: "초 단위", TotalQuantity: 10, StartTime: fake.Now().Unix()})
+	if err != nil || legacy.Campaign.Status != coupon.CampaignStatus_ACTIVE || legacy.Campaign.StartTimeMs != fake.Now().Unix()*1000 {
+		t.Errorf("초 단위 요청 처리 결과가 다름: %v, %v", legacy, err)
+	}
+
+	// 두 필드를 모두 보내면 같은 시각이어야 함
+	_, err = svc.CreateCampaign(ctx, &coupon.CreateCampaignRequest{
+		Name:          "불일치",
+		TotalQuantity: 10,
+		StartTime:     fake.Now().Add(time.Hour).Unix(),
+		StartTimeMs:   fake.Now().Add(2 * time.Hour).UnixMilli(),
+	})
+	var domainErr *model.DomainError
+	if !errors.As(err, &domainErr) || len(domainErr.Violations) != 1 || domainErr.Violations[0].Field != "start_time_ms" {
+		t.Errorf("두 시각 필드 불일치가 거절되지 않음: %v", err)
+	}
+}
+
+// 스케줄러는 시작/종료 시각에 정확히 상태를 전이해 저장하고, 발급으로 소진되거나 수량이 늘어난 변화도 훅으로 알림
+func TestCampaignSchedulerTransitions(t *testing.T) {
+	fake := clock.NewFake(time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC))
+	campaignRepo := repository.NewMemoryCampaignRepository(fake)
+	couponRepo := repository.NewMemoryCouponRepository(campaignRepo)
+
+	transitions := make(chan CampaignTransition, 10)
+	scheduler := NewCampaignScheduler(campaignRepo, fake, SchedulerOptions{})
+	scheduler.OnTransition(func(ctx context.Context, transition CampaignTransition) {
+		transitions <- transition
+	})
+	if err := scheduler.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer scheduler.Close()
+
+	svc := NewCouponService(campaignRepo, couponRepo, NewCouponCodeGenerator(), nil, NewIdempotencyStore(time.Minute, fake), fake, scheduler)
+	ctx := context.Background()
+
+	startTime := fake.Now().Add(time.Minute + 250*time.Millisecond)
+	endTime := fake.Now().Add(time.Hour)
+	created, err := svc.CreateCampaign(ctx, &coupon.CreateCampaignRequest{
+		Name:          "정각 오픈",
+		TotalQuantity: 1,
+		StartTimeMs:   startTime.UnixMilli(),
+		EndTimeMs:     endTime.UnixMilli(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	campaignID := created.Campaign.CampaignId
+
+	expect := func(from, to coupon.CampaignStatus, at time.Time) {
+		t.Helper()
+		select {
+		case transition := <-transitions:
+			if transition.From != from || transition.To != to || !transition.At.Equal(at) {
+				t.Fatalf("상태 전이가 다름: %s → %s (%s), 기대값: %s → %s (%s)", transition.From, transition.To, transition.At, from, to, at)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("상태 전이 훅이 호출되지 않음: %s → %s", from, to)
+		}
+	}
+	storedStatus := func() coupon.CampaignStatus {
+		var status coupon.CampaignStatus
+		campaignRepo.Modify(ctx, campaignID, func(c *model.Campaign) error {
+			status = c.Status // lazy evaluation 없이 저장된 상태
+			return errors.New("조회만 함")
+		})
+		return status
+	}
+
+	// 시작 시간이 속한 초에 들어섰지만 아직 250ms 전
+	fake.Advance(time.Minute)
+	select {
+	case transition := <-transitions:
+		t.Fatalf("시작 시간 전에 상태가 전이됨: %+v", transition)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	fake.Advance(250 * time.Millisecond)
+	expect(coupon.CampaignStatus_WAITING, coupon.CampaignStatus_ACTIVE, startTime)
+	if status := storedStatus(); status != coupon.CampaignStatus_ACTIVE {
+		t.Errorf("저장된 상태가 다름: %s", status)
+	}
+
+	if _, err := svc.IssueCoupon(ctx, &coupon.IssueCouponRequest{CampaignId: campaignID, UserId: "user-1"}); err != nil {
+		t.Fatal(err)
+	}
+	expect(coupon.CampaignStatus_ACTIVE, coupon.CampaignStatus_COMPLETED, fake.Now())
+
+	current, _ := svc.GetCampaign(ctx, &coupon.GetCampaignRequest{CampaignId: campaignID})
+	if _, err := svc.UpdateCampaign(ctx, &coupon.UpdateCampaignRequest{
+		CampaignId:      campaignID,
+		ExpectedVersion: current.Campaign.Version,
+		TotalQuantity:   proto.Int32(2),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	expect(coupon.CampaignStatus_COMPLETED, coupon.CampaignStatus_ACTIVE, fake.Now())
+
+	fake.Set(endTime)
+	expect(coupon.CampaignStatus_ACTIVE, coupon.CampaignStatus_ENDED, endTime)
+	if status := storedStatus(); status != coupon.CampaignStatus_ENDED {
+		t.Errorf("저장된 상태가 다름: %s", status)
+	}
+}
+
+// 캠페인 생성/수정 요청의 값 오류는 첫 오류에서 멈추지 않고 필드별로 모두 반환
+func TestCampaignRequestFieldViolations(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+	now := time.Now()
+
+	violatedFields := func(err error) []string {
+		var domainErr *model.DomainError
+		if !errors.As(err, &domainErr) || !errors.Is(err, model.ErrInvalidArgument) {
+			t.Fatalf("요청 값 오류가 아님: %v", err)
+		}
+		fields := make([]string, len(domainErr.Violations))
+		for i, violation := range domainErr.Violations {
+			fields[i] = violation.Field
+		}
+		return fields
+	}
+
+	resp, err := svc.CreateCampaign(ctx, &coupon.CreateCampaignRequest{
+		Name:          strings.Repeat("가", maxCampaignNameLength+1),
+		TotalQuantity: maxTotalQuantity + 1,
+		MaxPerUser:    -1,
+		StartTime:     now.Add(maxStartTimeHorizon + time.Hour).Unix(),
+		EndTime:       now.Unix(),
+	})
+	expected := []string{"name", "total_quantity", "max_per_user", "start_time", "end_time"}
+	if fields := violatedFields(err); !slices.Equal(fields, expected) || resp.Campaign != nil || resp.Message != err.Error() {
+		t.Errorf("위반 필드 예상 %v, 실제 %v (%s)", expected, fields, resp.Message)
+	}
+
+	// 한도 안의 값은 허용
+	created, err := svc.CreateCampaign(ctx, &coupon.CreateCampaignRequest{
+		Name:          strings.Repeat("가", maxCampaignNameLength),
+		TotalQuantity: 100,
+		StartTime:     now.Add(maxStartTimeHorizon - time.Hour).Unix(),
+	})
+	if err != nil || created.Campaign == nil {
+		t.Fatalf("캠페인 생성 실패: %v, %v", created, err)
+	}
+
+	_, err = svc.UpdateCampaign(ctx, &coupon.UpdateCampaignRequest{
+		CampaignId:      created.Campaign.CampaignId,
+		ExpectedVersion: created.Campaign.Version,
+		Name:            proto.String(""),
+		StartTime:       proto.Int64(now.Unix() - 60),
+		TotalQuantity:   proto.Int32(0),
+	})
+	expected = []string{"name", "start_time", "total_quantity"}
+	if fields := violatedFields(err); !slices.Equal(fields, expected) {
+		t.Errorf("수정 요청 위반 필드 예상 %v, 실제 %v", expected, fields)
+	}
+
+	// 변경할 항목이 없어도 다른 위반 사항과 함께 반환
+	_, err = svc.UpdateCampaign(ctx, &coupon.UpdateCampaignRequest{})
+	expected = []string{"campaign_id", "expected_version", "name"}
+	if fields := violatedFields(err); !slices.Equal(fields, expected) {
+		t.Errorf("빈 수정 요청 위반 필드 예상 %v, 실제 %v", expected, fields)
+	}
+}
+
+// 페이지를 넘기는 도중 캠페인이 추가돼도 중복/누락 없이 created_at 순으로 조회
+func TestListCampaignsPagination(t *testing.T) {
+	svc, campaignRepo := newTestService()
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		campaignRepo.Save(ctx, &coupon.Campaign{
+			CampaignId: fmt.Sprintf("list_%d", i),
+			Name:       fmt.Sprintf("목록 캠페인 %d", i),
+			CreatedAt:  int64(1000 + i),
+			Status:     coupon.CampaignStatus_COMPLETED,
+		})
+	}
+	campaignRepo.Save(ctx, &coupon.Campaign{CampaignId: "other", Name: "다른 이름", CreatedAt: 999})
+
+	var seen []string
+	pageToken := ""
+	for page := 0; ; page++ {
+		resp, err := svc.ListCampaigns(ctx, &coupon.ListCampaignsRequest{
+			NameContains: "목록",
+			PageSize:     2,
+			PageToken:    pageToken,
+		})
+		if err != nil || resp.Message != "조회 성공" {
+			t.Fatalf("목록 조회 실패: %v, %s", err, resp.Message)
+		}
+		for _, c := range resp.Campaigns {
+			seen = append(seen, c.CampaignId)
+		}
+
+		// 첫 페이지 이후 새 캠페인 추가
+		if page == 0 {
+			campaignRepo.Save(ctx, &coupon.Campaign{
+				CampaignId: "list_new", Name: "목록 신규", CreatedAt: 2000, Status: coupon.CampaignStatus_COMPLETED,
+			})
+		}
+
+		if resp.NextPageToken == "" {
+			break
+		}
+		pageToken = resp.NextPageToken
+	}
+
+	expected := []string{"list_0", "list_1", "list_2", "list_3", "list_4", "list_new"}
+	if !slices.Equal(seen, expected) {
+		t.Errorf("예상: %v, 실제: %v", expected, seen)
+	}
+
+	// 다른 조건으로 토큰 재사용 불가
+	first, _ := svc.ListCampaigns(ctx, &coupon.ListCampaignsRequest{NameContains: "목록", PageSize: 1})
+	resp, _ := svc.ListCampaigns(ctx, &coupon.ListCampaignsRequest{NameContains: "다른", PageToken: first.NextPageToken})
+	if len(resp.Campaigns) != 0 || resp.Message == "조회 성공" {
+		t.Errorf("조건이 다른 페이지 토큰이 허용됨: %v", resp)
+	}
+}
+
+// 발급 쿠폰을 페이지/스트림 단위로 나눠도 발급 순서 그대로 전부 조회
+func TestListAndStreamIssuedCoupons(t *testing.T) {
+	svc, campaignRepo := newTestService()
+	ctx := context.Background()
+
+	campaignRepo.Save(ctx, &coupon.Campaign{
+		CampaignId:    "s2",
+		Name:          "스트림",
+		TotalQuantity: 7,
+		Status:        coupon.CampaignStatus_ACTIVE,
+		StartTime:     time.Now().Unix(),
+	})
+
+	var issued []string
+	for i := 0; i < 7; i++ {
+		resp, _ := svc.IssueCoupon(ctx, &coupon.IssueCouponRequest{CampaignId: "s2", UserId: fmt.Sprintf("user-%d", i)})
+		if !resp.Success {
+			t.Fatalf("쿠폰 발급 실패: %s", resp.Message)
+		}
+		issued = append(issued, resp.Coupon.CouponCode)
+	}
+
+	var paged []string
+	pageToken := ""
+	for {
+		resp, err := svc.ListIssuedCoupons(ctx, &coupon.ListIssuedCouponsRequest{CampaignId: "s2", PageSize: 3, PageToken: pageToken})
+		if err != nil || resp.Message != "조회 성공" {
+			t.Fatalf("쿠폰 목록 조회 실패: %v, %s", err, resp.Message)
+		}
+		for _, c := range resp.Coupons {
+			paged = append(paged, c.CouponCode)
+		}
+		if resp.NextPageToken == "" {
+			break
+		}
+		pageToken = resp.NextPageToken
+	}
+	if !slices.Equal(paged, issued) {
+		t.Errorf("페이지 조회 결과가 발급 순서와 다름: %v vs %v", paged, issued)
+	}
+
+	var streamed []string
+	batches := 0
+	err := svc.StreamIssuedCoupons(ctx, &coupon.StreamIssuedCouponsRequest{CampaignId: "s2", BatchSize: 3},
+		func(batch *coupon.StreamIssuedCouponsResponse) error {
+			batches++
+			for _, c := range batch.Coupons {
+				streamed = append(streamed, c.CouponCode)
+			}
+			return nil
+		})
+	if err != nil || batches != 3 || !slices.Equal(streamed, issued) {
+		t.Errorf("스트림 조회 결과가 발급 순서와 다름: %v, 묶음 %d개, %v", err, batches, streamed)
+	}
+
+	// 카운터만 조회
+	resp, _ := svc.GetCampaign(ctx, &coupon.GetCampaignRequest{CampaignId: "s2", ExcludeCoupons: true})
+	if len(resp.IssuedCoupons) != 0 || resp.Campaign.IssuedQuantity != 7 {
+		t.Errorf("카운터만 조회 실패: %v", resp)
+	}
+}
+
+// collidingCouponRepository 처음 collisions 번의 발급을 코드 중복으로 거절하는 저장소
+type collidingCouponRepository struct {
+	repository.CouponRepository
+	mutex      sync.Mutex
+	collisions int
+}
+
+func (r *collidingCouponRepository) IssueCoupon(ctx context.Context, campaignID, userID, couponCode string) (*coupon.Coupon, error) {
+	r.mutex.Lock()
+	if r.collisions > 0 {
+		r.collisions--
+		r.mutex.Unlock()
+		return nil, repository.ErrDuplicateCouponCode
+	}
+	r.mutex.Unlock()
+
+	return r.CouponRepository.IssueCoupon(ctx, campaignID, userID, couponCode)
+}
+
+// 저장소가 코드 중복으로 거절하면 새 코드로 다시 시도하고 충돌 지표를 올림. 한도를 넘기면 실패
+func TestIssueCouponRegeneratesOnCodeCollision(t *testing.T) {
+	campaignRepo := repository.NewMemoryCampaignRepository(clock.System())
+	couponRepo := &collidingCouponRepository{CouponRepository: repository.NewMemoryCouponRepository(campaignRepo), collisions: 3}
+	svc := NewCouponService(campaignRepo, couponRepo, NewCouponCodeGenerator(), nil, NewIdempotencyStore(time.Minute, clock.System()), clock.System(), nil)
+	ctx := context.Background()
+
+	campaignRepo.Save(ctx, &coupon.Campaign{
+		CampaignId:    "s4",
+		Name:          "충돌",
+		TotalQuantity: 10,
+		Status:        coupon.CampaignStatus_ACTIVE,
+		StartTime:     time.Now().Unix(),
+	})
+
+	collisionsBefore := CodeMetricValue(metricCodeCollisions)
+
+	resp, err := svc.IssueCoupon(ctx, &coupon.IssueCouponRequest{CampaignId: "s4", UserId: "user-1"})
+	if err != nil || !resp.Success {
+		t.Fatalf("충돌 후 재생성 발급 실패: %v, %v", err, resp)
+	}
+	if got := CodeMetricValue(metricCodeCollisions) - collisionsBefore; got != 3 {
+		t.Errorf("충돌 지표 예상 3, 실제 %d", got)
+	}
+
+	exhaustedBefore := CodeMetricValue(metricCodeExhausted)
+	couponRepo.collisions = maxCodeAttempts
+
+	resp, err = svc.IssueCoupon(ctx, &coupon.IssueCouponRequest{CampaignId: "s4", UserId: "user-2"})
+	if err == nil || resp.Success {
+		t.Fatalf("재생성 한도 초과가 실패로 처리되지 않음: %v", resp)
+	}
+	if got := CodeMetricValue(metricCodeExhausted) - exhaustedBefore; got != 1 {
+		t.Errorf("한도 초과 지표 예상 1, 실제 %d", got)
+	}
+}
+
+// 키 기반 생성기는 [0, 코드 수) 위의 순열이고, 순번이 이어져도 코드는 이어지지 않음
+func TestKeyedCodeGeneratorIsPermutation(t *testing.T) {
+	generator, err := NewKeyedCodeGenerator([]byte("0123456789abcdef"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 작은 범위에서 전체 순열 확인 (cycle walking 포함)
+	const capacity = 1000
+	seen := make(map[uint64]bool)
+	for value := uint64(0); value < capacity; value++ {
+		permuted := generator.permute("c1", value, capacity)
+		if permuted >= capacity || seen[permuted] {
+			t.Fatalf("순열이 아님: %d → %d", value, permuted)
+		}
+		seen[permuted] = true
+	}
+
+	format, err := NewCouponCodeGenerator().ForCampaign(&coupon.Campaign{Name: "신규가입"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	codes := make(map[string]int64)
+	var previous string
+	var ascending int
+	for sequence := int64(0); sequence < 20000; sequence++ {
+		code, err := generator.CodeAt("c1", format, sequence)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len([]rune(code)) != 10 || !strings.HasPrefix(code, "신규가") {
+			t.Fatalf("코드 형식 오류: %s", code)
+		}
+		if other, exists := codes[code]; exists {
+			t.Fatalf("순번 %d 와 %d 의 코드가 같음: %s", other, sequence, code)
+		}
+		codes[code] = sequence
+
+		if code > previous {
+			ascending++
+		}
+		previous = code
+	}
+	if ascending > 15000 {
+		t.Errorf("코드가 순번 순서를 따름 (오름차순 %d/20000)", ascending)
+	}
+
+	other, _ := generator.CodeAt("c2", format, 0)
+	first, _ := generator.CodeAt("c1", format, 0)
+	if other == first {
+		t.Errorf("캠페인이 달라도 같은 순열을 사용함: %s", first)
+	}
+
+	if _, err := generator.CodeAt("c1", format, format.Keyspace()); !errors.Is(err, errCodeSpaceExhausted) {
+		t.Errorf("코드 수를 넘는 순번이 거절되지 않음: %v", err)
+	}
+}
+
+// 키 기반 생성기를 쓰면 발급마다 캠페인 코드 순번이 증가하고, 코드는 그 순번의 코드
+func TestIssueCouponWithKeyedCodes(t *testing.T) {
+	generator, err := NewKeyedCodeGenerator([]byte("0123456789abcdef"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	campaignRepo := repository.NewMemoryCampaignRepository(clock.System())
+	couponRepo := repository.NewMemoryCouponRepository(campaignRepo)
+	svc := NewCouponService(campaignRepo, couponRepo, NewCouponCodeGenerator(), generator, NewIdempotencyStore(time.Minute, clock.System()), clock.System(), nil)
+	ctx := context.Background()
+
+	campaignRepo.Save(ctx, &coupon.Campaign{
+		CampaignId:    "s5",
+		Name:          "순번",
+		TotalQuantity: 50,
+		Status:        coupon.CampaignStatus_ACTIVE,
+		StartTime:     time.Now().Unix(),
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+
+			resp, err := svc.IssueCoupon(ctx, &coupon.IssueCouponRequest{CampaignId: "s5", UserId: fmt.Sprintf("user-%d", index)})
+			if err != nil || !resp.Success {
+				t.Errorf("발급 실패: %v, %v", err, resp)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	campaign, _ := campaignRepo.GetByID(ctx, "s5")
+	if campaign.CodeSequence != 50 {
+		t.Errorf("코드 순번 예상 50, 실제 %d", campaign.CodeSequence)
+	}
+
+	format, _ := NewCouponCodeGenerator().ForCampaign(campaign)
+	for sequence := int64(0); sequence < 50; sequence++ {
+		code, _ := generator.CodeAt("s5", format, sequence)
+		if _, err := couponRepo.GetByCode(ctx, code); err != nil {
+			t.Errorf("순번 %d 의 코드가 발급되지 않음: %s", sequence, code)
+		}
+	}
+}
+
+// 내장 코드 형식은 형식대로 코드를 만들고, 10자 초과/코드 수 부족은 캠페인 생성 시 거절
+func TestCampaignCodeFormats(t *testing.T) {
+	svc, campaignRepo := newTestService()
+	ctx := context.Background()
+
+	formats := []struct {
+		campaign *coupon.Campaign
+		pattern  string
+	}{
+		{&coupon.Campaign{Name: "여름세일"}, `^여름세[가-힣]{2}[0-9]{5}$`},
+		{&coupon.Campaign{CodeFormat: coupon.CodeFormat_CODE_FORMAT_ALPHANUMERIC, CodePrefix: "SUM", CodeLength: 8}, `^SUM[0-9A-Z]{5}$`},
+		{&coupon.Campaign{CodeFormat: coupon.CodeFormat_CODE_FORMAT_UNAMBIGUOUS}, `^[2-9A-HJKMNP-Z]{10}$`},
+		{&coupon.Campaign{CodeFormat: coupon.CodeFormat_CODE_FORMAT_NUMERIC, CodeLength: 6}, `^[0-9]{6}$`},
+		{&coupon.Campaign{CodeFormat: coupon.CodeFormat_CODE_FORMAT_TEMPLATE, CodePrefix: "AB", CodeTemplate: "{PREFIX}-{AAAA}-{9999}"}, `^AB[A-Z]{4}[0-9]{4}$`},
+	}
+
+	for i, format := range formats {
+		campaign := format.campaign
+		campaign.CampaignId = fmt.Sprintf("format-%d", i)
+		campaign.TotalQuantity = 10
+		campaign.Status = coupon.CampaignStatus_ACTIVE
+		campaign.StartTime = time.Now().Unix()
+		campaignRepo.Save(ctx, campaign)
+
+		resp, err := svc.IssueCoupon(ctx, &coupon.IssueCouponRequest{CampaignId: campaign.CampaignId, UserId: "user-1"})
+		if err != nil || !resp.Success {
+			t.Fatalf("%s 형식 발급 실패: %v, %v", campaign.CodeFormat, err, resp)
+		}
+		if !regexp.MustCompile(format.pattern).MatchString(resp.Coupon.CouponCode) {
+			t.Errorf("%s 형식 코드가 %s 와 맞지 않음: %s", campaign.CodeFormat, format.pattern, resp.Coupon.CouponCode)
+		}
+	}
+
+	invalid := []*coupon.CreateCampaignRequest{
+		{CodeFormat: coupon.CodeFormat_CODE_FORMAT_TEMPLATE, CodePrefix: "ABC", CodeTemplate: "{PREFIX}-{AAAA}-{9999}"},
+		{CodeFormat: coupon.CodeFormat_CODE_FORMAT_TEMPLATE, CodeTemplate: "{AAAA}-{ZZ}"},
+		{CodeFormat: coupon.CodeFormat_CODE_FORMAT_ALPHANUMERIC, CodeLength: 11},
+		{CodeFormat: coupon.CodeFormat_CODE_FORMAT_NUMERIC, CodeLength: 2, TotalQuantity: 101},
+		{CodeFormat: coupon.CodeFormat_CODE_FORMAT_TEMPLATE, CodeTemplate: "{9999}{HH}"},
+		{CodeFormat: coupon.CodeFormat_CODE_FORMAT_NUMERIC, CodeCheckChar: true},
+	}
+
+	for _, req := range invalid {
+		req.Name = "형식 오류"
+		req.StartTime = time.Now().Unix() + 60
+		if req.TotalQuantity == 0 {
+			req.TotalQuantity = 10
+		}
+
+		resp, err := svc.CreateCampaign(ctx, req)
+		if !errors.Is(err, model.ErrInvalidArgument) || resp.Campaign != nil {
+			t.Errorf("잘못된 코드 형식이 거절되지 않음: %v, %v, %v", req, resp, err)
+		}
+	}
+}
+
+// 같은 코드 공간을 쓰는 캠페인의 수량까지 합쳐 사용률을 계산하고, 안전 범위를 넘으면 경고 또는 거절
+func TestCreateCampaignKeyspaceUsage(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+
+	create := func(prefix string, quantity int32) *coupon.CreateCampaignResponse {
+		resp, err := svc.CreateCampaign(ctx, &coupon.CreateCampaignRequest{
+			Name:          "코드 공간",
+			StartTime:     time.Now().Unix() + 60,
+			EndTime:       time.Now().Unix() + 3600,
+			TotalQuantity: quantity,
+			CodeFormat:    coupon.CodeFormat_CODE_FORMAT_NUMERIC,
+			CodePrefix:    prefix,
+			CodeLength:    4, // prefix 1자 + 숫자 3자 = 1000개
+		})
+		if err != nil && !errors.Is(err, model.ErrInvalidArgument) {
+			t.Fatalf("캠페인 생성 오류: %v", err)
+		}
+		return resp
+	}
+
+	// 사용률 5%: 경고 없음
+	resp := create("A", 50)
+	if resp.Campaign == nil || resp.Warning != "" || resp.CodeKeyspace != 1000 || resp.CodeSpaceReserved != 0 {
+		t.Fatalf("여유 있는 코드 공간에서 생성 결과가 다름: %v", resp)
+	}
+	if resp.CollisionProbability <= 0 || resp.CollisionProbability >= 1 {
+		t.Errorf("충돌 확률 추정이 범위를 벗어남: %v", resp.CollisionProbability)
+	}
+
+	// 다른 prefix 는 다른 코드 공간
+	resp = create("B", 50)
+	if resp.CodeSpaceReserved != 0 {
+		t.Errorf("다른 코드 공간의 캠페인이 사용량에 포함됨: %d", resp.CodeSpaceReserved)
+	}
+
+	// 50 + 200 = 25%: 생성하되 경고
+	resp = create("A", 200)
+	if resp.Campaign == nil || resp.Warning == "" || resp.CodeSpaceReserved != 50 || resp.CodeSpaceUsage != 0.25 {
+		t.Fatalf("경고 범위에서 생성 결과가 다름: %v", resp)
+	}
+
+	// 250 + 300 = 55%: 이 캠페인만으로는 30% 지만 다른 캠페인과 합쳐 거절
+	resp = create("A", 300)
+	if resp.Campaign != nil || resp.CodeSpaceReserved != 250 || resp.CodeSpaceUsage <= maxKeyspaceUsage {
+		t.Fatalf("안전 범위를 넘는 캠페인이 거절되지 않음: %v", resp)
+	}
+}
+
+// 검사 문자가 있는 코드는 한 글자 오타를 저장소 조회 없이 구분하고, 형식은 맞지만 없는 코드와 구분
+func TestCouponCodeCheckChar(t *testing.T) {
+	svc, campaignRepo := newTestService()
+	ctx := context.Background()
+
+	campaignRepo.Save(ctx, &coupon.Campaign{
+		CampaignId:    "s6",
+		Name:          "전화주문",
+		TotalQuantity: 10,
+		Status:        coupon.CampaignStatus_ACTIVE,
+		StartTime:     time.Now().Unix(),
+		CodeCheckChar: true,
+	})
+
+	issued, err := svc.IssueCoupon(ctx, &coupon.IssueCouponRequest{CampaignId: "s6", UserId: "user-1"})
+	if err != nil || !issued.Success {
+		t.Fatalf("발급 실패: %v, %v", err, issued)
+	}
+	code := []rune(issued.Coupon.CouponCode)
+	if len(code) != 10 || verifyCheckChar(string(code)) != codeCheckValid {
+		t.Fatalf("검사 문자가 붙지 않음: %s", string(code))
+	}
+
+	validate := func(code string) coupon.CodeCheckResult {
+		resp, err := svc.ValidateCoupon(ctx, &coupon.ValidateCouponRequest{CouponCode: code})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.Result
+	}
+
+	if result := validate(string(code)); result != coupon.CodeCheckResult_CODE_CHECK_RESULT_VALID {
+		t.Errorf("발급된 코드 확인 결과: %s", result)
+	}
+
+	// prefix 뒤의 모든 자리에서 같은 종류 문자로 바꾼 한 글자 오타는 모두 검출
+	for position := 3; position < len(code); position++ {
+		alphabet := digitAlphabet
+		if slices.Contains(hangulAlphabet, code[position]) {
+			alphabet = hangulAlphabet
+		}
+
+		for _, typo := range alphabet {
+			if typo == code[position] {
+				continue
+			}
+			mistyped := slices.Clone(code)
+			mistyped[position] = typo
+
+			if result := validate(string(mistyped)); result != coupon.CodeCheckResult_CODE_CHECK_RESULT_MALFORMED {
+				t.Fatalf("오타가 검출되지 않음: %s → %s (%s)", string(code), string(mistyped), result)
+			}
+		}
+	}
+
+	redeem, _ := svc.RedeemCoupon(ctx, &coupon.RedeemCouponRequest{
+		CouponCode: string(code[:9]) + "0", UserId: "user-1", OrderId: "order-1",
+	})
+	if redeem.Success || redeem.Message == mistypedCodeMessage {
+		t.Errorf("검사 문자가 없는 모양의 코드를 오타로 처리함: %v", redeem)
+	}
+
+	unknown := appendCheckChar("전화주가가0000")
+	if unknown == string(code) {
+		unknown = appendCheckChar("전화주가가0001")
+	}
+	if result := validate(unknown); result != coupon.CodeCheckResult_CODE_CHECK_RESULT_NOT_FOUND {
+		t.Errorf("발급되지 않은 코드 확인 결과: %s (%s)", result, unknown)
+	}
+
+	if result := validate("쿠폰!@#"); result != coupon.CodeCheckResult_CODE_CHECK_RESULT_MALFORMED {
+		t.Errorf("형식이 틀린 코드 확인 결과: %s", result)
+	}
+}
+
+// streamOf 메시지 목록을 ImportCouponCodes 의 receive 함수로 변환
+func streamOf(messages ...*coupon.ImportCouponCodesRequest) func() (*coupon.ImportCouponCodesRequest, error) {
+	return func() (*coupon.ImportCouponCodesRequest, error) {
+		if len(messages) == 0 {
+			return nil, io.EOF
+		}
+		next := messages[0]
+		messages = messages[1:]
+		return next, nil
+	}
+}
+
+// 코드 목록을 여러 메시지로 나눠 등록하면 총 수량이 코드 수가 되고, 등록 순서대로 발급
+func TestImportCouponCodes(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+
+	created, err := svc.CreateCampaign(ctx, &coupon.CreateCampaignRequest{
+		Name:       "제휴 카드",
+		StartTime:  time.Now().Unix(),
+		CodeFormat: coupon.CodeFormat_CODE_FORMAT_POOL,
+	})
+	if err != nil || created.Campaign == nil {
+		t.Fatalf("POOL 캠페인 생성 실패: %v, %v", err, created)
+	}
+	campaignID := created.Campaign.CampaignId
+
+	// 형식 오류(검사 문자 모양, 10자 초과)와 요청 안 중복이 있으면 아무것도 등록하지 않음
+	resp, err := svc.ImportCouponCodes(ctx, streamOf(
+		&coupon.ImportCouponCodesRequest{CampaignId: campaignID, Codes: []string{"CARD-0001", "CARD1가"}},
+		&coupon.ImportCouponCodesRequest{Codes: []string{"CARD-0001", "CARD-000000002"}},
+	))
+	if !errors.Is(err, model.ErrInvalidArgument) || resp.Success || !slices.Equal(resp.RejectedCodes, []string{"CARD1가", "CARD-0001", "CARD-000000002"}) {
+		t.Fatalf("잘못된 코드가 거절되지 않음: %v, %v", resp, err)
+	}
+
+	resp, err = svc.ImportCouponCodes(ctx, streamOf(
+		&coupon.ImportCouponCodesRequest{CampaignId: campaignID, Codes: []string{"CARD-0001", "CARD-0002"}},
+		&coupon.ImportCouponCodesRequest{Codes: []string{"CARD-0003"}},
+	))
+	if err != nil || !resp.Success || resp.ImportedCount != 3 || resp.Campaign.TotalQuantity != 3 {
+		t.Fatalf("코드 등록 실패: %v, %v", resp, err)
+	}
+
+	for i, expected := range []string{"CARD0001", "CARD0002", "CARD0003"} {
+		issued, err := svc.IssueCoupon(ctx, &coupon.IssueCouponRequest{CampaignId: campaignID, UserId: fmt.Sprintf("user-%d", i)})
+		if err != nil || !issued.Success || issued.Coupon.CouponCode != expected {
+			t.Fatalf("%d번째 발급이 %s 가 아님: %v, %v", i, expected, issued, err)
+		}
+	}
+
+	if issued, err := svc.IssueCoupon(ctx, &coupon.IssueCouponRequest{CampaignId: campaignID, UserId: "user-9"}); issued.Success || !errors.Is(err, model.ErrCampaignSoldOut) {
+		t.Errorf("등록한 코드 수보다 많이 발급됨: %v, %v", issued, err)
+	}
+
+	// 총 수량은 코드 수로만 정해짐
+	updated, _ := svc.UpdateCampaign(ctx, &coupon.UpdateCampaignRequest{
+		CampaignId:      campaignID,
+		ExpectedVersion: resp.Campaign.Version,
+		TotalQuantity:   proto.Int32(10),
+	})
+	if updated.Success {
+		t.Error("POOL 캠페인의 총 수량이 직접 변경됨")
+	}
+}
+
+// 자모가 나뉜 한글, 전각 문자, 공백/하이픈이 섞인 입력도 같은 쿠폰으로 취급
+func TestCouponCodeNormalization(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+
+	created, err := svc.CreateCampaign(ctx, &coupon.CreateCampaignRequest{
+		Name:       "제휴 카드",
+		StartTime:  time.Now().Unix(),
+		CodeFormat: coupon.CodeFormat_CODE_FORMAT_POOL,
+	})
+	if err != nil || created.Campaign == nil {
+		t.Fatalf("POOL 캠페인 생성 실패: %v, %v", err, created)
+	}
+	campaignID := created.Campaign.CampaignId
+
+	// 정규형이 같은 코드는 요청 안 중복
+	resp, _ := svc.ImportCouponCodes(ctx, streamOf(&coupon.ImportCouponCodesRequest{
+		CampaignId: campaignID, Codes: []string{"가나AB12", norm.NFD.String("가나-ＡＢ１２")},
+	}))
+	if resp.Success {
+		t.Fatal("정규형이 같은 코드가 중복 없이 등록됨")
+	}
+
+	resp, _ = svc.ImportCouponCodes(ctx, streamOf(&coupon.ImportCouponCodesRequest{
+		CampaignId: campaignID, Codes: []string{norm.NFD.String("가나 ＡＢ１２")},
+	}))
+	if !resp.Success {
+		t.Fatalf("코드 등록 실패: %v", resp)
+	}
+
+	issued, err := svc.IssueCoupon(ctx, &coupon.IssueCouponRequest{CampaignId: campaignID, UserId: "user-1"})
+	if err != nil || !issued.Success || issued.Coupon.CouponCode != "가나AB12" {
+		t.Fatalf("정규형 코드로 발급되지 않음: %v, %v", issued, err)
+	}
+
+	for _, input := range []string{"가나AB12", " 가나-AB-12 ", "가나ＡＢ１２", norm.NFD.String("가나\u200bAB12")} {
+		validated, _ := svc.ValidateCoupon(ctx, &coupon.ValidateCouponRequest{CouponCode: input})
+		if validated.Result != coupon.CodeCheckResult_CODE_CHECK_RESULT_VALID {
+			t.Errorf("%q 확인 결과: %s", input, validated.Result)
+		}
+	}
+
+	redeemed, _ := svc.RedeemCoupon(ctx, &coupon.RedeemCouponRequest{
+		CouponCode: norm.NFD.String("가나－ＡＢ１２"), UserId: "user-1", OrderId: "order-1",
+	})
+	if !redeemed.Success {
+		t.Errorf("정규형이 같은 코드로 사용되지 않음: %v", redeemed)
+	}
+
+	if validated, _ := svc.ValidateCoupon(ctx, &coupon.ValidateCouponRequest{CouponCode: " - "}); validated.Message == "" {
+		t.Error("공백과 하이픈만 있는 코드가 허용됨")
+	}
+}
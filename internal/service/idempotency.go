@@ -0,0 +1,110 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"coupon-issuance-system/gen/coupon"
+	"coupon-issuance-system/internal/clock"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultIdempotencyRetention 멱등성 키 기본 보관 기간
+const DefaultIdempotencyRetention = 10 * time.Minute
+
+// idempotencyEntry 멱등성 키 하나에 대한 처리 상태
+// done 이 닫히기 전까지는 최초 요청이 처리 중(in-flight)인 상태
+type idempotencyEntry struct {
+	done      chan struct{}
+	response  *coupon.IssueCouponResponse // nil 이면 최초 요청이 저장소 오류로 끝난 것 (재시도 허용)
+	err       error                       // 최초 요청이 발급 불가 같은 도메인 오류로 끝났으면 응답과 함께 돌려줄 오류
+	expiresAt time.Time
+}
+
+// IdempotencyStore IssueCoupon 재시도 요청의 응답을 보관하는 저장소
+type IdempotencyStore struct {
+	entries   map[string]*idempotencyEntry
+	mutex     sync.Mutex
+	retention time.Duration
+	lastSweep time.Time
+	clock     clock.Clock
+}
+
+// NewIdempotencyStore 생성자. retention 동안 같은 키의 요청은 최초 응답을 돌려받음
+func NewIdempotencyStore(retention time.Duration, clk clock.Clock) *IdempotencyStore {
+	if retention <= 0 {
+		retention = DefaultIdempotencyRetention
+	}
+
+	return &IdempotencyStore{
+		entries:   make(map[string]*idempotencyEntry),
+		retention: retention,
+		clock:     clk,
+	}
+}
+
+// begin 키에 대한 처리를 시작
+// owner 가 true 면 호출자가 실제 발급을 수행하고 complete/abort 를 반드시 호출해야 함
+// owner 가 false 면 이미 처리 중이거나 처리된 요청이므로 entry.done 을 기다리면 됨
+func (s *IdempotencyStore) begin(key string) (entry *idempotencyEntry, owner bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.clock.Now()
+	s.evictExpired(now)
+
+	if existing, exists := s.entries[key]; exists && !existing.expired(now) {
+		return existing, false
+	}
+
+	entry = &idempotencyEntry{done: make(chan struct{})}
+	s.entries[key] = entry
+	return entry, true
+}
+
+// complete 최초 요청의 응답(과 도메인 오류)을 기록하고 대기 중인 재시도 요청들을 깨움
+func (s *IdempotencyStore) complete(entry *idempotencyEntry, response *coupon.IssueCouponResponse, err error) {
+	s.mutex.Lock()
+	entry.response = proto.Clone(response).(*coupon.IssueCouponResponse)
+	entry.err = err
+	entry.expiresAt = s.clock.Now().Add(s.retention)
+	s.mutex.Unlock()
+
+	close(entry.done)
+}
+
+// abort 최초 요청이 저장소 오류로 끝난 경우 키를 해제하여 이후 재시도가 다시 발급을 시도할 수 있게 함
+func (s *IdempotencyStore) abort(key string, entry *idempotencyEntry) {
+	s.mutex.Lock()
+	if s.entries[key] == entry {
+		delete(s.entries, key)
+	}
+	s.mutex.Unlock()
+
+	close(entry.done)
+}
+
+// evictExpired 보관 기간이 지난 응답 제거 (mutex 를 잡은 상태에서 호출)
+// 매 요청마다 전체를 훑지 않도록 보관 기간의 1/10 간격으로만 정리
+func (s *IdempotencyStore) evictExpired(now time.Time) {
+	if now.Sub(s.lastSweep) < s.retention/10 {
+		return
+	}
+	s.lastSweep = now
+
+	for key, entry := range s.entries {
+		if entry.expired(now) {
+			delete(s.entries, key)
+		}
+	}
+}
+
+// expired 처리 완료 후 보관 기간이 지났는지 여부 (처리 중인 요청은 만료되지 않음)
+func (e *idempotencyEntry) expired(now time.Time) bool {
+	return e.response != nil && now.After(e.expiresAt)
+}
+
+// idempotencyScope 키 충돌로 다른 사용자의 쿠폰이 반환되지 않도록 캠페인/사용자 단위로 키를 구분
+func idempotencyScope(req *coupon.IssueCouponRequest) string {
+	return req.CampaignId + "\x00" + req.UserId + "\x00" + req.IdempotencyKey
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"math/bits"
+)
+
+/*
+# 키 기반 쿠폰 코드 생성 (형식 보존 암호화)
+
+캠페인의 코드 형식(CodeGenerator)이 만들 수 있는 코드는 [0, N) (N = Keyspace) 의 값과 일대일로 대응함 (Encode)
+캠페인 코드 순번 → 값 변환에 비밀 키와 캠페인 ID 로 정해지는 순열을 사용하므로
+  - 같은 캠페인 안에서 순번이 다르면 코드도 반드시 다름 (저장소 조회나 재시도 없이 유일)
+  - 키를 모르면 코드로부터 순번, 발급 수량, 다음 코드를 추측할 수 없음
+
+순열: 2^(2h) >= N 인 가장 작은 h 에 대해 h 비트씩 나눈 Feistel 네트워크 (라운드 함수 HMAC-SHA256)
+범위를 벗어난 값이 나오면 범위 안에 들어올 때까지 순열을 반복 적용 (cycle walking. 평균 2회 미만)
+
+서로 다른 캠페인은 순열이 다르므로 prefix 와 형식이 같으면 드물게 코드가 겹칠 수 있음
+이 경우 저장소가 발급과 같은 원자적 단위에서 감지하고 다음 순번으로 넘어감 (CouponRepository.IssueSequencedCoupon)
+*/
+
+const (
+	minCodeKeyLength = 16 // 비밀 키 최소 길이 (바이트)
+	feistelRounds    = 8
+)
+
+// errCodeSpaceExhausted 캠페인의 코드를 모두 사용함 (순번이 코드 수 이상)
+var errCodeSpaceExhausted = errors.New("쿠폰 코드 순번이 범위를 벗어났습니다")
+
+// KeyedCodeGenerator 캠페인 코드 순번을 키 기반 순열로 섞어 쿠폰 코드를 만드는 생성기
+type KeyedCodeGenerator struct {
+	key []byte
+}
+
+// NewKeyedCodeGenerator 생성자. 키가 바뀌면 같은 순번이 다른 코드가 되므로 운영 중에는 키를 바꾸면 안 됨
+func NewKeyedCodeGenerator(key []byte) (*KeyedCodeGenerator, error) {
+	if len(key) < minCodeKeyLength {
+		return nil, fmt.Errorf("쿠폰 코드 키는 %d바이트 이상이어야 합니다", minCodeKeyLength)
+	}
+
+	return &KeyedCodeGenerator{key: append([]byte(nil), key...)}, nil
+}
+
+// CodeAt 캠페인의 sequence 번째 코드 (사용 가능한 순번은 0 ~ format.Keyspace()-1)
+func (g *KeyedCodeGenerator) CodeAt(campaignID string, format CodeGenerator, sequence int64) (string, error) {
+	capacity := format.Keyspace()
+	if sequence < 0 || sequence >= capacity {
+		return "", fmt.Errorf("%w (순번: %d, 코드 수: %d)", errCodeSpaceExhausted, sequence, capacity)
+	}
+
+	return format.Encode(int64(g.permute(campaignID, uint64(sequence), uint64(capacity)))), nil
+}
+
+// permute [0, capacity) 위의 순열. 캠페인마다 다른 순열이 되도록 캠페인 ID 를 라운드 함수에 섞음
+func (g *KeyedCodeGenerator) permute(campaignID string, value, capacity uint64) uint64 {
+	halfBits := uint((bits.Len64(capacity-1) + 1) / 2)
+	mac := hmac.New(sha256.New, g.key)
+
+	for {
+		value = g.feistel(mac, campaignID, value, halfBits)
+		if value < capacity {
+			return value
+		}
+	}
+}
+
+func (g *KeyedCodeGenerator) feistel(mac hash.Hash, campaignID string, value uint64, halfBits uint) uint64 {
+	mask := uint64(1)<<halfBits - 1
+	left, right := value>>halfBits, value&mask
+
+	var block [9]byte
+	for round := 0; round < feistelRounds; round++ {
+		// 고정 길이 필드를 앞에 두어 (라운드, 값, 캠페인 ID) 조합이 모호하지 않게 함
+		block[0] = byte(round)
+		binary.BigEndian.PutUint64(block[1:], right)
+
+		mac.Reset()
+		mac.Write(block[:])
+		mac.Write([]byte(campaignID))
+
+		left, right = right, left^(binary.BigEndian.Uint64(mac.Sum(nil))&mask)
+	}
+
+	return left<<halfBits | right
+}
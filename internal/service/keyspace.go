@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"coupon-issuance-system/gen/coupon"
+	"coupon-issuance-system/internal/repository"
+)
+
+/*
+# 코드 공간(keyspace) 사용률
+
+캠페인 생성 시 코드 형식이 만들 수 있는 코드 수(keyspace)와, 같은 코드 공간을 쓰는 다른 캠페인이 차지한 코드 수를 비교
+  - 같은 코드 공간: 형식, prefix, 길이 등이 같아 만들 수 있는 코드 집합이 같은 캠페인 (예: 이름이 "가"로 시작하는 HANGUL 캠페인)
+  - 차지한 코드 수: 진행 중이거나 시작 전인 캠페인은 총 수량(앞으로 발급될 수 있음), 끝난 캠페인은 발급 수량
+  - 형식이 달라 일부만 겹치는 경우(예: 같은 prefix 의 NUMERIC 과 ALPHANUMERIC)는 계산하지 않음
+
+사용률 u = (차지한 코드 수 + 요청 수량) / keyspace
+  - u > maxKeyspaceUsage     : 거절. 무작위 생성은 코드 하나에 평균 1/(1-u) 번 시도하고, 10번 모두 충돌할 확률이 u^10 으로 커짐
+  - u > warnKeyspaceUsage    : 생성하되 경고
+
+충돌 확률 추정: 빈 자리가 m 개 차 있는 공간에 n 개를 무작위로 발급할 때 기대 충돌 수 E = (n·m + n(n-1)/2) / N,
+한 번 이상 충돌할 확률 ≈ 1 - e^(-E)
+*/
+
+const (
+	maxKeyspaceUsage  = 0.5
+	warnKeyspaceUsage = 0.1
+
+	keyspaceScanPageSize = 100
+)
+
+// keyspaceEstimate 캠페인 코드 공간 사용률 추정
+type keyspaceEstimate struct {
+	Keyspace             int64
+	Reserved             int64 // 같은 코드 공간을 쓰는 다른 캠페인이 차지한 코드 수
+	Usage                float64
+	CollisionProbability float64
+}
+
+// codeSpaceKey 생성기가 만들 수 있는 코드 집합의 식별자
+func codeSpaceKey(generator CodeGenerator) string {
+	if keyed, ok := generator.(interface{ spaceKey() string }); ok {
+		return keyed.spaceKey()
+	}
+	return fmt.Sprintf("%T", generator)
+}
+
+// estimateKeyspace 캠페인 목록을 훑어 같은 코드 공간을 쓰는 캠페인의 코드 수를 모아 사용률 계산
+// 캠페인 수에 비례하는 비용이지만 캠페인 생성 때만 호출됨
+func (s *CouponService) estimateKeyspace(
+	ctx context.Context,
+	codeGenerator CodeGenerator,
+	totalQuantity int32,
+) (keyspaceEstimate, error) {
+
+	key := codeSpaceKey(codeGenerator)
+	estimate := keyspaceEstimate{Keyspace: codeGenerator.Keyspace()}
+
+	var after *repository.CampaignCursor
+	for {
+		campaigns, hasMore, err := s.campaignRepo.List(ctx, repository.CampaignFilter{}, after, keyspaceScanPageSize)
+		if err != nil {
+			return keyspaceEstimate{}, err
+		}
+
+		for _, campaign := range campaigns {
+			other, err := s.codeGen.ForCampaign(campaign)
+			if err != nil || codeSpaceKey(other) != key {
+				continue
+			}
+			estimate.Reserved += reservedCodes(campaign)
+		}
+
+		if !hasMore {
+			break
+		}
+		last := campaigns[len(campaigns)-1]
+		after = &repository.CampaignCursor{CreatedAt: last.CreatedAt, CampaignID: last.CampaignId}
+	}
+
+	n := float64(totalQuantity)
+	m := float64(estimate.Reserved)
+	space := float64(estimate.Keyspace)
+
+	estimate.Usage = (m + n) / space
+	estimate.CollisionProbability = 1 - math.Exp(-(n*m+n*(n-1)/2)/space)
+	return estimate, nil
+}
+
+// reservedCodes 캠페인이 코드 공간에서 차지한(또는 앞으로 차지할 수 있는) 코드 수
+func reservedCodes(campaign *coupon.Campaign) int64 {
+	switch campaign.Status {
+	case coupon.CampaignStatus_COMPLETED, coupon.CampaignStatus_ENDED, coupon.CampaignStatus_CANCELLED:
+		return int64(campaign.IssuedQuantity)
+	default:
+		return int64(campaign.TotalQuantity)
+	}
+}
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"expvar"
+)
+
+// 쿠폰 코드 발급 지표 (expvar: GET /debug/vars 의 "coupon_code")
+//   - attempts   : 저장소에 코드 예약을 시도한 횟수
+//   - collisions : 이미 사용 중인 코드라 다시 생성한 횟수
+//   - exhausted  : 재생성 한도를 넘겨 발급에 실패한 횟수
+//
+// collisions / attempts 가 높아지면 코드 공간이 부족하다는 신호
+var codeMetrics = expvar.NewMap("coupon_code")
+
+const (
+	metricCodeAttempts   = "attempts"
+	metricCodeCollisions = "collisions"
+	metricCodeExhausted  = "exhausted"
+)
+
+// CodeMetricValue 코드 발급 지표의 현재 값 (테스트/진단용)
+func CodeMetricValue(name string) int64 {
+	if v, ok := codeMetrics.Get(name).(*expvar.Int); ok {
+		return v.Value()
+	}
+	return 0
+}
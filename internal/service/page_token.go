@@ -0,0 +1,129 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"coupon-issuance-system/gen/coupon"
+	"coupon-issuance-system/internal/repository"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+
+	defaultStreamBatchSize = 100
+	maxStreamBatchSize     = 1000
+)
+
+var errInvalidPageToken = errors.New("유효하지 않은 페이지 토큰입니다")
+
+// campaignPageToken ListCampaigns 의 페이지 토큰 내용
+// 클라이언트에게는 base64 로 인코딩된 불투명한 문자열로만 노출됨
+type campaignPageToken struct {
+	CreatedAt  int64  `json:"c"`
+	CampaignID string `json:"i"`
+	Filter     string `json:"f"` // 조회 조건 지문. 다른 조건으로 토큰을 재사용하는 것을 막음
+}
+
+func encodeCampaignPageToken(cursor repository.CampaignCursor, filter repository.CampaignFilter) string {
+	raw, _ := json.Marshal(campaignPageToken{
+		CreatedAt:  cursor.CreatedAt,
+		CampaignID: cursor.CampaignID,
+		Filter:     campaignFilterFingerprint(filter),
+	})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// decodeCampaignPageToken 빈 토큰이면 nil 커서(첫 페이지)를 반환
+func decodeCampaignPageToken(token string, filter repository.CampaignFilter) (*repository.CampaignCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errInvalidPageToken
+	}
+
+	var decoded campaignPageToken
+	if err := json.Unmarshal(raw, &decoded); err != nil || decoded.CampaignID == "" {
+		return nil, errInvalidPageToken
+	}
+
+	if decoded.Filter != campaignFilterFingerprint(filter) {
+		return nil, fmt.Errorf("%w: 조회 조건이 이전 요청과 다릅니다", errInvalidPageToken)
+	}
+
+	return &repository.CampaignCursor{CreatedAt: decoded.CreatedAt, CampaignID: decoded.CampaignID}, nil
+}
+
+func campaignFilterFingerprint(filter repository.CampaignFilter) string {
+	statuses := slices.Clone(filter.Statuses)
+	slices.Sort(statuses)
+
+	raw, _ := json.Marshal(struct {
+		Statuses []coupon.CampaignStatus
+		Name     string
+		From, To int64
+	}{statuses, filter.NameContains, filter.StartTimeFrom, filter.StartTimeTo})
+
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:8])
+}
+
+// couponPageToken ListIssuedCoupons 의 페이지 토큰 내용
+// 메모리 저장소의 커서는 예전 offset 과 같은 값이므로 예전 토큰도 그대로 이어서 조회됨
+type couponPageToken struct {
+	CampaignID string                  `json:"i"`
+	After      repository.CouponCursor `json:"o"`
+}
+
+func encodeCouponPageToken(campaignID string, after repository.CouponCursor) string {
+	raw, _ := json.Marshal(couponPageToken{CampaignID: campaignID, After: after})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// decodeCouponPageToken 빈 토큰이면 0(첫 페이지)을 반환
+func decodeCouponPageToken(token string, campaignID string) (repository.CouponCursor, error) {
+	if token == "" {
+		return 0, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, errInvalidPageToken
+	}
+
+	var decoded couponPageToken
+	if err := json.Unmarshal(raw, &decoded); err != nil || decoded.After < 0 {
+		return 0, errInvalidPageToken
+	}
+
+	if decoded.CampaignID != campaignID {
+		return 0, fmt.Errorf("%w: 다른 캠페인의 토큰입니다", errInvalidPageToken)
+	}
+
+	return decoded.After, nil
+}
+
+// effectivePageSize 0 이면 기본값 사용 (범위 검증은 validation 에서 수행)
+func effectivePageSize(pageSize int32) int {
+	if pageSize == 0 {
+		return defaultPageSize
+	}
+	return int(pageSize)
+}
+
+// effectiveStreamBatchSize 0 이면 기본값 사용 (범위 검증은 validation 에서 수행)
+func effectiveStreamBatchSize(batchSize int32) int {
+	if batchSize == 0 {
+		return defaultStreamBatchSize
+	}
+	return int(batchSize)
+}
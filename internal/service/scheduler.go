@@ -0,0 +1,355 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"log"
+	"sync"
+	"time"
+
+	"coupon-issuance-system/gen/coupon"
+	"coupon-issuance-system/internal/clock"
+	"coupon-issuance-system/internal/model"
+	"coupon-issuance-system/internal/repository"
+)
+
+/*
+# 캠페인 스케줄러
+
+조회/발급 시점의 lazy evaluation 만으로는 아무도 조회하지 않는 캠페인의 저장된 상태가 바뀌지 않고,
+시작 순간에 캐시 예열이나 구독자 알림을 할 곳이 없음. 스케줄러는 캠페인별 다음 시간 이벤트를 기억해 두었다가
+정확히 그 시각에 캠페인 락(Modify) 안에서 상태를 전이/저장하고 TransitionHook 을 호출함
+
+- 시간 이벤트: WAITING → 시작 시간, ACTIVE → 종료 시간 (주입된 시계 기준, 밀리초 단위)
+- 소진/관리자 변경: 서비스가 Notify 로 알려주면 바로 다시 확인 (발급이 몰려도 pendingCheckInterval 에 한 번만 확인)
+- 다른 서버가 만든 캠페인: RescanInterval 마다 진행 중인 캠페인 목록을 다시 읽어 새 캠페인을 추가
+- 전이는 저장소(WAL/PostgreSQL)에 기록되고, 이미 다른 곳(발급, 다른 서버의 스케줄러)에서 바뀐 상태는 기록 없이 훅만 호출
+*/
+
+// DefaultSchedulerRescanInterval 진행 중인 캠페인 목록을 다시 읽는 기본 주기
+const DefaultSchedulerRescanInterval = time.Minute
+
+const (
+	pendingCheckInterval = 50 * time.Millisecond // Notify 받은 캠페인을 다시 확인하는 최소 간격 (실제 시간)
+	checkRetryDelay      = time.Second           // 저장소 오류로 확인하지 못한 캠페인을 다시 시도하는 간격
+	schedulerPageSize    = 100
+)
+
+// 스케줄러 지표 (expvar: GET /debug/vars 의 "campaign_scheduler")
+//   - transitions : 스케줄러가 상태를 전이하고 저장한 횟수
+//   - hooks       : 전이 훅을 호출한 횟수 (다른 곳에서 바뀐 상태 포함)
+//   - errors      : 저장소 오류로 캠페인을 확인하지 못한 횟수
+var schedulerMetrics = expvar.NewMap("campaign_scheduler")
+
+// errNoTransition 확인 결과 바꿀 상태가 없으면 Modify 를 저장 없이 끝내기 위한 오류
+var errNoTransition = errors.New("상태 전이 없음")
+
+// CampaignTransition 캠페인 상태 전이 하나
+type CampaignTransition struct {
+	Campaign *coupon.Campaign // 전이 후 캠페인
+	From     coupon.CampaignStatus
+	To       coupon.CampaignStatus
+	At       time.Time // 전이 시각 (시작/종료는 지정된 시간, 그 밖에는 확인한 시각)
+}
+
+// TransitionHook 상태 전이 직후 호출 (캐시 예열, 구독자 알림 등)
+// 스케줄러 고루틴에서 순서대로 호출되므로 오래 걸리는 작업은 훅 안에서 고루틴으로 넘겨야 다음 이벤트가 늦지 않음
+type TransitionHook func(ctx context.Context, transition CampaignTransition)
+
+// SchedulerOptions 캠페인 스케줄러 설정
+type SchedulerOptions struct {
+	RescanInterval time.Duration // 진행 중인 캠페인 목록을 다시 읽는 주기 (기본값 1분)
+}
+
+// scheduledCampaign 스케줄러가 마지막으로 확인한 캠페인 상태와 다음 시간 이벤트
+type scheduledCampaign struct {
+	status coupon.CampaignStatus
+	due    time.Time // 0 이면 시간 이벤트 없음 (PAUSED, COMPLETED)
+}
+
+// CampaignScheduler 캠페인 시작/종료 시각에 맞춰 상태를 전이하고 훅을 호출하는 백그라운드 작업
+type CampaignScheduler struct {
+	campaignRepo repository.CampaignRepository
+	clock        clock.Clock
+	opts         SchedulerOptions
+	hooks        []TransitionHook
+
+	campaigns map[string]*scheduledCampaign // 스케줄러 고루틴만 접근
+
+	pendingMutex sync.Mutex
+	pending      map[string]struct{} // Notify 받은 캠페인
+	wake         chan struct{}
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func NewCampaignScheduler(campaignRepo repository.CampaignRepository, clk clock.Clock, opts SchedulerOptions) *CampaignScheduler {
+	if opts.RescanInterval <= 0 {
+		opts.RescanInterval = DefaultSchedulerRescanInterval
+	}
+
+	return &CampaignScheduler{
+		campaignRepo: campaignRepo,
+		clock:        clk,
+		opts:         opts,
+		campaigns:    make(map[string]*scheduledCampaign),
+		pending:      make(map[string]struct{}),
+		wake:         make(chan struct{}, 1),
+		stop:         make(chan struct{}),
+		done:         make(chan struct{}),
+	}
+}
+
+// OnTransition 상태 전이 훅 등록 (Start 전에만 호출)
+func (s *CampaignScheduler) OnTransition(hook TransitionHook) {
+	s.hooks = append(s.hooks, hook)
+}
+
+// Start 저장된 모든 캠페인을 읽어 확인 대상으로 올리고 스케줄러 고루틴을 시작
+// 서버가 내려가 있는 동안 지난 시작/종료 시간은 첫 확인에서 바로 전이됨
+func (s *CampaignScheduler) Start(ctx context.Context) error {
+	if err := s.scan(ctx, repository.CampaignFilter{}); err != nil {
+		return err
+	}
+
+	go s.run()
+	return nil
+}
+
+// Close 스케줄러 고루틴을 멈추고 끝날 때까지 기다림
+func (s *CampaignScheduler) Close() {
+	close(s.stop)
+	<-s.done
+}
+
+// Notify 캠페인 상태가 바뀌었을 수 있음을 알림 (생성, 수정, 발급 등). 스케줄러가 없으면(nil) 아무 일도 하지 않음
+func (s *CampaignScheduler) Notify(campaignID string) {
+	if s == nil {
+		return
+	}
+
+	s.pendingMutex.Lock()
+	s.pending[campaignID] = struct{}{}
+	s.pendingMutex.Unlock()
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *CampaignScheduler) run() {
+	defer close(s.done)
+
+	ctx := context.Background()
+	lastRescan := time.Now()
+	var lastPendingCheck time.Time
+
+	for {
+		// 시각을 읽기 전에 채널을 받아 두어야 그 사이에 시계가 옮겨져도 놓치지 않음
+		clockChanged := clock.ChangedOf(s.clock)
+
+		if time.Since(lastPendingCheck) >= pendingCheckInterval && s.checkPending(ctx) {
+			lastPendingCheck = time.Now()
+		}
+		s.checkDue(ctx)
+
+		if time.Since(lastRescan) >= s.opts.RescanInterval {
+			// 아직 시간 이벤트가 남은 캠페인만 조회 (이미 아는 캠페인은 건너뜀)
+			// 다른 서버에서 만들어져 다음 조회 전에 끝난 캠페인은 훅이 호출되지 않지만, 상태는 조회 시점에 lazy evaluation 으로 반영됨
+			filter := repository.CampaignFilter{Statuses: []coupon.CampaignStatus{
+				coupon.CampaignStatus_WAITING, coupon.CampaignStatus_ACTIVE,
+			}}
+			if err := s.scan(ctx, filter); err != nil {
+				log.Printf("스케줄러 캠페인 목록 조회 실패: %v", err)
+			}
+			lastRescan = time.Now()
+			continue
+		}
+
+		wait := s.opts.RescanInterval - time.Since(lastRescan)
+		if due, exists := s.nextDue(); exists {
+			wait = min(wait, due.Sub(s.clock.Now()))
+		}
+		if s.hasPending() {
+			wait = min(wait, pendingCheckInterval-time.Since(lastPendingCheck))
+		}
+
+		timer := time.NewTimer(max(wait, 0))
+		select {
+		case <-s.stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+		case <-s.wake:
+			timer.Stop()
+		case <-clockChanged:
+			timer.Stop()
+		}
+	}
+}
+
+// scan 조건에 맞는 캠페인 중 아직 모르는 캠페인을 확인 대상으로 올림
+func (s *CampaignScheduler) scan(ctx context.Context, filter repository.CampaignFilter) error {
+	var after *repository.CampaignCursor
+	for {
+		campaigns, hasMore, err := s.campaignRepo.List(ctx, filter, after, schedulerPageSize)
+		if err != nil {
+			return err
+		}
+
+		s.pendingMutex.Lock()
+		for _, campaign := range campaigns {
+			if _, known := s.campaigns[campaign.CampaignId]; !known {
+				s.pending[campaign.CampaignId] = struct{}{}
+			}
+		}
+		s.pendingMutex.Unlock()
+
+		if !hasMore || len(campaigns) == 0 {
+			return nil
+		}
+		last := campaigns[len(campaigns)-1]
+		after = &repository.CampaignCursor{CreatedAt: last.CreatedAt, CampaignID: last.CampaignId}
+	}
+}
+
+func (s *CampaignScheduler) hasPending() bool {
+	s.pendingMutex.Lock()
+	defer s.pendingMutex.Unlock()
+	return len(s.pending) > 0
+}
+
+// checkPending Notify 받은 캠페인을 모두 확인. 확인할 캠페인이 없었으면 false
+func (s *CampaignScheduler) checkPending(ctx context.Context) bool {
+	s.pendingMutex.Lock()
+	pending := s.pending
+	s.pending = make(map[string]struct{})
+	s.pendingMutex.Unlock()
+
+	for campaignID := range pending {
+		s.check(ctx, campaignID)
+	}
+	return len(pending) > 0
+}
+
+// checkDue 시간 이벤트가 된 캠페인을 확인
+func (s *CampaignScheduler) checkDue(ctx context.Context) {
+	now := s.clock.Now()
+	for campaignID, scheduled := range s.campaigns {
+		if !scheduled.due.IsZero() && !now.Before(scheduled.due) {
+			s.check(ctx, campaignID)
+		}
+	}
+}
+
+// nextDue 가장 가까운 시간 이벤트
+func (s *CampaignScheduler) nextDue() (time.Time, bool) {
+	var next time.Time
+	for _, scheduled := range s.campaigns {
+		if !scheduled.due.IsZero() && (next.IsZero() || scheduled.due.Before(next)) {
+			next = scheduled.due
+		}
+	}
+	return next, !next.IsZero()
+}
+
+// check 캠페인 락 안에서 현재 시각 기준 상태로 전이하고, 마지막으로 확인한 상태와 다르면 훅 호출
+func (s *CampaignScheduler) check(ctx context.Context, campaignID string) {
+	now := s.clock.Now()
+
+	var stored coupon.CampaignStatus
+	var current *coupon.Campaign
+	modified, err := s.campaignRepo.Modify(ctx, campaignID, func(c *model.Campaign) error {
+		stored = c.Status
+		c.UpdateStatusIfNeeded(now)
+		current = c.Campaign // 전이가 없으면 저장되지 않는 복사본
+		if c.Status == stored {
+			return errNoTransition
+		}
+		return nil
+	})
+
+	switch {
+	case errors.Is(err, errNoTransition):
+
+	case errors.Is(err, model.ErrCampaignNotFound):
+		delete(s.campaigns, campaignID)
+		return
+
+	case err != nil:
+		schedulerMetrics.Add("errors", 1)
+		log.Printf("스케줄러 캠페인 확인 실패. campaign: %s, err: %v", campaignID, err)
+		if scheduled, known := s.campaigns[campaignID]; known {
+			scheduled.due = now.Add(checkRetryDelay)
+		} else {
+			s.campaigns[campaignID] = &scheduledCampaign{status: stored, due: now.Add(checkRetryDelay)}
+		}
+		return
+
+	default:
+		current = modified
+		schedulerMetrics.Add("transitions", 1)
+		log.Printf("스케줄러 캠페인 상태 전이. campaign: %s, before: %s, after: %s", campaignID, stored, current.Status)
+	}
+
+	// 처음 확인하는 캠페인은 저장된 상태 기준, 이미 아는 캠페인은 마지막으로 확인한 상태 기준 (발급으로 소진된 경우 등)
+	from := stored
+	if scheduled, known := s.campaigns[campaignID]; known {
+		from = scheduled.status
+	}
+
+	s.schedule(current)
+
+	if current.Status != from {
+		s.fire(ctx, CampaignTransition{
+			Campaign: current,
+			From:     from,
+			To:       current.Status,
+			At:       transitionTime(model.NewCampaign(current), from, now),
+		})
+	}
+}
+
+// schedule 캠페인의 다음 시간 이벤트 기록. 종료/취소된 캠페인은 더 이상 추적하지 않음
+func (s *CampaignScheduler) schedule(campaign *coupon.Campaign) {
+	domainCampaign := model.NewCampaign(campaign)
+	scheduled := &scheduledCampaign{status: campaign.Status}
+
+	switch campaign.Status {
+	case coupon.CampaignStatus_WAITING:
+		scheduled.due = time.UnixMilli(domainCampaign.StartTimeMillis())
+
+	case coupon.CampaignStatus_ACTIVE:
+		if endTime := domainCampaign.EndTimeMillis(); endTime > 0 {
+			scheduled.due = time.UnixMilli(endTime)
+		}
+
+	case coupon.CampaignStatus_ENDED, coupon.CampaignStatus_CANCELLED:
+		delete(s.campaigns, campaign.CampaignId)
+		return
+	}
+
+	s.campaigns[campaign.CampaignId] = scheduled
+}
+
+// fire 등록된 훅을 순서대로 호출
+func (s *CampaignScheduler) fire(ctx context.Context, transition CampaignTransition) {
+	for _, hook := range s.hooks {
+		schedulerMetrics.Add("hooks", 1)
+		hook(ctx, transition)
+	}
+}
+
+// transitionTime 시작/종료는 지정된 시간, 그 밖의 전이는 확인한 시각
+func transitionTime(campaign *model.Campaign, from coupon.CampaignStatus, now time.Time) time.Time {
+	switch {
+	case from == coupon.CampaignStatus_WAITING && campaign.Status == coupon.CampaignStatus_ACTIVE:
+		return time.UnixMilli(campaign.StartTimeMillis())
+	case campaign.Status == coupon.CampaignStatus_ENDED:
+		return time.UnixMilli(campaign.EndTimeMillis())
+	}
+	return now
+}
@@ -2,15 +2,32 @@
 package service
 
 import (
+	"fmt"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"coupon-issuance-system/gen/coupon"
+	"coupon-issuance-system/internal/model"
+)
+
+const (
+	// maxIdempotencyKeyLength 멱등성 키 최대 길이
+	maxIdempotencyKeyLength = 128
+	// maxCampaignNameLength 캠페인 이름 최대 글자 수
+	maxCampaignNameLength = 100
+	// maxTotalQuantity 캠페인 하나의 최대 발급 수량
+	maxTotalQuantity = 10_000_000
+	// maxStartTimeHorizon 캠페인 시작 시간으로 정할 수 있는 가장 먼 미래
+	maxStartTimeHorizon = 365 * 24 * time.Hour
 )
 
 // ValidationResult 검증 결과
+// 필드 값 검증은 첫 위반에서 멈추지 않고 모든 위반 사항을 Violations 에 모음 (Message 는 위반 내용을 이어 붙인 문구)
 type ValidationResult struct {
-	IsValid bool
-	Message string
+	IsValid    bool
+	Message    string
+	Violations []*coupon.FieldViolation
 }
 
 // Valid 검증 성공
@@ -18,47 +35,335 @@ func Valid() ValidationResult {
 	return ValidationResult{IsValid: true}
 }
 
-// Invalid 검증 실패
+// Invalid 검증 실패 (특정 필드의 문제가 아닌 경우)
 func Invalid(message string) ValidationResult {
 	return ValidationResult{IsValid: false, Message: message}
 }
 
+// Err 검증 실패를 필드별 위반 사항이 담긴 INVALID_ARGUMENT 도메인 오류로 변환 (성공이면 nil)
+func (v ValidationResult) Err() error {
+	if v.IsValid {
+		return nil
+	}
+	return model.InvalidArgument(v.Message, v.Violations...)
+}
+
+// violations 요청 검증 중 발견한 필드별 위반 사항
+type violations []*coupon.FieldViolation
+
+// add field 는 요청 메시지의 proto 필드 이름
+func (v *violations) add(field, description string) {
+	*v = append(*v, &coupon.FieldViolation{Field: field, Description: description})
+}
+
+// result 위반 사항이 없으면 Valid, 있으면 모든 위반 사항을 담은 검증 실패
+func (v violations) result() ValidationResult {
+	if len(v) == 0 {
+		return Valid()
+	}
+
+	descriptions := make([]string, len(v))
+	for i, violation := range v {
+		descriptions[i] = violation.Description
+	}
+
+	return ValidationResult{
+		IsValid:    false,
+		Message:    strings.Join(descriptions, ", "),
+		Violations: v,
+	}
+}
+
+// checkCampaignName 캠페인 이름 (생성, 수정 공통)
+func (v *violations) checkCampaignName(name string) {
+	if name == "" {
+		v.add("name", "캠페인 이름은 필수입니다")
+	} else if utf8.RuneCountInString(name) > maxCampaignNameLength {
+		v.add("name", fmt.Sprintf("캠페인 이름은 %d자 이하여야 합니다", maxCampaignNameLength))
+	}
+}
+
+// checkTotalQuantity 발급 수량 (생성, 수정 공통)
+func (v *violations) checkTotalQuantity(totalQuantity int32) {
+	if totalQuantity <= 0 {
+		v.add("total_quantity", "발급 수량은 1개 이상이어야 합니다")
+	} else if totalQuantity > maxTotalQuantity {
+		v.add("total_quantity", fmt.Sprintf("발급 수량은 %d개 이하여야 합니다", maxTotalQuantity))
+	}
+}
+
+// requestTime 초 단위 필드(name)와 밀리초 필드(name_ms)로 받은 시각 (Unix 밀리초)과 위반 사항에 쓸 필드명
+// 밀리초 필드를 보냈으면 그 값을 쓰고, 둘 다 보냈으면 같은 시각이어야 함
+func (v *violations) requestTime(name string, seconds, millis int64) (int64, string) {
+	if millis == 0 {
+		return seconds * 1000, name
+	}
+	if seconds != 0 && model.Seconds(millis) != seconds {
+		v.add(name+"_ms", fmt.Sprintf("%s, %s_ms 는 같은 시각이어야 합니다", name, name))
+	}
+	return millis, name + "_ms"
+}
+
+// checkStartTime 시작 시간(Unix 밀리초, 생성/수정 공통). 현재 이후이면서 maxStartTimeHorizon 이내
+// 초 단위로 보내는 클라이언트가 현재 시각을 보내도 거절되지 않도록 현재 초의 시작부터 허용
+func (v *violations) checkStartTime(field string, startTime int64, now time.Time) {
+	if startTime < now.Truncate(time.Second).UnixMilli() {
+		v.add(field, "시작 시간은 현재 시간 이후여야 합니다")
+	} else if startTime > now.Add(maxStartTimeHorizon).UnixMilli() {
+		v.add(field, fmt.Sprintf("시작 시간은 %d일 이내여야 합니다", int(maxStartTimeHorizon/(24*time.Hour))))
+	}
+}
+
 // validateCreateCampaignRequest 캠페인 생성 요청 검증
-func validateCreateCampaignRequest(req *coupon.CreateCampaignRequest) ValidationResult {
-	if req.Name == "" {
-		return Invalid("캠페인 이름은 필수입니다")
+func validateCreateCampaignRequest(req *coupon.CreateCampaignRequest, now time.Time) ValidationResult {
+	var v violations
+
+	v.checkCampaignName(req.Name)
+
+	if req.CodeFormat == coupon.CodeFormat_CODE_FORMAT_POOL {
+		if req.TotalQuantity != 0 {
+			v.add("total_quantity", "POOL 형식 캠페인의 발급 수량은 등록한 코드 수로 정해지므로 지정할 수 없습니다")
+		}
+		if req.CodeLength != 0 {
+			v.add("code_length", "POOL 형식 캠페인은 코드 길이를 지정할 수 없습니다")
+		}
+		if req.CodePrefix != "" {
+			v.add("code_prefix", "POOL 형식 캠페인은 prefix 를 지정할 수 없습니다")
+		}
+		if req.CodeTemplate != "" {
+			v.add("code_template", "POOL 형식 캠페인은 템플릿을 지정할 수 없습니다")
+		}
+		if req.CodeCheckChar {
+			v.add("code_check_char", "POOL 형식 캠페인은 검사 문자를 사용할 수 없습니다")
+		}
+	} else {
+		v.checkTotalQuantity(req.TotalQuantity)
 	}
 
-	if req.TotalQuantity <= 0 {
-		return Invalid("발급 수량은 1개 이상이어야 합니다")
+	if req.MaxPerUser < 0 {
+		v.add("max_per_user", "사용자당 발급 수량은 0(기본값) 이상이어야 합니다")
+	}
+
+	startTime, startField := v.requestTime("start_time", req.StartTime, req.StartTimeMs)
+	v.checkStartTime(startField, startTime, now)
+
+	if endTime, endField := v.requestTime("end_time", req.EndTime, req.EndTimeMs); endTime != 0 && endTime <= startTime {
+		v.add(endField, "종료 시간은 시작 시간 이후여야 합니다")
+	}
+
+	return v.result()
+}
+
+// validateCodeKeyspace 코드 형식으로 만들 수 있는 코드 수가 총 발급 수량 이상인지 검증
+func validateCodeKeyspace(codeGenerator CodeGenerator, totalQuantity int32) ValidationResult {
+	var v violations
+
+	if codeGenerator.Keyspace() < int64(totalQuantity) {
+		v.add("total_quantity", fmt.Sprintf("쿠폰 코드 형식으로 만들 수 있는 코드 수(%d개)가 발급 수량보다 적습니다", codeGenerator.Keyspace()))
+	}
+
+	return v.result()
+}
+
+// validateKeyspaceUsage 사용률이 안전 범위를 넘으면 거절, 경고 범위면 경고 메시지 반환
+func validateKeyspaceUsage(estimate keyspaceEstimate) (ValidationResult, string) {
+	var v violations
+
+	if estimate.Usage > maxKeyspaceUsage {
+		v.add("total_quantity", fmt.Sprintf(
+			"발급 수량이 쿠폰 코드 공간에 비해 너무 많습니다 (코드 수 %d개 중 다른 캠페인 %d개 사용, 사용률 %.1f%% > %.0f%%). 코드 길이나 형식을 바꿔 주세요",
+			estimate.Keyspace, estimate.Reserved, estimate.Usage*100, maxKeyspaceUsage*100))
+		return v.result(), ""
+	}
+
+	if estimate.Usage > warnKeyspaceUsage {
+		return Valid(), fmt.Sprintf(
+			"쿠폰 코드 공간 사용률이 %.1f%% 입니다. 발급 중 코드 재생성이 잦아질 수 있습니다 (충돌 확률 추정 %.2f%%)",
+			estimate.Usage*100, estimate.CollisionProbability*100)
+	}
+
+	return Valid(), ""
+}
+
+// validateImportCouponCodesRequest 코드 등록 스트림의 메시지 하나 검증
+// campaignID 는 첫 메시지에서 정해진 캠페인 ID (첫 메시지면 빈 값), received 는 앞 메시지까지 받은 코드 수
+func validateImportCouponCodesRequest(req *coupon.ImportCouponCodesRequest, campaignID string, received int) ValidationResult {
+	var v violations
+
+	if campaignID == "" && req.CampaignId == "" {
+		v.add("campaign_id", "첫 메시지에 캠페인 ID가 필요합니다")
+	}
+
+	if campaignID != "" && req.CampaignId != "" && req.CampaignId != campaignID {
+		v.add("campaign_id", "한 요청에서는 하나의 캠페인에만 코드를 등록할 수 있습니다")
+	}
+
+	if received+len(req.Codes) > maxImportCodes {
+		v.add("codes", fmt.Sprintf("한 번에 등록할 수 있는 코드는 %d개 이하입니다", maxImportCodes))
+	}
+
+	return v.result()
+}
+
+// validateImportedCodes 코드 등록 스트림을 끝까지 받은 뒤 검증
+// rejected 는 형식이 맞지 않거나 중복되어 거절된 코드 수, accepted 는 등록할 코드 수
+func validateImportedCodes(campaignID string, rejected, accepted int) ValidationResult {
+	var v violations
+
+	if campaignID == "" {
+		// 메시지를 하나도 받지 못함
+		v.add("campaign_id", "첫 메시지에 캠페인 ID가 필요합니다")
+		return v.result()
 	}
 
-	now := time.Now().Unix()
-	if req.StartTime < now {
-		return Invalid("시작 시간은 현재 시간 이후여야 합니다")
+	if rejected > 0 {
+		v.add("codes", fmt.Sprintf("등록할 수 없는 코드가 %d개 있습니다 (공백과 '-' 를 빼고 최대 %d자 영문/숫자/한글, 요청 안 중복 불가)", rejected, maxCouponCodeLength))
+	} else if accepted == 0 {
+		v.add("codes", "등록할 코드가 없습니다")
 	}
 
-	return Valid()
+	return v.result()
 }
 
 // validateIssueCouponRequest 쿠폰 발급 요청 검증
 func validateIssueCouponRequest(req *coupon.IssueCouponRequest) ValidationResult {
+	var v violations
+
 	if req.CampaignId == "" {
-		return Invalid("캠페인 ID는 필수입니다")
+		v.add("campaign_id", "캠페인 ID는 필수입니다")
 	}
 
 	if req.UserId == "" {
-		return Invalid("사용자 ID는 필수입니다")
+		v.add("user_id", "사용자 ID는 필수입니다")
+	}
+
+	if len(req.IdempotencyKey) > maxIdempotencyKeyLength {
+		v.add("idempotency_key", fmt.Sprintf("멱등성 키는 %d자 이하여야 합니다", maxIdempotencyKeyLength))
 	}
 
-	return Valid()
+	return v.result()
 }
 
 // validateGetCampaignRequest 캠페인 조회 요청 검증
 func validateGetCampaignRequest(req *coupon.GetCampaignRequest) ValidationResult {
+	return validateCampaignID(req.CampaignId)
+}
+
+// validateRedeemCouponRequest 쿠폰 사용 요청 검증
+func validateRedeemCouponRequest(req *coupon.RedeemCouponRequest) ValidationResult {
+	var v violations
+
+	if model.NormalizeCouponCode(req.CouponCode) == "" {
+		v.add("coupon_code", "쿠폰 코드는 필수입니다")
+	}
+
+	if req.UserId == "" {
+		v.add("user_id", "사용자 ID는 필수입니다")
+	}
+
+	if req.OrderId == "" {
+		v.add("order_id", "주문 ID는 필수입니다")
+	}
+
+	return v.result()
+}
+
+// validateValidateCouponRequest 쿠폰 코드 확인 요청 검증
+func validateValidateCouponRequest(req *coupon.ValidateCouponRequest) ValidationResult {
+	var v violations
+
+	if model.NormalizeCouponCode(req.CouponCode) == "" {
+		v.add("coupon_code", "쿠폰 코드는 필수입니다")
+	}
+
+	return v.result()
+}
+
+// validateListCampaignsRequest 캠페인 목록 조회 요청 검증
+func validateListCampaignsRequest(req *coupon.ListCampaignsRequest) ValidationResult {
+	var v violations
+
+	if req.PageSize < 0 || req.PageSize > maxPageSize {
+		v.add("page_size", "페이지 크기는 0(기본값)~100 사이여야 합니다")
+	}
+
+	if req.StartTimeFrom != 0 && req.StartTimeTo != 0 && req.StartTimeFrom > req.StartTimeTo {
+		v.add("start_time_to", "시작 시간 범위가 올바르지 않습니다")
+	}
+
+	return v.result()
+}
+
+// validateListIssuedCouponsRequest 발급 쿠폰 목록 조회 요청 검증
+func validateListIssuedCouponsRequest(req *coupon.ListIssuedCouponsRequest) ValidationResult {
+	var v violations
+
 	if req.CampaignId == "" {
-		return Invalid("캠페인 ID는 필수입니다")
+		v.add("campaign_id", "캠페인 ID는 필수입니다")
+	}
+
+	if req.PageSize < 0 || req.PageSize > maxPageSize {
+		v.add("page_size", "페이지 크기는 0(기본값)~100 사이여야 합니다")
+	}
+
+	return v.result()
+}
+
+// validateStreamIssuedCouponsRequest 발급 쿠폰 스트리밍 요청 검증
+func validateStreamIssuedCouponsRequest(req *coupon.StreamIssuedCouponsRequest) ValidationResult {
+	var v violations
+
+	if req.CampaignId == "" {
+		v.add("campaign_id", "캠페인 ID는 필수입니다")
+	}
+
+	if req.BatchSize < 0 || req.BatchSize > maxStreamBatchSize {
+		v.add("batch_size", "묶음 크기는 0(기본값)~1000 사이여야 합니다")
+	}
+
+	return v.result()
+}
+
+// validateCampaignID 캠페인 ID 만 받는 요청(조회, 일시 중지/재개/취소) 검증
+func validateCampaignID(campaignID string) ValidationResult {
+	var v violations
+
+	if campaignID == "" {
+		v.add("campaign_id", "캠페인 ID는 필수입니다")
+	}
+
+	return v.result()
+}
+
+// validateUpdateCampaignRequest 캠페인 수정 요청 검증 (상태에 따른 규칙은 도메인 모델에서 검증)
+func validateUpdateCampaignRequest(req *coupon.UpdateCampaignRequest, now time.Time) ValidationResult {
+	var v violations
+
+	if req.CampaignId == "" {
+		v.add("campaign_id", "캠페인 ID는 필수입니다")
+	}
+
+	if req.ExpectedVersion <= 0 {
+		v.add("expected_version", "수정할 캠페인의 version 은 필수입니다")
+	}
+
+	// 변경할 항목이 하나도 없으면 첫 번째 변경 가능 필드(name)에 위반 사항으로 남김
+	if req.Name == nil && req.StartTime == nil && req.StartTimeMs == nil && req.TotalQuantity == nil {
+		v.add("name", "변경할 항목(name, start_time, start_time_ms, total_quantity)이 없습니다")
+	}
+
+	if req.Name != nil {
+		v.checkCampaignName(*req.Name)
+	}
+
+	if req.StartTime != nil || req.StartTimeMs != nil {
+		startTime, startField := v.requestTime("start_time", req.GetStartTime(), req.GetStartTimeMs())
+		v.checkStartTime(startField, startTime, now)
+	}
+
+	if req.TotalQuantity != nil {
+		v.checkTotalQuantity(*req.TotalQuantity)
 	}
 
-	return Valid()
+	return v.result()
 }
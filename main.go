@@ -1,14 +1,25 @@
 package main
 
 import (
+	"context"
+	"database/sql"
+	"errors"
+	"expvar"
+	"flag"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
+	"coupon-issuance-system/gen/coupon"
 	"coupon-issuance-system/gen/coupon/couponconnect"
+	"coupon-issuance-system/internal/clock"
 	"coupon-issuance-system/internal/handler"
 	"coupon-issuance-system/internal/repository"
 	"coupon-issuance-system/internal/service"
+	"github.com/redis/go-redis/v9"
 )
 
 /*
@@ -33,12 +44,123 @@ ConnectRPC Handler      ← 여기서 프로토콜 구분!
 - ConnectRPC: "어떤 프로토콜로 파싱할지" 결정
 */
 
+// shutdownTimeout 종료 신호를 받은 뒤 진행 중인 요청이 끝나기를 기다리는 최대 시간
+const shutdownTimeout = 30 * time.Second
+
 func main() {
-	// 의존성 주입
-	campaignRepo := repository.NewMemoryCampaignRepository()
-	couponRepo := repository.NewMemoryCouponRepository(campaignRepo)
+	dataDir := flag.String("data-dir", "", "WAL/스냅샷 저장 경로 (비어 있으면 메모리에만 저장)")
+	fsync := flag.String("fsync", "always", "WAL fsync 정책 (always, interval, none)")
+	postgresDSN := flag.String("postgres-dsn", "", "PostgreSQL 연결 문자열 (지정하면 -data-dir 대신 PostgreSQL 사용, 여러 서버가 공유 가능)")
+	redisAddr := flag.String("redis-addr", "", "Redis 주소 (지정하면 쿠폰 발급을 Redis Lua 스크립트로 처리하고 쿠폰은 위 저장소에 비동기로 저장)")
+	codeKey := flag.String("code-key", os.Getenv("COUPON_CODE_KEY"), "쿠폰 코드 비밀 키 (16바이트 이상. 지정하면 무작위 코드 대신 캠페인 순번 기반 코드 사용, 기본값 $COUPON_CODE_KEY)")
+	clockOffset := flag.Duration("clock-offset", 0, "서버 시계를 실제 시간보다 지정한 만큼 앞당기거나(양수) 늦춤(음수) (오픈 리허설용, 예: 72h)")
+	clockStart := flag.String("clock-start", "", "서버 시계를 지정한 시각(RFC3339)부터 흐르게 함 (오픈 리허설용, -clock-offset 보다 우선)")
+	clockControl := flag.Bool("clock-control", false, "/debug/clock 으로 서버 시계를 조회/변경할 수 있게 함 (운영 환경에서는 사용 금지)")
+	flag.Parse()
+
+	// 시계 (모든 계층이 같은 시계를 기준으로 캠페인 시작/종료와 발급/사용 시각을 판단)
+	var clk clock.Clock = clock.System()
+	var adjustableClock *clock.Adjustable
+	if *clockOffset != 0 || *clockStart != "" || *clockControl {
+		adjustableClock = clock.NewAdjustable(clock.System(), *clockOffset)
+		if *clockStart != "" {
+			start, err := time.Parse(time.RFC3339, *clockStart)
+			if err != nil {
+				log.Fatalf("-clock-start 는 RFC3339 형식이어야 합니다: %v", err)
+			}
+			adjustableClock.Set(start)
+		}
+		clk = adjustableClock
+		log.Printf("⚠️ 조정된 서버 시계 사용. 현재 시각: %s, 실제 시간과의 차이: %s", clk.Now().Format(time.RFC3339), adjustableClock.Offset())
+	}
+
+	// 의존성 주입 (서비스는 저장소 인터페이스에만 의존)
+	var campaignRepo repository.CampaignRepository
+	var couponRepo repository.CouponRepository
+
+	// 종료 시 정리할 자원 (log.Fatal 은 defer 를 실행하지 않으므로 서버 종료 후 직접 닫음)
+	var db *sql.DB
+	var fileStore *repository.FileStore
+
+	switch {
+	case *postgresDSN != "":
+		var err error
+		db, err = repository.OpenPostgres(context.Background(), *postgresDSN)
+		if err != nil {
+			log.Fatalf("PostgreSQL 저장소 초기화 실패: %v", err)
+		}
+
+		campaignRepo = repository.NewPostgresCampaignRepository(db, clk)
+		couponRepo = repository.NewPostgresCouponRepository(db, clk)
+
+	case *dataDir != "":
+		syncPolicy, err := repository.ParseSyncPolicy(*fsync)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fileStore, err = repository.OpenFileStore(repository.FileStoreOptions{Dir: *dataDir, SyncPolicy: syncPolicy, Clock: clk})
+		if err != nil {
+			log.Fatalf("파일 저장소 복구 실패: %v", err)
+		}
+
+		campaignRepo = fileStore.CampaignRepository()
+		couponRepo = fileStore.CouponRepository()
+
+	default:
+		memoryCampaignRepo := repository.NewMemoryCampaignRepository(clk)
+		campaignRepo = memoryCampaignRepo
+		couponRepo = repository.NewMemoryCouponRepository(memoryCampaignRepo)
+	}
+
+	var redisClient *redis.Client
+	var redisCouponRepo *repository.RedisCouponRepository
+	if *redisAddr != "" {
+		redisClient = redis.NewClient(&redis.Options{Addr: *redisAddr})
+
+		var err error
+		redisCouponRepo, err = repository.NewRedisCouponRepository(context.Background(), redisClient, campaignRepo, couponRepo, repository.RedisIssuerOptions{Clock: clk})
+		if err != nil {
+			log.Fatalf("Redis 발급 저장소 초기화 실패: %v", err)
+		}
+
+		campaignRepo = redisCouponRepo.CampaignRepository() // 캠페인 수정도 Redis 발급 수량 기준으로 검증
+		couponRepo = redisCouponRepo
+	}
+
 	codeGenerator := service.NewCouponCodeGenerator()
-	couponService := service.NewCouponService(campaignRepo, couponRepo, codeGenerator)
+
+	var keyedCodeGenerator *service.KeyedCodeGenerator
+	if *codeKey != "" {
+		var err error
+		keyedCodeGenerator, err = service.NewKeyedCodeGenerator([]byte(*codeKey))
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	// 캠페인 시작/종료/소진 시각에 맞춰 상태를 전이하는 스케줄러
+	scheduler := service.NewCampaignScheduler(campaignRepo, clk, service.SchedulerOptions{})
+	scheduler.OnTransition(func(ctx context.Context, t service.CampaignTransition) {
+		log.Printf("캠페인 상태 전이. ID: %s, %s → %s, 시각: %s", t.Campaign.CampaignId, t.From, t.To, t.At.Format(time.RFC3339Nano))
+
+		// 시작 시각에 Redis 발급 상태를 미리 초기화해 오픈 직후 몰리는 요청이 초기화를 기다리지 않도록 함
+		if redisCouponRepo != nil && t.To == coupon.CampaignStatus_ACTIVE {
+			if err := redisCouponRepo.Warm(ctx, t.Campaign); err != nil {
+				log.Printf("Redis 발급 상태 예열 실패. ID: %s, err: %v", t.Campaign.CampaignId, err)
+			}
+		}
+	})
+	if redisCouponRepo != nil {
+		// 다른 서버에서 발급된 쿠폰도 outbox 를 처리하는 서버가 알려 소진(COMPLETED) 전이가 늦어지지 않도록 함
+		redisCouponRepo.OnIssuedChange(scheduler.Notify)
+	}
+	if err := scheduler.Start(context.Background()); err != nil {
+		log.Fatalf("캠페인 스케줄러 시작 실패: %v", err)
+	}
+
+	idempotencyStore := service.NewIdempotencyStore(service.DefaultIdempotencyRetention, clk)
+	couponService := service.NewCouponService(campaignRepo, couponRepo, codeGenerator, keyedCodeGenerator, idempotencyStore, clk, scheduler)
 
 	// ConnectRPC 핸들러 등록
 	couponHandler := handler.NewCouponServiceHandler(couponService)
@@ -48,6 +170,14 @@ func main() {
 	mux := http.NewServeMux()     // ServeMux = HTTP 라우터 (Spring의 @RequestMapping 같은 역할)
 	mux.Handle(path, httpHandler) // ServeMux는 여러 URL 경로를 각각 다른 핸들러로 분배하는 라우터 역할을 함
 
+	// 운영 지표 (쿠폰 코드 충돌 수 등)
+	mux.Handle("/debug/vars", expvar.Handler())
+
+	// 리허설용 시계 조작 (GET 조회, POST ?set=<RFC3339> 또는 ?advance=<duration>)
+	if *clockControl {
+		mux.Handle("/debug/clock", clock.NewHandler(adjustableClock))
+	}
+
 	// 미들웨어 추가
 	finalHandler := corsMiddleware(loggingMiddleware(mux))
 
@@ -63,8 +193,59 @@ func main() {
 		Handler: finalHandler, // h2c 제거
 	}
 
+	// SIGINT/SIGTERM 을 받으면 새 요청을 멈추고 진행 중인 요청이 끝난 뒤 자원을 정리
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.ListenAndServe()
+	}()
 	log.Printf("🚀 쿠폰 발급 서버 시작: http://localhost:8080")
-	log.Fatal(server.ListenAndServe())
+
+	exitCode := 0
+	select {
+	case <-ctx.Done():
+		log.Printf("종료 신호를 받았습니다. 진행 중인 요청을 마무리합니다")
+	case err := <-serveErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("HTTP 서버 오류: %v", err)
+			exitCode = 1
+		}
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("HTTP 서버 종료 실패: %v", err)
+	}
+	cancel()
+
+	// 요청 처리가 끝난 뒤 만든 순서의 역순으로 정리
+	//   - 스케줄러: 상태 전이 중단
+	//   - Redis: outbox 에 남은 발급 결과를 영속 저장소에 모두 반영
+	//   - 파일 저장소: 마지막 WAL fsync (interval 정책에서 아직 fsync 되지 않은 기록 포함)
+	scheduler.Close()
+	if redisCouponRepo != nil {
+		if err := redisCouponRepo.Close(context.Background()); err != nil {
+			log.Printf("Redis outbox 반영 실패: %v", err)
+			exitCode = 1
+		}
+		redisClient.Close()
+	}
+	if fileStore != nil {
+		if err := fileStore.Close(); err != nil {
+			log.Printf("파일 저장소 종료 실패: %v", err)
+			exitCode = 1
+		}
+	}
+	if db != nil {
+		db.Close()
+	}
+
+	log.Printf("쿠폰 발급 서버 종료")
+	if exitCode != 0 {
+		os.Exit(exitCode)
+	}
 }
 
 // CORS 미들웨어
@@ -72,7 +253,7 @@ func corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Connect-Protocol-Version, Connect-Timeout-Ms")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Connect-Protocol-Version, Connect-Timeout-Ms, Idempotency-Key")
 
 		if r.Method == "OPTIONS" {
 			w.WriteHeader(http.StatusOK)